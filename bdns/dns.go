@@ -2,6 +2,7 @@ package bdns
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -147,9 +148,9 @@ var (
 
 // Client queries for DNS records
 type Client interface {
-	LookupTXT(context.Context, string) (txts []string, err error)
-	LookupHost(context.Context, string) ([]net.IP, error)
-	LookupCAA(context.Context, string) ([]*dns.CAA, string, error)
+	LookupTXT(context.Context, string) (txts []string, dnssec DNSSECState, err error)
+	LookupHost(context.Context, string) ([]net.IP, DNSSECState, error)
+	LookupCAA(context.Context, string) ([]*dns.CAA, string, DNSSECState, error)
 }
 
 // impl represents a client that talks to an external resolver
@@ -160,6 +161,8 @@ type impl struct {
 	maxTries                 int
 	clk                      clock.Clock
 	log                      blog.Logger
+	// validator is nil unless DNSSEC validation is enabled.
+	validator *validator
 
 	queryTime         *prometheus.HistogramVec
 	totalLookupTime   *prometheus.HistogramVec
@@ -174,20 +177,23 @@ type exchanger interface {
 }
 
 // New constructs a new DNS resolver object that utilizes the
-// provided list of DNS servers for resolution.
+// provided list of DNS servers for resolution. The tlsConfig is used for
+// servers reached by DNS over TLS or DNS over HTTPS, to provide a client
+// certificate or trusted roots. If nil, no client certificate is sent and the
+// system roots are trusted. If trustAnchors is non-empty, responses are
+// validated with DNSSEC, building the chain of trust from those DS records;
+// otherwise DNSSEC validation is left to the servers.
 func New(
 	readTimeout time.Duration,
 	servers ServerProvider,
+	tlsConfig *tls.Config,
+	trustAnchors []*dns.DS,
 	stats prometheus.Registerer,
 	clk clock.Clock,
 	maxTries int,
 	log blog.Logger,
 ) Client {
-	dnsClient := new(dns.Client)
-
-	// Set timeout for underlying net.Conn
-	dnsClient.ReadTimeout = readTimeout
-	dnsClient.Net = "udp"
+	dnsClient := newTransportExchanger(readTimeout, tlsConfig)
 
 	queryTime := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -221,7 +227,7 @@ func New(
 	)
 	stats.MustRegister(queryTime, totalLookupTime, timeoutCounter, idMismatchCounter)
 
-	return &impl{
+	client := &impl{
 		dnsClient:                dnsClient,
 		servers:                  servers,
 		allowRestrictedAddresses: false,
@@ -233,6 +239,10 @@ func New(
 		idMismatchCounter:        idMismatchCounter,
 		log:                      log,
 	}
+	if len(trustAnchors) > 0 {
+		client.validator = newValidator(trustAnchors, client.exchangeOne, clk)
+	}
+	return client
 }
 
 // NewTest constructs a new DNS resolver object that utilizes the
@@ -241,19 +251,21 @@ func New(
 func NewTest(
 	readTimeout time.Duration,
 	servers ServerProvider,
+	tlsConfig *tls.Config,
+	trustAnchors []*dns.DS,
 	stats prometheus.Registerer,
 	clk clock.Clock,
 	maxTries int,
 	log blog.Logger) Client {
-	resolver := New(readTimeout, servers, stats, clk, maxTries, log)
+	resolver := New(readTimeout, servers, tlsConfig, trustAnchors, stats, clk, maxTries, log)
 	resolver.(*impl).allowRestrictedAddresses = true
 	return resolver
 }
 
 // exchangeOne performs a single DNS exchange with a randomly chosen server
 // out of the server list, returning the response, time, and error (if any).
-// We assume that the upstream resolver requests and validates DNSSEC records
-// itself.
+// Unless DNSSEC validation is enabled, we assume that the upstream resolver
+// requests and validates DNSSEC records itself.
 func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype uint16) (resp *dns.Msg, err error) {
 	m := new(dns.Msg)
 	// Set question type
@@ -268,7 +280,15 @@ func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype u
 	// Tell the resolver that we're willing to receive responses up to 4096 bytes.
 	// This happens sometimes when there are a very large number of CAA records
 	// present.
-	m.SetEdns0(4096, false)
+	if dnsClient.validator != nil {
+		// Ask for the RRSIG, NSEC and NSEC3 records we need to validate the
+		// response ourselves, and for the response to be passed along even if
+		// the resolver's own validation fails, so that we can tell why.
+		m.SetEdns0(4096, true)
+		m.CheckingDisabled = true
+	} else {
+		m.SetEdns0(4096, false)
+	}
 
 	servers, err := dnsClient.servers.Addrs()
 	if err != nil {
@@ -394,18 +414,36 @@ type dnsResp struct {
 	err error
 }
 
+// validate checks the DNSSEC validity of r, the response to a query for
+// hostname and qtype, if validation is enabled. Bogus responses and failures
+// to validate are returned as errors.
+func (dnsClient *impl) validate(ctx context.Context, hostname string, qtype uint16, r *dns.Msg) (DNSSECState, error) {
+	if dnsClient.validator == nil {
+		return DNSSECUnchecked, nil
+	}
+	state, err := dnsClient.validator.validate(ctx, hostname, qtype, r)
+	if err != nil {
+		return state, &Error{qtype, hostname, err, -1}
+	}
+	return state, nil
+}
+
 // LookupTXT sends a DNS query to find all TXT records associated with
 // the provided hostname which it returns along with the returned
-// DNS authority section.
-func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string, error) {
+// DNS authority section, and the DNSSEC state of the response.
+func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string, DNSSECState, error) {
 	var txt []string
 	dnsType := dns.TypeTXT
 	r, err := dnsClient.exchangeOne(ctx, hostname, dnsType)
 	if err != nil {
-		return nil, &Error{dnsType, hostname, err, -1}
+		return nil, DNSSECUnchecked, &Error{dnsType, hostname, err, -1}
 	}
 	if r.Rcode != dns.RcodeSuccess {
-		return nil, &Error{dnsType, hostname, nil, r.Rcode}
+		return nil, DNSSECUnchecked, &Error{dnsType, hostname, nil, r.Rcode}
+	}
+	state, err := dnsClient.validate(ctx, hostname, dnsType, r)
+	if err != nil {
+		return nil, state, err
 	}
 
 	for _, answer := range r.Answer {
@@ -416,7 +454,17 @@ func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string
 		}
 	}
 
-	return txt, err
+	return txt, state, nil
+}
+
+// IsReservedIP reports whether the given IP address falls within one of the
+// private or otherwise reserved IPv4 or IPv6 ranges that the resolver refuses
+// to return.
+func IsReservedIP(ip net.IP) bool {
+	if ip.To4() != nil {
+		return isPrivateV4(ip)
+	}
+	return isPrivateV6(ip)
 }
 
 func isPrivateV4(ip net.IP) bool {
@@ -437,36 +485,42 @@ func isPrivateV6(ip net.IP) bool {
 	return false
 }
 
-func (dnsClient *impl) lookupIP(ctx context.Context, hostname string, ipType uint16) ([]dns.RR, error) {
+func (dnsClient *impl) lookupIP(ctx context.Context, hostname string, ipType uint16) ([]dns.RR, DNSSECState, error) {
 	resp, err := dnsClient.exchangeOne(ctx, hostname, ipType)
 	if err != nil {
-		return nil, &Error{ipType, hostname, err, -1}
+		return nil, DNSSECUnchecked, &Error{ipType, hostname, err, -1}
 	}
 	if resp.Rcode != dns.RcodeSuccess {
-		return nil, &Error{ipType, hostname, nil, resp.Rcode}
+		return nil, DNSSECUnchecked, &Error{ipType, hostname, nil, resp.Rcode}
+	}
+	state, err := dnsClient.validate(ctx, hostname, ipType, resp)
+	if err != nil {
+		return nil, state, err
 	}
-	return resp.Answer, nil
+	return resp.Answer, state, nil
 }
 
 // LookupHost sends a DNS query to find all A and AAAA records associated with
 // the provided hostname. This method assumes that the external resolver will
 // chase CNAME/DNAME aliases and return relevant records. It will retry
 // requests in the case of temporary network errors. It returns an error if
-// both the A and AAAA lookups fail or are empty, but succeeds otherwise.
-func (dnsClient *impl) LookupHost(ctx context.Context, hostname string) ([]net.IP, error) {
+// both the A and AAAA lookups fail or are empty, but succeeds otherwise. The
+// DNSSEC state returned is the weaker of those of the lookups which succeeded.
+func (dnsClient *impl) LookupHost(ctx context.Context, hostname string) ([]net.IP, DNSSECState, error) {
 	var recordsA, recordsAAAA []dns.RR
+	var stateA, stateAAAA DNSSECState
 	var errA, errAAAA error
 	var wg sync.WaitGroup
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		recordsA, errA = dnsClient.lookupIP(ctx, hostname, dns.TypeA)
+		recordsA, stateA, errA = dnsClient.lookupIP(ctx, hostname, dns.TypeA)
 	}()
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		recordsAAAA, errAAAA = dnsClient.lookupIP(ctx, hostname, dns.TypeAAAA)
+		recordsAAAA, stateAAAA, errAAAA = dnsClient.lookupIP(ctx, hostname, dns.TypeAAAA)
 	}()
 	wg.Wait()
 
@@ -506,25 +560,42 @@ func (dnsClient *impl) LookupHost(ctx context.Context, hostname string) ([]net.I
 		// branching. We don't use ProblemDetails and SubProblemDetails here, because
 		// this error will get wrapped in a DNSError and further munged by higher
 		// layers in the stack.
-		return nil, fmt.Errorf("%w; %s", errA, errAAAA)
+		return nil, DNSSECUnchecked, fmt.Errorf("%w; %s", errA, errAAAA)
 	}
 
-	return append(addrsA, addrsAAAA...), nil
+	var state DNSSECState
+	switch {
+	case errA != nil:
+		state = stateAAAA
+	case errAAAA != nil:
+		state = stateA
+	default:
+		state = WeakerDNSSECState(stateA, stateAAAA)
+	}
+	return append(addrsA, addrsAAAA...), state, nil
 }
 
 // LookupCAA sends a DNS query to find all CAA records associated with
 // the provided hostname and the complete dig-style RR `response`. This
 // response is quite verbose, however it's only populated when the CAA
-// response is non-empty.
-func (dnsClient *impl) LookupCAA(ctx context.Context, hostname string) ([]*dns.CAA, string, error) {
+// response is non-empty. The DNSSEC state of the response is also returned.
+func (dnsClient *impl) LookupCAA(ctx context.Context, hostname string) ([]*dns.CAA, string, DNSSECState, error) {
 	dnsType := dns.TypeCAA
 	r, err := dnsClient.exchangeOne(ctx, hostname, dnsType)
 	if err != nil {
-		return nil, "", &Error{dnsType, hostname, err, -1}
+		return nil, "", DNSSECUnchecked, &Error{dnsType, hostname, err, -1}
 	}
 
 	if r.Rcode == dns.RcodeServerFailure {
-		return nil, "", &Error{dnsType, hostname, nil, r.Rcode}
+		return nil, "", DNSSECUnchecked, &Error{dnsType, hostname, nil, r.Rcode}
+	}
+
+	var state DNSSECState
+	if r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError {
+		state, err = dnsClient.validate(ctx, hostname, dnsType, r)
+		if err != nil {
+			return nil, "", state, err
+		}
 	}
 
 	var CAAs []*dns.CAA
@@ -537,7 +608,7 @@ func (dnsClient *impl) LookupCAA(ctx context.Context, hostname string) ([]*dns.C
 	if len(CAAs) > 0 {
 		response = r.String()
 	}
-	return CAAs, response, nil
+	return CAAs, response, state, nil
 }
 
 // logDNSError logs the provided err result from making a query for hostname to
@@ -0,0 +1,763 @@
+package bdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+)
+
+// DNSSECState is the outcome of validating a DNS response with DNSSEC.
+type DNSSECState string
+
+const (
+	// DNSSECUnchecked means the response wasn't validated, because the client
+	// has no DNSSEC trust anchors configured.
+	DNSSECUnchecked DNSSECState = ""
+	// DNSSECSecure means the response was validated along a chain of trust from
+	// a trust anchor.
+	DNSSECSecure DNSSECState = "secure"
+	// DNSSECInsecure means the response was proven to come from an unsigned
+	// part of the DNS, or from outside the trust anchor's zone.
+	DNSSECInsecure DNSSECState = "insecure"
+	// DNSSECBogus means the response should have been signed, but couldn't be
+	// validated. Lookups never return bogus data; they return an error instead.
+	DNSSECBogus DNSSECState = "bogus"
+)
+
+// dnssecStateRank orders DNSSEC states by the assurance they provide.
+var dnssecStateRank = map[DNSSECState]int{
+	DNSSECBogus:     0,
+	DNSSECUnchecked: 1,
+	DNSSECInsecure:  2,
+	DNSSECSecure:    3,
+}
+
+// String returns the state, or "unchecked" for DNSSECUnchecked.
+func (s DNSSECState) String() string {
+	if s == DNSSECUnchecked {
+		return "unchecked"
+	}
+	return string(s)
+}
+
+// WeakerDNSSECState returns whichever of a and b provides less assurance.
+func WeakerDNSSECState(a, b DNSSECState) DNSSECState {
+	if dnssecStateRank[b] < dnssecStateRank[a] {
+		return b
+	}
+	return a
+}
+
+// maxDNSSECCacheTTL caps how long the validated keys of a zone, or the proof
+// that a zone is unsigned, are cached.
+const maxDNSSECCacheTTL = time.Hour
+
+// maxDNSSECCacheEntries bounds the number of names whose place in the chain of
+// trust is cached. The least recently used names are evicted first.
+const maxDNSSECCacheEntries = 10000
+
+// supportedDNSKEYAlgorithms are the DNSSEC signing algorithms we can validate.
+// Zones signed only with other algorithms are treated as unsigned, per RFC 4035
+// Section 5.2.
+var supportedDNSKEYAlgorithms = map[uint8]bool{
+	dns.RSASHA1:          true,
+	dns.RSASHA1NSEC3SHA1: true,
+	dns.RSASHA256:        true,
+	dns.RSASHA512:        true,
+	dns.ECDSAP256SHA256:  true,
+	dns.ECDSAP384SHA384:  true,
+	dns.ED25519:          true,
+}
+
+// supportedDSDigests are the DS digest types we can check.
+var supportedDSDigests = map[uint8]bool{
+	dns.SHA1:   true,
+	dns.SHA256: true,
+	dns.SHA384: true,
+}
+
+// ParseTrustAnchors parses DNSSEC trust anchors given as DS records in
+// presentation format, such as the root's ". IN DS 20326 8 2 E06D44B8...". All
+// of the anchors must be for the same zone.
+func ParseTrustAnchors(anchors []string) ([]*dns.DS, error) {
+	var parsed []*dns.DS
+	for _, anchor := range anchors {
+		rr, err := dns.NewRR(anchor)
+		if err != nil {
+			return nil, fmt.Errorf("parsing trust anchor %q: %w", anchor, err)
+		}
+		ds, ok := rr.(*dns.DS)
+		if !ok {
+			return nil, fmt.Errorf("trust anchor %q is not a DS record", anchor)
+		}
+		if len(parsed) > 0 && !strings.EqualFold(ds.Hdr.Name, parsed[0].Hdr.Name) {
+			return nil, fmt.Errorf("trust anchors are for more than one zone: %q and %q", parsed[0].Hdr.Name, ds.Hdr.Name)
+		}
+		parsed = append(parsed, ds)
+	}
+	return parsed, nil
+}
+
+// bogusError describes why a response failed DNSSEC validation.
+type bogusError struct {
+	reason string
+}
+
+func (be *bogusError) Error() string {
+	return be.reason
+}
+
+func bogusf(format string, args ...interface{}) *bogusError {
+	return &bogusError{reason: fmt.Sprintf(format, args...)}
+}
+
+// zoneResult is what the chain of trust says about a name: the closest
+// enclosing zone whose keys were validated, those keys, and whether names in
+// that zone are secure, insecure or bogus.
+type zoneResult struct {
+	zone    string
+	keys    []*dns.DNSKEY
+	state   DNSSECState
+	bogus   *bogusError
+	expires time.Time
+}
+
+// validator validates DNS responses in-process, building the chain of trust
+// from its trust anchors down to each answer using DS and DNSKEY queries. The
+// results of walking the chain are cached, so that lookups of names in the
+// same zones share them.
+type validator struct {
+	anchorZone string
+	anchors    []*dns.DS
+	// query sends a DNSSEC-enabled query for the given name and type.
+	query func(ctx context.Context, name string, qtype uint16) (*dns.Msg, error)
+	clk   clock.Clock
+
+	// Note: This must be a regular mutex, not an RWMutex, because chain.Get()
+	// mutates the lru.Cache (by updating the last-used info).
+	mu    sync.Mutex
+	chain *lru.Cache
+}
+
+func newValidator(anchors []*dns.DS, query func(context.Context, string, uint16) (*dns.Msg, error), clk clock.Clock) *validator {
+	return &validator{
+		anchorZone: strings.ToLower(dns.Fqdn(anchors[0].Hdr.Name)),
+		anchors:    anchors,
+		query:      query,
+		clk:        clk,
+		chain:      lru.New(maxDNSSECCacheEntries),
+	}
+}
+
+// rrset is a set of records sharing an owner name and type, with the
+// signatures covering them.
+type rrset struct {
+	name  string
+	rtype uint16
+	rrs   []dns.RR
+	sigs  []*dns.RRSIG
+}
+
+// groupRRsets splits the records of a message section into RRsets, in the
+// order they first appear.
+func groupRRsets(section []dns.RR) []*rrset {
+	type key struct {
+		name  string
+		rtype uint16
+	}
+	var sets []*rrset
+	byKey := make(map[key]*rrset)
+	get := func(k key) *rrset {
+		set, ok := byKey[k]
+		if !ok {
+			set = &rrset{name: k.name, rtype: k.rtype}
+			byKey[k] = set
+			sets = append(sets, set)
+		}
+		return set
+	}
+	for _, rr := range section {
+		name := strings.ToLower(rr.Header().Name)
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			set := get(key{name, sig.TypeCovered})
+			set.sigs = append(set.sigs, sig)
+			continue
+		}
+		if rr.Header().Rrtype == dns.TypeOPT {
+			continue
+		}
+		set := get(key{name, rr.Header().Rrtype})
+		set.rrs = append(set.rrs, rr)
+	}
+	// Drop sets which are only signatures.
+	var result []*rrset
+	for _, set := range sets {
+		if len(set.rrs) > 0 {
+			result = append(result, set)
+		}
+	}
+	return result
+}
+
+// find returns the RRset with the given name and type, or nil.
+func find(sets []*rrset, name string, rtype uint16) *rrset {
+	for _, set := range sets {
+		if set.rtype == rtype && set.name == name {
+			return set
+		}
+	}
+	return nil
+}
+
+// verify checks that at least one of the set's signatures is currently valid,
+// was made by zone, and verifies with one of keys.
+func (v *validator) verify(set *rrset, zone string, keys []*dns.DNSKEY) *bogusError {
+	if len(set.sigs) == 0 {
+		return bogusf("%s %s in signed zone %s is not signed", set.name, dns.TypeToString[set.rtype], zone)
+	}
+	now := v.clk.Now()
+	for _, sig := range set.sigs {
+		if !strings.EqualFold(sig.SignerName, zone) || !sig.ValidityPeriod(now) {
+			continue
+		}
+		for _, key := range keys {
+			if key.KeyTag() != sig.KeyTag || key.Algorithm != sig.Algorithm {
+				continue
+			}
+			if sig.Verify(key, set.rrs) == nil {
+				return nil
+			}
+		}
+	}
+	return bogusf("no valid signature by %s over %s %s", zone, set.name, dns.TypeToString[set.rtype])
+}
+
+// ttl returns the lowest TTL of the set's records, capped at
+// maxDNSSECCacheTTL.
+func (set *rrset) ttl() time.Duration {
+	ttl := maxDNSSECCacheTTL
+	for _, rr := range set.rrs {
+		if t := time.Duration(rr.Header().Ttl) * time.Second; t < ttl {
+			ttl = t
+		}
+	}
+	return ttl
+}
+
+// parentName returns the name one label above the given fully qualified name.
+func parentName(name string) string {
+	i, end := dns.NextLabel(name, 0)
+	if end {
+		return "."
+	}
+	return name[i:]
+}
+
+// ancestor returns the ancestor of name (or name itself) with the given
+// number of labels.
+func ancestor(name string, labels int) string {
+	all := dns.SplitDomainName(name)
+	if labels <= 0 {
+		return "."
+	}
+	return strings.Join(all[len(all)-labels:], ".") + "."
+}
+
+// walk returns what the chain of trust says about the given name, walking
+// down from the trust anchor one label at a time. The error is non-nil only if
+// a query failed, in which case nothing could be concluded.
+func (v *validator) walk(ctx context.Context, name string) (zoneResult, error) {
+	name = strings.ToLower(dns.Fqdn(name))
+	now := v.clk.Now()
+	v.mu.Lock()
+	val, ok := v.chain.Get(name)
+	if ok {
+		cached := val.(zoneResult)
+		if now.Before(cached.expires) {
+			v.mu.Unlock()
+			return cached, nil
+		}
+		// Expired entries have to be removed actively, because otherwise each
+		// lookup counts as a use and they'd never be evicted.
+		v.chain.Remove(name)
+	}
+	v.mu.Unlock()
+
+	var result zoneResult
+	switch {
+	case name == v.anchorZone:
+		resp, err := v.query(ctx, name, dns.TypeDNSKEY)
+		if err != nil {
+			return zoneResult{}, err
+		}
+		result = v.zoneKeys(name, resp, v.anchors)
+	case !dns.IsSubDomain(v.anchorZone, name):
+		// No trust anchor covers this name.
+		result = zoneResult{state: DNSSECInsecure, expires: now.Add(maxDNSSECCacheTTL)}
+	default:
+		parent, err := v.walk(ctx, parentName(name))
+		if err != nil {
+			return zoneResult{}, err
+		}
+		if parent.state != DNSSECSecure {
+			result = parent
+			break
+		}
+		result, err = v.delegation(ctx, parent, name)
+		if err != nil {
+			return zoneResult{}, err
+		}
+		if parent.expires.Before(result.expires) {
+			result.expires = parent.expires
+		}
+	}
+
+	v.mu.Lock()
+	v.chain.Add(name, result)
+	v.mu.Unlock()
+	return result, nil
+}
+
+// zoneKeys validates the DNSKEY RRset of zone in resp against the DS records
+// for the zone, and returns the zone's keys if it is secure.
+func (v *validator) zoneKeys(zone string, resp *dns.Msg, dsSet []*dns.DS) zoneResult {
+	now := v.clk.Now()
+	bogus := func(be *bogusError) zoneResult {
+		return zoneResult{zone: zone, state: DNSSECBogus, bogus: be, expires: now.Add(maxDNSSECCacheTTL)}
+	}
+
+	var supported []*dns.DS
+	for _, ds := range dsSet {
+		if supportedDSDigests[ds.DigestType] && supportedDNSKEYAlgorithms[ds.Algorithm] {
+			supported = append(supported, ds)
+		}
+	}
+	if len(supported) == 0 {
+		return zoneResult{zone: zone, state: DNSSECInsecure, expires: now.Add(maxDNSSECCacheTTL)}
+	}
+
+	keySet := find(groupRRsets(resp.Answer), zone, dns.TypeDNSKEY)
+	if keySet == nil {
+		return bogus(bogusf("no DNSKEY records for signed zone %s", zone))
+	}
+	var keys []*dns.DNSKEY
+	for _, rr := range keySet.rrs {
+		if key, ok := rr.(*dns.DNSKEY); ok && key.Flags&dns.ZONE != 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	// The DNSKEY RRset must be signed by a key which one of the DS records
+	// vouches for.
+	var trusted []*dns.DNSKEY
+	for _, ds := range supported {
+		for _, key := range keys {
+			if key.KeyTag() != ds.KeyTag || key.Algorithm != ds.Algorithm {
+				continue
+			}
+			if keyDS := key.ToDS(ds.DigestType); keyDS != nil && strings.EqualFold(keyDS.Digest, ds.Digest) {
+				trusted = append(trusted, key)
+			}
+		}
+	}
+	if len(trusted) == 0 {
+		return bogus(bogusf("no DNSKEY for %s matches its DS records", zone))
+	}
+	if be := v.verify(keySet, zone, trusted); be != nil {
+		return bogus(be)
+	}
+	return zoneResult{zone: zone, keys: keys, state: DNSSECSecure, expires: now.Add(keySet.ttl())}
+}
+
+// delegation determines whether name, directly beneath a name in the secure
+// zone described by parent, is the apex of a secure zone, of an insecure zone,
+// or not a zone cut at all.
+func (v *validator) delegation(ctx context.Context, parent zoneResult, name string) (zoneResult, error) {
+	now := v.clk.Now()
+	bogus := func(be *bogusError) (zoneResult, error) {
+		return zoneResult{zone: parent.zone, state: DNSSECBogus, bogus: be, expires: now.Add(maxDNSSECCacheTTL)}, nil
+	}
+
+	resp, err := v.query(ctx, name, dns.TypeDS)
+	if err != nil {
+		return zoneResult{}, err
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return zoneResult{}, fmt.Errorf("querying DS for %s: %s", name, dns.RcodeToString[resp.Rcode])
+	}
+
+	answer := groupRRsets(resp.Answer)
+	if dsSet := find(answer, name, dns.TypeDS); dsSet != nil {
+		if be := v.verify(dsSet, parent.zone, parent.keys); be != nil {
+			return bogus(be)
+		}
+		var ds []*dns.DS
+		for _, rr := range dsSet.rrs {
+			ds = append(ds, rr.(*dns.DS))
+		}
+		keyResp, err := v.query(ctx, name, dns.TypeDNSKEY)
+		if err != nil {
+			return zoneResult{}, err
+		}
+		result := v.zoneKeys(name, keyResp, ds)
+		if ttl := now.Add(dsSet.ttl()); ttl.Before(result.expires) {
+			result.expires = ttl
+		}
+		return result, nil
+	}
+
+	// Anything else means name is not a secure delegation, and parent must
+	// have signed a proof of that.
+	notCut := zoneResult{zone: parent.zone, keys: parent.keys, state: DNSSECSecure, expires: parent.expires}
+	if cname := find(answer, name, dns.TypeCNAME); cname != nil {
+		if be := v.verify(cname, parent.zone, parent.keys); be != nil {
+			return bogus(be)
+		}
+		return notCut, nil
+	}
+	denial, be := v.denialRecords(resp, parent)
+	if be != nil {
+		return bogus(be)
+	}
+	for _, nsec := range denial.nsec {
+		if strings.EqualFold(nsec.Hdr.Name, name) {
+			if hasType(nsec.TypeBitMap, dns.TypeDS) {
+				return bogus(bogusf("DS records for %s are proven to exist but are missing", name))
+			}
+			if hasType(nsec.TypeBitMap, dns.TypeNS) {
+				return zoneResult{zone: parent.zone, state: DNSSECInsecure, expires: now.Add(denial.ttl)}, nil
+			}
+			return notCut, nil
+		}
+		if nsecCovers(nsec, name) {
+			return notCut, nil
+		}
+	}
+	for _, nsec3 := range denial.nsec3 {
+		if nsec3.Match(name) {
+			if hasType(nsec3.TypeBitMap, dns.TypeDS) {
+				return bogus(bogusf("DS records for %s are proven to exist but are missing", name))
+			}
+			if hasType(nsec3.TypeBitMap, dns.TypeNS) {
+				return zoneResult{zone: parent.zone, state: DNSSECInsecure, expires: now.Add(denial.ttl)}, nil
+			}
+			return notCut, nil
+		}
+		if nsec3.Cover(name) {
+			// An opt-out span may hide unsigned delegations, so names in it
+			// are insecure (RFC 5155 Section 6).
+			if nsec3.Flags&1 == 1 {
+				return zoneResult{zone: parent.zone, state: DNSSECInsecure, expires: now.Add(denial.ttl)}, nil
+			}
+			return notCut, nil
+		}
+	}
+	return bogus(bogusf("no proof that %s has no DS records", name))
+}
+
+// denial holds the validated NSEC and NSEC3 records of a negative response.
+type denial struct {
+	nsec  []*dns.NSEC
+	nsec3 []*dns.NSEC3
+	ttl   time.Duration
+}
+
+// denialRecords verifies the NSEC and NSEC3 RRsets in the authority section of
+// resp against the keys of zr, and returns their records.
+func (v *validator) denialRecords(resp *dns.Msg, zr zoneResult) (denial, *bogusError) {
+	d := denial{ttl: maxDNSSECCacheTTL}
+	for _, set := range groupRRsets(resp.Ns) {
+		if set.rtype != dns.TypeNSEC && set.rtype != dns.TypeNSEC3 {
+			continue
+		}
+		if be := v.verify(set, zr.zone, zr.keys); be != nil {
+			return denial{}, be
+		}
+		if set.ttl() < d.ttl {
+			d.ttl = set.ttl()
+		}
+		for _, rr := range set.rrs {
+			switch rr := rr.(type) {
+			case *dns.NSEC:
+				d.nsec = append(d.nsec, rr)
+			case *dns.NSEC3:
+				d.nsec3 = append(d.nsec3, rr)
+			}
+		}
+	}
+	return d, nil
+}
+
+func hasType(bitmap []uint16, rtype uint16) bool {
+	for _, t := range bitmap {
+		if t == rtype {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalLess reports whether a sorts before b in the canonical order of
+// DNS names given by RFC 4034 Section 6.1.
+func canonicalLess(a, b string) bool {
+	la := dns.SplitDomainName(strings.ToLower(a))
+	lb := dns.SplitDomainName(strings.ToLower(b))
+	for i := 1; i <= len(la) && i <= len(lb); i++ {
+		x, y := la[len(la)-i], lb[len(lb)-i]
+		if x != y {
+			return x < y
+		}
+	}
+	return len(la) < len(lb)
+}
+
+// nsecCovers reports whether name falls strictly between the owner and next
+// names of nsec, proving that it doesn't exist.
+func nsecCovers(nsec *dns.NSEC, name string) bool {
+	owner, next := nsec.Hdr.Name, nsec.NextDomain
+	if !dns.IsSubDomain(next, name) && !canonicalLess(owner, next) {
+		// The last NSEC in a zone points back to the apex, so anything in the
+		// zone after the owner is covered.
+		return false
+	}
+	if !canonicalLess(owner, next) {
+		return canonicalLess(owner, name)
+	}
+	return canonicalLess(owner, name) && canonicalLess(name, next)
+}
+
+// nsecClosestEncloser returns the closest encloser of name which nsec, a
+// record covering name, implies: the longest ancestor of name which is also
+// an ancestor of the owner or next name of nsec.
+func nsecClosestEncloser(nsec *dns.NSEC, name string) string {
+	labels := dns.CompareDomainName(name, nsec.Hdr.Name)
+	if n := dns.CompareDomainName(name, nsec.NextDomain); n > labels {
+		labels = n
+	}
+	return ancestor(name, labels)
+}
+
+// nsec3ClosestEncloser finds the closest provable encloser of name (RFC 5155
+// Section 8.3): the longest ancestor matched by one of the NSEC3 records,
+// along with the record covering the next closer name beneath it. It returns
+// an empty encloser if there is no such proof.
+func nsec3ClosestEncloser(nsec3s []*dns.NSEC3, name string) (string, *dns.NSEC3) {
+	labels := dns.CountLabel(name)
+	for n := labels - 1; n >= 0; n-- {
+		ce := ancestor(name, n)
+		for _, match := range nsec3s {
+			if !match.Match(ce) {
+				continue
+			}
+			nextCloser := ancestor(name, n+1)
+			for _, cover := range nsec3s {
+				if cover.Cover(nextCloser) {
+					return ce, cover
+				}
+			}
+			return "", nil
+		}
+	}
+	return "", nil
+}
+
+// validate checks the DNSSEC validity of resp, a response to a query for
+// qname and qtype. It returns the state of the response: the weakest state of
+// any of the RRsets in its answer, and of its proof of non-existence if the
+// answer is negative. If the state is DNSSECBogus the error describes why.
+// Otherwise a non-nil error means a query needed for validation failed.
+func (v *validator) validate(ctx context.Context, qname string, qtype uint16, resp *dns.Msg) (DNSSECState, error) {
+	qname = strings.ToLower(dns.Fqdn(qname))
+	state := DNSSECSecure
+	answer := groupRRsets(resp.Answer)
+	for _, set := range answer {
+		if set.rtype == dns.TypeCNAME && len(set.sigs) == 0 && synthesizedFromDNAME(answer, set.name) {
+			// CNAMEs synthesized from a DNAME aren't signed (RFC 6672
+			// Section 5.3.1); the DNAME is validated instead.
+			continue
+		}
+		setState, err := v.validateRRset(ctx, set, resp)
+		if err != nil || setState == DNSSECBogus {
+			return setState, err
+		}
+		state = WeakerDNSSECState(state, setState)
+	}
+
+	// Follow any aliases to find the name whose records were asked for.
+	sname := qname
+	for i := 0; i < len(answer); i++ {
+		cname := find(answer, sname, dns.TypeCNAME)
+		if cname == nil {
+			break
+		}
+		sname = strings.ToLower(cname.rrs[0].(*dns.CNAME).Target)
+	}
+	if resp.Rcode == dns.RcodeNameError || find(answer, sname, qtype) == nil {
+		denialState, err := v.validateDenial(ctx, sname, qtype, resp)
+		if err != nil || denialState == DNSSECBogus {
+			return denialState, err
+		}
+		state = WeakerDNSSECState(state, denialState)
+	}
+	return state, nil
+}
+
+// synthesizedFromDNAME reports whether the answer contains a DNAME at an
+// ancestor of name.
+func synthesizedFromDNAME(answer []*rrset, name string) bool {
+	for _, set := range answer {
+		if set.rtype == dns.TypeDNAME && set.name != name && dns.IsSubDomain(set.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRRset validates a single RRset of an answer.
+func (v *validator) validateRRset(ctx context.Context, set *rrset, resp *dns.Msg) (DNSSECState, error) {
+	zr, err := v.walk(ctx, set.name)
+	if err != nil {
+		return DNSSECUnchecked, err
+	}
+	if zr.state != DNSSECSecure {
+		return zr.state, bogusErrorOrNil(zr.bogus)
+	}
+	if be := v.verify(set, zr.zone, zr.keys); be != nil {
+		return DNSSECBogus, be
+	}
+
+	// An RRSIG with fewer labels than its owner name means the RRset was
+	// expanded from a wildcard, which is only valid if the name asked for
+	// doesn't exist (RFC 4035 Section 5.3.4).
+	labels := dns.CountLabel(set.name)
+	wildcardLabels := labels
+	for _, sig := range set.sigs {
+		if int(sig.Labels) < wildcardLabels {
+			wildcardLabels = int(sig.Labels)
+		}
+	}
+	if wildcardLabels == labels {
+		return DNSSECSecure, nil
+	}
+	d, be := v.denialRecords(resp, zr)
+	if be != nil {
+		return DNSSECBogus, be
+	}
+	for _, nsec := range d.nsec {
+		if nsecCovers(nsec, set.name) {
+			return DNSSECSecure, nil
+		}
+	}
+	nextCloser := ancestor(set.name, wildcardLabels+1)
+	for _, nsec3 := range d.nsec3 {
+		if nsec3.Cover(nextCloser) {
+			return DNSSECSecure, nil
+		}
+	}
+	return DNSSECBogus, bogusf("no proof that %s doesn't exist for wildcard answer", set.name)
+}
+
+// validateDenial validates the proof in resp that sname has no records of type
+// qtype, or doesn't exist at all.
+func (v *validator) validateDenial(ctx context.Context, sname string, qtype uint16, resp *dns.Msg) (DNSSECState, error) {
+	zr, err := v.walk(ctx, sname)
+	if err != nil {
+		return DNSSECUnchecked, err
+	}
+	if zr.state != DNSSECSecure {
+		return zr.state, bogusErrorOrNil(zr.bogus)
+	}
+	d, be := v.denialRecords(resp, zr)
+	if be != nil {
+		return DNSSECBogus, be
+	}
+	typeAbsent := func(bitmap []uint16) bool {
+		return !hasType(bitmap, qtype) && !hasType(bitmap, dns.TypeCNAME)
+	}
+	nxdomain := resp.Rcode == dns.RcodeNameError
+	qtypeStr := dns.TypeToString[qtype]
+
+	if !nxdomain {
+		// The name exists, so a record for it must show qtype is absent.
+		for _, nsec := range d.nsec {
+			if strings.EqualFold(nsec.Hdr.Name, sname) && typeAbsent(nsec.TypeBitMap) {
+				return DNSSECSecure, nil
+			}
+		}
+		for _, nsec3 := range d.nsec3 {
+			if nsec3.Match(sname) && typeAbsent(nsec3.TypeBitMap) {
+				return DNSSECSecure, nil
+			}
+		}
+	}
+
+	// Otherwise sname must not exist, and nor may a wildcard which would
+	// match it, except that a matching wildcard without qtype proves there is
+	// no data.
+	for _, nsec := range d.nsec {
+		if !nsecCovers(nsec, sname) {
+			continue
+		}
+		wildcard := "*." + strings.TrimPrefix(nsecClosestEncloser(nsec, sname), ".")
+		if wildcard == "*.." {
+			wildcard = "*."
+		}
+		for _, w := range d.nsec {
+			if nsecCovers(w, wildcard) && nxdomain {
+				return DNSSECSecure, nil
+			}
+			if strings.EqualFold(w.Hdr.Name, wildcard) && typeAbsent(w.TypeBitMap) && !nxdomain {
+				return DNSSECSecure, nil
+			}
+		}
+	}
+	if len(d.nsec3) > 0 {
+		ce, cover := nsec3ClosestEncloser(d.nsec3, sname)
+		if cover != nil {
+			if cover.Flags&1 == 1 {
+				return DNSSECInsecure, nil
+			}
+			wildcard := "*." + ce
+			if ce == "." {
+				wildcard = "*."
+			}
+			for _, w := range d.nsec3 {
+				if w.Cover(wildcard) && nxdomain {
+					return DNSSECSecure, nil
+				}
+				if w.Match(wildcard) && typeAbsent(w.TypeBitMap) && !nxdomain {
+					return DNSSECSecure, nil
+				}
+			}
+		}
+	}
+	if nxdomain {
+		return DNSSECBogus, bogusf("no proof that %s doesn't exist", sname)
+	}
+	return DNSSECBogus, bogusf("no proof that %s has no %s records", sname, qtypeStr)
+}
+
+// bogusErrorOrNil avoids returning a typed nil as an error.
+func bogusErrorOrNil(be *bogusError) error {
+	if be == nil {
+		return nil
+	}
+	return be
+}
+
+// isBogus reports whether err is a DNSSEC validation failure, and if so
+// returns the reason.
+func isBogus(err error) (string, bool) {
+	var be *bogusError
+	if errors.As(err, &be) {
+		return be.reason, true
+	}
+	return "", false
+}
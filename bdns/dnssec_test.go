@@ -0,0 +1,269 @@
+package bdns
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/miekg/dns"
+)
+
+// signedZone is a DNSSEC signed zone with a single key.
+type signedZone struct {
+	name string
+	key  *dns.DNSKEY
+	priv crypto.Signer
+}
+
+func newSignedZone(t *testing.T, name string) *signedZone {
+	t.Helper()
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: name, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	test.AssertNotError(t, err, "generating DNSKEY")
+	return &signedZone{name: name, key: key, priv: priv.(crypto.Signer)}
+}
+
+// sign returns the RRset made of rrs along with the zone's signature over it.
+func (z *signedZone) sign(t *testing.T, clk clock.Clock, rrs ...dns.RR) []dns.RR {
+	t.Helper()
+	sig := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Name: rrs[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 3600},
+		Algorithm:  z.key.Algorithm,
+		SignerName: z.name,
+		KeyTag:     z.key.KeyTag(),
+		Inception:  uint32(clk.Now().Add(-time.Hour).Unix()),
+		Expiration: uint32(clk.Now().Add(time.Hour).Unix()),
+	}
+	err := sig.Sign(z.priv, rrs)
+	test.AssertNotError(t, err, "signing RRset")
+	return append(rrs, sig)
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+	rr, err := dns.NewRR(s)
+	test.AssertNotError(t, err, "parsing RR")
+	return rr
+}
+
+// testHierarchy is a signed root zone, with a signed example. zone delegated
+// from it, containing www.example. and an unsigned delegation to
+// unsigned.example.
+type testHierarchy struct {
+	clk       clock.FakeClock
+	root      *signedZone
+	example   *signedZone
+	responses map[string]*dns.Msg
+}
+
+func newTestHierarchy(t *testing.T) *testHierarchy {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))
+	h := &testHierarchy{
+		clk:       fc,
+		root:      newSignedZone(t, "."),
+		example:   newSignedZone(t, "example."),
+		responses: make(map[string]*dns.Msg),
+	}
+	h.set(".", dns.TypeDNSKEY, &dns.Msg{Answer: h.root.sign(t, h.clk, h.root.key)})
+	h.set("example.", dns.TypeDS, &dns.Msg{Answer: h.root.sign(t, h.clk, h.example.key.ToDS(dns.SHA256))})
+	h.set("example.", dns.TypeDNSKEY, &dns.Msg{Answer: h.example.sign(t, h.clk, h.example.key)})
+	h.set("www.example.", dns.TypeDS, &dns.Msg{
+		Ns: h.example.sign(t, h.clk, mustRR(t, "www.example. 3600 IN NSEC \\000.www.example. TXT RRSIG NSEC")),
+	})
+	h.set("unsigned.example.", dns.TypeDS, &dns.Msg{
+		Ns: h.example.sign(t, h.clk, mustRR(t, "unsigned.example. 3600 IN NSEC www.example. NS RRSIG NSEC")),
+	})
+	return h
+}
+
+func (h *testHierarchy) set(name string, qtype uint16, resp *dns.Msg) {
+	h.responses[fmt.Sprintf("%s/%d", name, qtype)] = resp
+}
+
+func (h *testHierarchy) query(_ context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	resp, ok := h.responses[fmt.Sprintf("%s/%d", dns.Fqdn(name), qtype)]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query for %s %s", name, dns.TypeToString[qtype])
+	}
+	return resp, nil
+}
+
+func (h *testHierarchy) validator() *validator {
+	return newValidator([]*dns.DS{h.root.key.ToDS(dns.SHA256)}, h.query, h.clk)
+}
+
+func TestDNSSECValidate(t *testing.T) {
+	h := newTestHierarchy(t)
+	txt := mustRR(t, "www.example. 300 IN TXT \"hello\"")
+	tampered := h.example.sign(t, h.clk, mustRR(t, "www.example. 300 IN TXT \"hello\""))
+	tampered[0].(*dns.TXT).Txt = []string{"goodbye"}
+
+	testCases := []struct {
+		name     string
+		qname    string
+		qtype    uint16
+		resp     *dns.Msg
+		expected DNSSECState
+	}{
+		{
+			name:     "signed answer",
+			qname:    "www.example.",
+			qtype:    dns.TypeTXT,
+			resp:     &dns.Msg{Answer: h.example.sign(t, h.clk, txt)},
+			expected: DNSSECSecure,
+		},
+		{
+			name:     "unsigned answer in signed zone",
+			qname:    "www.example.",
+			qtype:    dns.TypeTXT,
+			resp:     &dns.Msg{Answer: []dns.RR{txt}},
+			expected: DNSSECBogus,
+		},
+		{
+			name:     "tampered answer",
+			qname:    "www.example.",
+			qtype:    dns.TypeTXT,
+			resp:     &dns.Msg{Answer: tampered},
+			expected: DNSSECBogus,
+		},
+		{
+			name:  "signed proof of no data",
+			qname: "www.example.",
+			qtype: dns.TypeCAA,
+			resp: &dns.Msg{
+				Ns: h.example.sign(t, h.clk, mustRR(t, "www.example. 3600 IN NSEC \\000.www.example. TXT RRSIG NSEC")),
+			},
+			expected: DNSSECSecure,
+		},
+		{
+			name:     "missing proof of no data",
+			qname:    "www.example.",
+			qtype:    dns.TypeCAA,
+			resp:     &dns.Msg{},
+			expected: DNSSECBogus,
+		},
+		{
+			name:  "proof of no data for the wrong type",
+			qname: "www.example.",
+			qtype: dns.TypeTXT,
+			resp: &dns.Msg{
+				Ns: h.example.sign(t, h.clk, mustRR(t, "www.example. 3600 IN NSEC \\000.www.example. TXT RRSIG NSEC")),
+			},
+			expected: DNSSECBogus,
+		},
+		{
+			name:     "unsigned answer beneath insecure delegation",
+			qname:    "www.unsigned.example.",
+			qtype:    dns.TypeTXT,
+			resp:     &dns.Msg{Answer: []dns.RR{mustRR(t, "www.unsigned.example. 300 IN TXT \"hello\"")}},
+			expected: DNSSECInsecure,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := h.validator().validate(context.Background(), tc.qname, tc.qtype, tc.resp)
+			test.AssertEquals(t, state, tc.expected)
+			if tc.expected == DNSSECBogus {
+				_, bogus := isBogus(err)
+				test.Assert(t, bogus, fmt.Sprintf("expected bogus error, got %v", err))
+			} else {
+				test.AssertNotError(t, err, "validating response")
+			}
+		})
+	}
+}
+
+func TestDNSSECOutsideTrustAnchor(t *testing.T) {
+	h := newTestHierarchy(t)
+	v := newValidator([]*dns.DS{h.example.key.ToDS(dns.SHA256)}, h.query, h.clk)
+	state, err := v.validate(context.Background(), "www.other.", dns.TypeA, &dns.Msg{
+		Answer: []dns.RR{mustRR(t, "www.other. 300 IN A 192.0.2.1")},
+	})
+	test.AssertNotError(t, err, "validating response")
+	test.AssertEquals(t, state, DNSSECInsecure)
+}
+
+func TestDNSSECExpiredSignature(t *testing.T) {
+	h := newTestHierarchy(t)
+	resp := &dns.Msg{Answer: h.example.sign(t, h.clk, mustRR(t, "www.example. 300 IN TXT \"hello\""))}
+	h.clk.Add(2 * time.Hour)
+	state, err := h.validator().validate(context.Background(), "www.example.", dns.TypeTXT, resp)
+	test.AssertEquals(t, state, DNSSECBogus)
+	test.AssertError(t, err, "expected expired signatures to fail validation")
+}
+
+func TestDNSSECQueryFailure(t *testing.T) {
+	h := newTestHierarchy(t)
+	delete(h.responses, fmt.Sprintf("example./%d", dns.TypeDNSKEY))
+	_, err := h.validator().validate(context.Background(), "www.example.", dns.TypeTXT, &dns.Msg{
+		Answer: h.example.sign(t, h.clk, mustRR(t, "www.example. 300 IN TXT \"hello\"")),
+	})
+	test.AssertError(t, err, "expected failed query to fail validation")
+	_, bogus := isBogus(err)
+	test.Assert(t, !bogus, "failed query shouldn't be reported as bogus")
+}
+
+func TestDNSSECChainCache(t *testing.T) {
+	h := newTestHierarchy(t)
+	v := h.validator()
+
+	zr, err := v.walk(context.Background(), "www.example.")
+	test.AssertNotError(t, err, "walking the chain of trust")
+	test.AssertEquals(t, zr.state, DNSSECSecure)
+	test.AssertEquals(t, v.chain.Len(), 3)
+
+	// Cached results are served without querying again.
+	delete(h.responses, fmt.Sprintf("example./%d", dns.TypeDNSKEY))
+	_, err = v.walk(context.Background(), "www.example.")
+	test.AssertNotError(t, err, "walking the cached chain of trust")
+
+	// Expired results are validated again rather than served. By now the
+	// root's signatures have expired too, so the chain is bogus.
+	h.clk.Add(maxDNSSECCacheTTL + time.Second)
+	zr, err = v.walk(context.Background(), "www.example.")
+	test.AssertNotError(t, err, "walking the chain of trust")
+	test.AssertEquals(t, zr.state, DNSSECBogus)
+
+	// The cache doesn't grow past its maximum size.
+	h = newTestHierarchy(t)
+	v = h.validator()
+	v.chain.MaxEntries = 2
+	_, err = v.walk(context.Background(), "www.example.")
+	test.AssertNotError(t, err, "walking the chain of trust")
+	test.AssertEquals(t, v.chain.Len(), 2)
+}
+
+func TestWeakerDNSSECState(t *testing.T) {
+	test.AssertEquals(t, WeakerDNSSECState(DNSSECSecure, DNSSECInsecure), DNSSECInsecure)
+	test.AssertEquals(t, WeakerDNSSECState(DNSSECInsecure, DNSSECSecure), DNSSECInsecure)
+	test.AssertEquals(t, WeakerDNSSECState(DNSSECSecure, DNSSECUnchecked), DNSSECUnchecked)
+	test.AssertEquals(t, WeakerDNSSECState(DNSSECBogus, DNSSECSecure), DNSSECBogus)
+}
+
+func TestParseTrustAnchors(t *testing.T) {
+	anchors, err := ParseTrustAnchors([]string{
+		". IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+	})
+	test.AssertNotError(t, err, "parsing root trust anchor")
+	test.AssertEquals(t, len(anchors), 1)
+	test.AssertEquals(t, anchors[0].KeyTag, uint16(20326))
+
+	_, err = ParseTrustAnchors([]string{". IN DNSKEY 257 3 8 AwEAAa=="})
+	test.AssertError(t, err, "expected error for non-DS trust anchor")
+
+	_, err = ParseTrustAnchors([]string{
+		". IN DS 20326 8 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+		"example. IN DS 12345 13 2 E06D44B80B8F1D39A95C0B0D7C65D08458E880409BBC683457104237C7F8EC8D",
+	})
+	test.AssertError(t, err, "expected error for trust anchors in different zones")
+}
@@ -20,7 +20,10 @@ type Error struct {
 func (d Error) Error() string {
 	var detail, additional string
 	if d.underlying != nil {
-		if netErr, ok := d.underlying.(*net.OpError); ok {
+		if reason, ok := isBogus(d.underlying); ok {
+			detail = detailDNSSECFailure
+			additional = " - " + reason
+		} else if netErr, ok := d.underlying.(*net.OpError); ok {
 			if netErr.Timeout() {
 				detail = detailDNSTimeout
 			} else {
@@ -51,6 +54,7 @@ const detailDNSTimeout = "query timed out"
 const detailCanceled = "query timed out (and was canceled)"
 const detailDNSNetFailure = "networking error"
 const detailServerFailure = "server failure at resolver"
+const detailDNSSECFailure = "DNSSEC validation failure"
 
 // rcodeExplanations provide additional friendly explanatory text to be included in DNS
 // error messages, for select inscrutable RCODEs.
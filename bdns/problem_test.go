@@ -35,6 +35,9 @@ func TestError(t *testing.T) {
 		}, {
 			&Error{dns.TypeA, "hostname", nil, dns.RcodeFormatError},
 			"DNS problem: FORMERR looking up A for hostname",
+		}, {
+			&Error{dns.TypeA, "hostname", bogusf("no valid signature by example.com. over hostname. A"), -1},
+			"DNS problem: DNSSEC validation failure looking up A for hostname - no valid signature by example.com. over hostname. A",
 		},
 	}
 	for _, tc := range testCases {
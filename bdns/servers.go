@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -68,14 +70,63 @@ func validateServerAddress(address string) error {
 	return nil
 }
 
+// parseServer checks that a configured server is either a host:port address of
+// a plain DNS server, a "tls://host[:port]" URI of a DNS over TLS server, or an
+// "https://host[:port][/path]" URL of a DNS over HTTPS server. It returns the
+// server in the form used by the exchanger, with the default port (853) or
+// path ("/dns-query") filled in where they were omitted.
+func parseServer(server string) (string, error) {
+	switch {
+	case strings.HasPrefix(server, schemeTLS):
+		address := strings.TrimPrefix(server, schemeTLS)
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			address = net.JoinHostPort(strings.Trim(address, "[]"), defaultTLSPort)
+		}
+		err := validateServerAddress(address)
+		if err != nil {
+			return "", err
+		}
+		return schemeTLS + address, nil
+	case strings.HasPrefix(server, schemeHTTPS):
+		u, err := url.Parse(server)
+		if err != nil {
+			return "", err
+		}
+		if u.Hostname() == "" {
+			return "", errors.New("host cannot be missing")
+		}
+		if u.Port() != "" {
+			err = validateServerAddress(u.Host)
+			if err != nil {
+				return "", err
+			}
+		}
+		if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+			return "", errors.New("URL cannot contain user info, query or fragment")
+		}
+		if u.Path == "" {
+			u.Path = defaultDoHPath
+		}
+		return u.String(), nil
+	}
+	err := validateServerAddress(server)
+	if err != nil {
+		return "", err
+	}
+	return server, nil
+}
+
+// NewStaticProvider constructs a staticProvider for the given servers, each of
+// which may be a plain, DNS over TLS or DNS over HTTPS server as accepted by
+// parseServer.
 func NewStaticProvider(servers []string) (*staticProvider, error) {
 	var serverAddrs []string
 	for _, server := range servers {
-		err := validateServerAddress(server)
+		parsed, err := parseServer(server)
 		if err != nil {
 			return nil, fmt.Errorf("server address %q invalid: %s", server, err)
 		}
-		serverAddrs = append(serverAddrs, server)
+		serverAddrs = append(serverAddrs, parsed)
 	}
 	return &staticProvider{servers: serverAddrs}, nil
 }
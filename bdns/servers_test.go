@@ -60,3 +60,41 @@ func Test_validateServerAddress(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  string
+		want    string
+		wantErr bool
+	}{
+		{"plain", "1.1.1.1:53", "1.1.1.1:53", false},
+		{"plain without port", "1.1.1.1", "", true},
+
+		{"tls with port", "tls://1.1.1.1:8853", "tls://1.1.1.1:8853", false},
+		{"tls default port", "tls://1.1.1.1", "tls://1.1.1.1:853", false},
+		{"tls ipv6 default port", "tls://[2606:4700:4700::1111]", "tls://[2606:4700:4700::1111]:853", false},
+		{"tls hostname", "tls://resolver.example.com", "tls://resolver.example.com:853", false},
+		{"tls bad port", "tls://1.1.1.1:65536", "", true},
+		{"tls empty", "tls://", "", true},
+
+		{"https default path", "https://resolver.example.com", "https://resolver.example.com/dns-query", false},
+		{"https with port and path", "https://1.1.1.1:8443/resolve", "https://1.1.1.1:8443/resolve", false},
+		{"https bad port", "https://1.1.1.1:0/dns-query", "", true},
+		{"https no host", "https:///dns-query", "", true},
+		{"https query", "https://resolver.example.com/dns-query?dns=abc", "", true},
+		{"https user info", "https://user@resolver.example.com/dns-query", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseServer(tt.server)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseServer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseServer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package bdns
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// schemeTLS prefixes the addresses of upstream resolvers which are reached
+	// using DNS over TLS (RFC 7858).
+	schemeTLS = "tls://"
+	// schemeHTTPS prefixes the URLs of upstream resolvers which are reached
+	// using DNS over HTTPS (RFC 8484).
+	schemeHTTPS = "https://"
+
+	// defaultTLSPort is the port used for DNS over TLS when none is given.
+	defaultTLSPort = "853"
+	// defaultDoHPath is the path used for DNS over HTTPS when none is given.
+	defaultDoHPath = "/dns-query"
+
+	// dohMediaType is the media type of DNS messages sent over HTTPS.
+	dohMediaType = "application/dns-message"
+
+	// maxIdleConnsPerServer limits how many idle connections are kept open to
+	// each DNS over TLS or DNS over HTTPS server for reuse.
+	maxIdleConnsPerServer = 16
+)
+
+// transportExchanger performs each exchange over the transport selected by the
+// form of the server address: DNS over TLS for "tls://host:port", DNS over
+// HTTPS for "https://" URLs, and plain DNS over UDP for "host:port".
+type transportExchanger struct {
+	plain exchanger
+	tls   *dotExchanger
+	https *dohExchanger
+}
+
+var _ exchanger = &transportExchanger{}
+
+func newTransportExchanger(readTimeout time.Duration, tlsConfig *tls.Config) *transportExchanger {
+	plain := new(dns.Client)
+	// Set timeout for underlying net.Conn
+	plain.ReadTimeout = readTimeout
+	plain.Net = "udp"
+
+	return &transportExchanger{
+		plain: plain,
+		tls:   newDoTExchanger(readTimeout, tlsConfig),
+		https: newDoHExchanger(readTimeout, tlsConfig),
+	}
+}
+
+func (te *transportExchanger) Exchange(m *dns.Msg, a string) (*dns.Msg, time.Duration, error) {
+	switch {
+	case strings.HasPrefix(a, schemeTLS):
+		return te.tls.Exchange(m, strings.TrimPrefix(a, schemeTLS))
+	case strings.HasPrefix(a, schemeHTTPS):
+		return te.https.Exchange(m, a)
+	}
+	return te.plain.Exchange(m, a)
+}
+
+// dotExchanger performs exchanges using DNS over TLS. Connections are kept
+// open after each exchange and reused by later exchanges with the same server,
+// each connection carrying one exchange at a time.
+type dotExchanger struct {
+	client *dns.Client
+
+	mu   sync.Mutex
+	idle map[string][]*dns.Conn
+}
+
+func newDoTExchanger(readTimeout time.Duration, tlsConfig *tls.Config) *dotExchanger {
+	return &dotExchanger{
+		client: &dns.Client{
+			Net:          "tcp-tls",
+			TLSConfig:    tlsConfig,
+			DialTimeout:  readTimeout,
+			ReadTimeout:  readTimeout,
+			WriteTimeout: readTimeout,
+		},
+		idle: make(map[string][]*dns.Conn),
+	}
+}
+
+// Exchange sends m to the server at addr, which is a host:port pair. If a
+// reused connection fails, which happens when the server has closed it while
+// idle, the exchange is retried once over a new connection.
+func (de *dotExchanger) Exchange(m *dns.Msg, addr string) (*dns.Msg, time.Duration, error) {
+	conn := de.get(addr)
+	if conn != nil {
+		r, rtt, err := de.client.ExchangeWithConn(m, conn)
+		if err == nil {
+			de.put(addr, conn)
+			return r, rtt, nil
+		}
+		_ = conn.Close()
+	}
+
+	conn, err := de.client.Dial(addr)
+	if err != nil {
+		return nil, 0, err
+	}
+	r, rtt, err := de.client.ExchangeWithConn(m, conn)
+	if err != nil {
+		_ = conn.Close()
+		return r, rtt, err
+	}
+	de.put(addr, conn)
+	return r, rtt, nil
+}
+
+// get returns an idle connection to addr, or nil if there is none.
+func (de *dotExchanger) get(addr string) *dns.Conn {
+	de.mu.Lock()
+	defer de.mu.Unlock()
+	conns := de.idle[addr]
+	if len(conns) == 0 {
+		return nil
+	}
+	conn := conns[len(conns)-1]
+	de.idle[addr] = conns[:len(conns)-1]
+	return conn
+}
+
+// put makes conn available for reuse by later exchanges with addr, or closes it
+// if enough connections to addr are already idle.
+func (de *dotExchanger) put(addr string, conn *dns.Conn) {
+	de.mu.Lock()
+	defer de.mu.Unlock()
+	if len(de.idle[addr]) >= maxIdleConnsPerServer {
+		_ = conn.Close()
+		return
+	}
+	de.idle[addr] = append(de.idle[addr], conn)
+}
+
+// dohExchanger performs exchanges using DNS over HTTPS, POSTing each query in
+// wire format. Connections are reused by the underlying HTTP client.
+type dohExchanger struct {
+	client *http.Client
+}
+
+func newDoHExchanger(readTimeout time.Duration, tlsConfig *tls.Config) *dohExchanger {
+	return &dohExchanger{
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig:     tlsConfig,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConnsPerHost: maxIdleConnsPerServer,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: readTimeout,
+			},
+			Timeout: readTimeout,
+			// Resolvers have no reason to redirect us, and following a redirect
+			// would turn our POST into a GET without the query.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Exchange sends m to the DNS over HTTPS server at url.
+func (he *dohExchanger) Exchange(m *dns.Msg, url string) (*dns.Msg, time.Duration, error) {
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(packed))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", dohMediaType)
+	req.Header.Set("Accept", dohMediaType)
+
+	start := time.Now()
+	resp, err := he.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	// A DNS message is at most 65535 bytes long.
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	rtt := time.Since(start)
+	if err != nil {
+		return nil, rtt, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, rtt, fmt.Errorf("DNS over HTTPS server returned status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != dohMediaType {
+		return nil, rtt, fmt.Errorf("DNS over HTTPS server returned content type %q", ct)
+	}
+
+	r := new(dns.Msg)
+	err = r.Unpack(body)
+	if err != nil {
+		return nil, rtt, err
+	}
+	if r.Id != m.Id {
+		return r, rtt, dns.ErrId
+	}
+	return r, rtt, nil
+}
@@ -0,0 +1,275 @@
+package bdns
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/miekg/dns"
+)
+
+// makeTLSConfigs returns a server TLS config for 127.0.0.1 which requires a
+// client certificate, and a client TLS config which trusts the server and
+// presents such a certificate.
+func makeTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating CA key")
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test resolver CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	test.AssertNotError(t, err, "creating CA certificate")
+	caCert, err := x509.ParseCertificate(caDER)
+	test.AssertNotError(t, err, "parsing CA certificate")
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	leaf := func(serial int64, eku x509.ExtKeyUsage, ips []net.IP) tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		test.AssertNotError(t, err, "generating leaf key")
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "test resolver leaf"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{eku},
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			IPAddresses:  ips,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+		test.AssertNotError(t, err, "creating leaf certificate")
+		return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+
+	serverConfig := &tls.Config{
+		Certificates: []tls.Certificate{leaf(2, x509.ExtKeyUsageServerAuth, []net.IP{net.IPv4(127, 0, 0, 1)})},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	clientConfig := &tls.Config{
+		Certificates: []tls.Certificate{leaf(3, x509.ExtKeyUsageClientAuth, nil)},
+		RootCAs:      pool,
+	}
+	return serverConfig, clientConfig
+}
+
+// countingListener counts the connections it accepts.
+type countingListener struct {
+	net.Listener
+	accepted int32
+}
+
+func (cl *countingListener) Accept() (net.Conn, error) {
+	conn, err := cl.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&cl.accepted, 1)
+	}
+	return conn, err
+}
+
+// serveDoT starts a DNS over TLS stand-in resolver answering with
+// mockDNSQuery, and returns its address and listener.
+func serveDoT(t *testing.T, serverConfig *tls.Config) (string, *countingListener) {
+	t.Helper()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	test.AssertNotError(t, err, "listening for DNS over TLS")
+	counting := &countingListener{Listener: ln}
+	server := &dns.Server{
+		Net:      "tcp-tls",
+		Listener: counting,
+		Handler:  dns.HandlerFunc(mockDNSQuery),
+	}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+	return ln.Addr().String(), counting
+}
+
+func TestDoTLookup(t *testing.T) {
+	serverConfig, clientConfig := makeTLSConfigs(t)
+	addr, listener := serveDoT(t, serverConfig)
+
+	staticProvider, err := NewStaticProvider([]string{"tls://" + addr})
+	test.AssertNotError(t, err, "Got error creating StaticProvider")
+	obj := NewTest(time.Second*10, staticProvider, clientConfig, nil, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+
+	// Sequential lookups share one connection.
+	for i := 0; i < 3; i++ {
+		txts, _, err := obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
+		test.AssertNotError(t, err, "LookupTXT over TLS failed")
+		test.AssertDeepEquals(t, txts, []string{"abc"})
+	}
+	test.AssertEquals(t, atomic.LoadInt32(&listener.accepted), int32(1))
+
+	ips, _, err := obj.LookupHost(context.Background(), "cps.letsencrypt.org")
+	test.AssertNotError(t, err, "LookupHost over TLS failed")
+	test.Assert(t, len(ips) > 0, "no IPs returned")
+}
+
+func TestDoTReconnect(t *testing.T) {
+	serverConfig, clientConfig := makeTLSConfigs(t)
+	addr, listener := serveDoT(t, serverConfig)
+
+	de := newDoTExchanger(time.Second*10, clientConfig)
+	m := new(dns.Msg)
+	m.SetQuestion("split-txt.letsencrypt.org.", dns.TypeTXT)
+	_, _, err := de.Exchange(m, addr)
+	test.AssertNotError(t, err, "first exchange failed")
+
+	// If the server has closed an idle connection, a new one is made.
+	idle := de.get(addr)
+	test.AssertNotNil(t, idle, "connection wasn't kept for reuse")
+	_ = idle.Close()
+	de.put(addr, idle)
+	m.SetQuestion("split-txt.letsencrypt.org.", dns.TypeTXT)
+	r, _, err := de.Exchange(m, addr)
+	test.AssertNotError(t, err, "exchange after idle connection closed failed")
+	test.AssertEquals(t, len(r.Answer), 1)
+	test.AssertEquals(t, atomic.LoadInt32(&listener.accepted), int32(2))
+}
+
+func TestDoTClientCertRequired(t *testing.T) {
+	serverConfig, clientConfig := makeTLSConfigs(t)
+	addr, _ := serveDoT(t, serverConfig)
+
+	// Without the client certificate, the resolver rejects the connection.
+	noCert := clientConfig.Clone()
+	noCert.Certificates = nil
+	staticProvider, err := NewStaticProvider([]string{"tls://" + addr})
+	test.AssertNotError(t, err, "Got error creating StaticProvider")
+	obj := NewTest(time.Second*10, staticProvider, noCert, nil, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+	_, _, err = obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded without a client certificate")
+
+	// Without the custom roots, the resolver's certificate isn't trusted.
+	noRoots := clientConfig.Clone()
+	noRoots.RootCAs = nil
+	obj = NewTest(time.Second*10, staticProvider, noRoots, nil, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+	_, _, err = obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded with an untrusted resolver certificate")
+}
+
+// dohResponseWriter captures the reply written by a dns.Handler, so that a
+// DNS over HTTPS stand-in can reuse mockDNSQuery.
+type dohResponseWriter struct {
+	dns.ResponseWriter
+	reply *dns.Msg
+}
+
+func (w *dohResponseWriter) WriteMsg(m *dns.Msg) error {
+	w.reply = m
+	return nil
+}
+
+// serveDoH starts a DNS over HTTPS stand-in resolver answering with
+// mockDNSQuery at the default path, and returns it along with a count of the
+// connections it has accepted.
+func serveDoH(t *testing.T, serverConfig *tls.Config) (*httptest.Server, *int32) {
+	t.Helper()
+	var accepted int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(defaultDoHPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dohMediaType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		query := new(dns.Msg)
+		err = query.Unpack(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rw := &dohResponseWriter{}
+		mockDNSQuery(rw, query)
+		packed, err := rw.reply.Pack()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", dohMediaType)
+		_, _ = w.Write(packed)
+	})
+	server := httptest.NewUnstartedServer(mux)
+	server.TLS = serverConfig
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&accepted, 1)
+		}
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server, &accepted
+}
+
+func TestDoHLookup(t *testing.T) {
+	serverConfig, clientConfig := makeTLSConfigs(t)
+	server, accepted := serveDoH(t, serverConfig)
+
+	// The default path is filled in.
+	staticProvider, err := NewStaticProvider([]string{server.URL})
+	test.AssertNotError(t, err, "Got error creating StaticProvider")
+	obj := NewTest(time.Second*10, staticProvider, clientConfig, nil, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+
+	// Sequential lookups share one connection.
+	for i := 0; i < 3; i++ {
+		txts, _, err := obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
+		test.AssertNotError(t, err, "LookupTXT over HTTPS failed")
+		test.AssertDeepEquals(t, txts, []string{"abc"})
+	}
+	test.AssertEquals(t, atomic.LoadInt32(accepted), int32(1))
+
+	ips, _, err := obj.LookupHost(context.Background(), "cps.letsencrypt.org")
+	test.AssertNotError(t, err, "LookupHost over HTTPS failed")
+	test.Assert(t, len(ips) > 0, "no IPs returned")
+
+	// Errors from the server are reported.
+	staticProvider, err = NewStaticProvider([]string{server.URL + "/wrong-path"})
+	test.AssertNotError(t, err, "Got error creating StaticProvider")
+	obj = NewTest(time.Second*10, staticProvider, clientConfig, nil, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+	_, _, err = obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded against the wrong path")
+}
+
+func TestDoHClientCertRequired(t *testing.T) {
+	serverConfig, clientConfig := makeTLSConfigs(t)
+	server, _ := serveDoH(t, serverConfig)
+
+	noCert := clientConfig.Clone()
+	noCert.Certificates = nil
+	staticProvider, err := NewStaticProvider([]string{server.URL})
+	test.AssertNotError(t, err, "Got error creating StaticProvider")
+	obj := NewTest(time.Second*10, staticProvider, noCert, nil, metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+	_, _, err = obj.LookupTXT(context.Background(), "split-txt.letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded without a client certificate")
+}
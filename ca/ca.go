@@ -71,6 +71,7 @@ type certificateAuthorityImpl struct {
 	ecdsaAllowList     *ECDSAAllowList
 	prefix             int // Prepended to the serial number
 	validityPeriod     time.Duration
+	certProfiles       map[string]certProfile
 	backdate           time.Duration
 	maxNames           int
 	keyPolicy          goodkey.KeyPolicy
@@ -104,6 +105,57 @@ func makeIssuerMaps(issuers []*issuance.Issuer) (issuerMaps, error) {
 	return issuerMaps{issuersByAlg, issuersByNameID}, nil
 }
 
+// certProfile holds the properties of a certificate profile which the CA
+// itself needs to know about, rather than just the issuer.
+type certProfile struct {
+	validityPeriod time.Duration
+	// shortLived certificates get no OCSP responses, so none is generated at
+	// issuance.
+	shortLived bool
+}
+
+// makeCertProfiles collects the validity periods and lifetimes of the
+// certificate profiles offered by the given issuers, keyed by profile name.
+// Profiles which don't configure a validity period use the given default. All
+// issuers must offer the same set of profiles with the same properties, so that
+// the choice of profile never constrains the choice of issuer.
+func makeCertProfiles(issuers []*issuance.Issuer, defaultValidity time.Duration) (map[string]certProfile, error) {
+	var certProfiles map[string]certProfile
+	for _, issuer := range issuers {
+		issuerProfiles := make(map[string]certProfile)
+		for _, name := range append([]string{""}, issuer.ProfileNames()...) {
+			profile, err := issuer.GetProfile(name)
+			if err != nil {
+				return nil, err
+			}
+			validityPeriod := profile.ValidityPeriod()
+			if validityPeriod == 0 {
+				validityPeriod = defaultValidity
+			}
+			issuerProfiles[name] = certProfile{
+				validityPeriod: validityPeriod,
+				shortLived:     profile.ShortLived(),
+			}
+		}
+		if certProfiles == nil {
+			certProfiles = issuerProfiles
+			continue
+		}
+		if len(issuerProfiles) != len(certProfiles) {
+			return nil, fmt.Errorf("issuer %q does not offer the same certificate profiles as the other issuers", issuer.Name())
+		}
+		for name, profile := range issuerProfiles {
+			if other, ok := certProfiles[name]; !ok || other != profile {
+				return nil, fmt.Errorf("issuer %q does not offer certificate profile %q like the other issuers", issuer.Name(), name)
+			}
+		}
+	}
+	if certProfiles == nil {
+		certProfiles = map[string]certProfile{"": {validityPeriod: defaultValidity}}
+	}
+	return certProfiles, nil
+}
+
 // NewCertificateAuthorityImpl creates a CA instance that can sign certificates
 // from any number of issuance.Issuers according to their profiles, and can sign
 // OCSP (via delegation to an ocspImpl and its issuers).
@@ -143,6 +195,10 @@ func NewCertificateAuthorityImpl(
 	if err != nil {
 		return nil, err
 	}
+	certProfiles, err := makeCertProfiles(boulderIssuers, certExpiry)
+	if err != nil {
+		return nil, err
+	}
 
 	csrExtensionCount := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -174,6 +230,7 @@ func NewCertificateAuthorityImpl(
 		ocsp:               ocsp,
 		issuers:            issuers,
 		validityPeriod:     certExpiry,
+		certProfiles:       certProfiles,
 		backdate:           certBackdate,
 		prefix:             serialPrefix,
 		maxNames:           maxNames,
@@ -212,7 +269,12 @@ func (ca *certificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 		return nil, berrors.InternalServerError("Incomplete issue certificate request")
 	}
 
-	serialBigInt, validity, err := ca.generateSerialNumberAndValidity()
+	profile, ok := ca.certProfiles[issueReq.CertProfile]
+	if !ok {
+		return nil, berrors.MalformedError("unrecognized certificate profile %q", issueReq.CertProfile)
+	}
+
+	serialBigInt, validity, err := ca.generateSerialNumberAndValidity(profile.validityPeriod, issueReq.NotBefore, issueReq.NotAfter)
 	if err != nil {
 		return nil, err
 	}
@@ -231,18 +293,24 @@ func (ca *certificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 		return nil, err
 	}
 
-	precertDER, ocspResp, issuer, err := ca.issuePrecertificateInner(ctx, issueReq, serialBigInt, validity)
+	precertDER, ocspResp, issuer, err := ca.issuePrecertificateInner(ctx, issueReq, serialBigInt, validity, profile.shortLived)
 	if err != nil {
 		return nil, err
 	}
 	issuerID := issuer.Cert.NameID()
+	crlShard, err := issuer.CRLShard(issueReq.CertProfile, serialBigInt)
+	if err != nil {
+		return nil, err
+	}
 
 	req := &sapb.AddCertificateRequest{
-		Der:      precertDER,
-		RegID:    regID,
-		Ocsp:     ocspResp.Response,
-		Issued:   nowNanos,
-		IssuerID: int64(issuerID),
+		Der:        precertDER,
+		RegID:      regID,
+		Ocsp:       ocspResp.GetResponse(),
+		Issued:     nowNanos,
+		IssuerID:   int64(issuerID),
+		CrlShard:   crlShard,
+		ShortLived: profile.shortLived,
 	}
 
 	_, err = ca.sa.AddPrecertificate(ctx, req)
@@ -251,15 +319,17 @@ func (ca *certificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 		err = berrors.InternalServerError(err.Error())
 		// Note: This log line is parsed by cmd/orphan-finder. If you make any
 		// changes here, you should make sure they are reflected in orphan-finder.
-		ca.log.AuditErrf("Failed RPC to store at SA, orphaning precertificate: serial=[%s], cert=[%s], issuerID=[%d], regID=[%d], orderID=[%d], err=[%v]",
-			serialHex, hex.EncodeToString(precertDER), issuerID, issueReq.RegistrationID, issueReq.OrderID, err)
+		ca.log.AuditErrf("Failed RPC to store at SA, orphaning precertificate: serial=[%s], cert=[%s], issuerID=[%d], crlShard=[%d], regID=[%d], orderID=[%d], err=[%v]",
+			serialHex, hex.EncodeToString(precertDER), issuerID, req.CrlShard, issueReq.RegistrationID, issueReq.OrderID, err)
 		if ca.orphanQueue != nil {
 			ca.queueOrphan(&orphanedCert{
-				DER:      precertDER,
-				RegID:    regID,
-				OCSPResp: ocspResp.Response,
-				Precert:  true,
-				IssuerID: int64(issuerID),
+				DER:        precertDER,
+				RegID:      regID,
+				OCSPResp:   req.Ocsp,
+				Precert:    true,
+				IssuerID:   int64(issuerID),
+				CRLShard:   req.CrlShard,
+				ShortLived: req.ShortLived,
 			})
 		}
 		return nil, err
@@ -326,7 +396,7 @@ func (ca *certificateAuthorityImpl) IssueCertificateForPrecertificate(ctx contex
 		return nil, berrors.InternalServerError("no issuer found for Issuer Name %s", precert.Issuer)
 	}
 
-	issuanceReq, err := issuance.RequestFromPrecert(precert, scts, req.TypeIdentifier)
+	issuanceReq, err := issuance.RequestFromPrecert(precert, scts, req.TypeIdentifier, req.CertProfile)
 	if err != nil {
 		return nil, err
 	}
@@ -357,7 +427,16 @@ type validity struct {
 	NotAfter  time.Time
 }
 
-func (ca *certificateAuthorityImpl) generateSerialNumberAndValidity() (*big.Int, validity, error) {
+// generateSerialNumberAndValidity returns a new random serial number and the
+// validity period for a certificate. By default certificates are backdated by
+// ca.backdate and are valid for the validity period of the requested
+// certificate profile. A client may have requested a later notBefore and/or an
+// earlier notAfter on its order (both as Unix timestamps in nanoseconds, zero
+// when not requested), but never a validity period that extends past the
+// default one. A requested notBefore is clamped to lie between the backdated
+// notBefore and now, since an order may be finalized long after it was
+// created, and certificates are never issued with a notBefore in the future.
+func (ca *certificateAuthorityImpl) generateSerialNumberAndValidity(validityPeriod time.Duration, requestedNotBefore, requestedNotAfter int64) (*big.Int, validity, error) {
 	// We want 136 bits of random number, plus an 8-bit instance id prefix.
 	const randBits = 136
 	serialBytes := make([]byte, randBits/8+1)
@@ -371,16 +450,41 @@ func (ca *certificateAuthorityImpl) generateSerialNumberAndValidity() (*big.Int,
 	serialBigInt := big.NewInt(0)
 	serialBigInt = serialBigInt.SetBytes(serialBytes)
 
-	notBefore := ca.clk.Now().Add(-ca.backdate)
+	now := ca.clk.Now()
+	notBefore := now.Add(-ca.backdate)
+	if requestedNotBefore != 0 {
+		requested := time.Unix(0, requestedNotBefore)
+		if requested.After(now) {
+			requested = now
+		}
+		if requested.After(notBefore) {
+			notBefore = requested
+		}
+	}
+	notAfter := notBefore.Add(validityPeriod - time.Second)
+	if requestedNotAfter != 0 {
+		requested := time.Unix(0, requestedNotAfter)
+		if requested.After(notAfter) {
+			return nil, validity{}, berrors.MalformedError(
+				"requested notAfter (%s) is later than the maximum allowed notAfter (%s)",
+				requested.UTC().Format(time.RFC3339), notAfter.UTC().Format(time.RFC3339))
+		}
+		if !requested.After(notBefore) {
+			return nil, validity{}, berrors.MalformedError(
+				"requested notAfter (%s) is not after notBefore (%s)",
+				requested.UTC().Format(time.RFC3339), notBefore.UTC().Format(time.RFC3339))
+		}
+		notAfter = requested
+	}
 	validity := validity{
 		NotBefore: notBefore,
-		NotAfter:  notBefore.Add(ca.validityPeriod - time.Second),
+		NotAfter:  notAfter,
 	}
 
 	return serialBigInt, validity, nil
 }
 
-func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context, issueReq *capb.IssueCertificateRequest, serialBigInt *big.Int, validity validity) ([]byte, *capb.OCSPResponse, *issuance.Issuer, error) {
+func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context, issueReq *capb.IssueCertificateRequest, serialBigInt *big.Int, validity validity, shortLived bool) ([]byte, *capb.OCSPResponse, *issuance.Issuer, error) {
 	csr, err := x509.ParseCertificateRequest(issueReq.Csr)
 	if err != nil {
 		return nil, nil, nil, err
@@ -427,16 +531,20 @@ func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 
 	serialHex := core.SerialToString(serialBigInt)
 
-	// Generate ocsp response before issuing precertificate
-	ocspResp, err := ca.ocsp.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
-		Serial:   serialHex,
-		IssuerID: int64(issuer.Cert.NameID()),
-		Status:   string(core.OCSPStatusGood),
-	})
-	if err != nil {
-		err = berrors.InternalServerError(err.Error())
-		ca.log.AuditInfof("OCSP Signing for precertificate failure: serial=[%s] err=[%s]", serialHex, err)
-		return nil, nil, nil, err
+	// Generate ocsp response before issuing precertificate, unless the
+	// certificate is short-lived and will never have one.
+	var ocspResp *capb.OCSPResponse
+	if !shortLived {
+		ocspResp, err = ca.ocsp.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
+			Serial:   serialHex,
+			IssuerID: int64(issuer.Cert.NameID()),
+			Status:   string(core.OCSPStatusGood),
+		})
+		if err != nil {
+			err = berrors.InternalServerError(err.Error())
+			ca.log.AuditInfof("OCSP Signing for precertificate failure: serial=[%s] err=[%s]", serialHex, err)
+			return nil, nil, nil, err
+		}
 	}
 
 	ca.log.AuditInfof("Signing: serial=[%s] regID=[%d] names=[%s] csr=[%s]",
@@ -446,11 +554,13 @@ func (ca *certificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		Serial:            serialBigInt.Bytes(),
 		CommonName:        csr.Subject.CommonName,
 		DNSNames:          csr.DNSNames,
+		IPAddresses:       csr.IPAddresses,
 		IncludeCTPoison:   true,
 		IncludeMustStaple: issuance.ContainsMustStaple(csr.Extensions),
 		NotBefore:         validity.NotBefore,
 		NotAfter:          validity.NotAfter,
 		TypeIdentifier:    issueReq.TypeIdentifier,
+		Profile:           issueReq.CertProfile,
 	})
 	ca.noteSignError(err)
 	if err != nil {
@@ -505,6 +615,10 @@ type orphanedCert struct {
 	RegID    int64
 	Precert  bool
 	IssuerID int64
+	CRLShard int64
+	// ShortLived is set for precertificates issued under a short-lived
+	// profile, which have no OCSP response.
+	ShortLived bool
 }
 
 func (ca *certificateAuthorityImpl) queueOrphan(o *orphanedCert) {
@@ -557,11 +671,13 @@ func (ca *certificateAuthorityImpl) integrateOrphan() error {
 	issued := cert.NotBefore.Add(ca.backdate)
 	if orphan.Precert {
 		_, err = ca.sa.AddPrecertificate(context.Background(), &sapb.AddCertificateRequest{
-			Der:      orphan.DER,
-			RegID:    orphan.RegID,
-			Ocsp:     orphan.OCSPResp,
-			Issued:   issued.UnixNano(),
-			IssuerID: orphan.IssuerID,
+			Der:        orphan.DER,
+			RegID:      orphan.RegID,
+			Ocsp:       orphan.OCSPResp,
+			Issued:     issued.UnixNano(),
+			IssuerID:   orphan.IssuerID,
+			CrlShard:   orphan.CRLShard,
+			ShortLived: orphan.ShortLived,
 		})
 		if err != nil && !errors.Is(err, berrors.Duplicate) {
 			return fmt.Errorf("failed to store orphaned precertificate: %s", err)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -148,6 +149,7 @@ type testCtx struct {
 
 type mockSA struct {
 	certificate core.Certificate
+	precertReq  *sapb.AddCertificateRequest
 }
 
 func (m *mockSA) AddCertificate(ctx context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*sapb.AddCertificateResponse, error) {
@@ -156,6 +158,7 @@ func (m *mockSA) AddCertificate(ctx context.Context, req *sapb.AddCertificateReq
 }
 
 func (m *mockSA) AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.precertReq = req
 	return &emptypb.Empty{}, nil
 }
 
@@ -219,7 +222,7 @@ func setup(t *testing.T) *testCtx {
 				UseForRSALeaves:   rsa,
 				IssuerURL:         "http://not-example.com/issuer-url",
 				OCSPURL:           "http://not-example.com/ocsp",
-				CRLURL:            "http://not-example.com/crl",
+				CRLURL:            "http://not-example.com/crl/",
 			},
 		)
 		return res
@@ -482,6 +485,78 @@ func TestMultipleIssuers(t *testing.T) {
 	test.AssertNotError(t, err, "Certificate failed signature validation")
 }
 
+func TestIssueEd25519(t *testing.T) {
+	testCtx := setup(t)
+	sa := &mockSA{}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "Failed to generate Ed25519 key")
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "not-example.com"},
+		DNSNames: []string{"not-example.com"},
+	}, priv)
+	test.AssertNotError(t, err, "Failed to create Ed25519 CSR")
+
+	// Use the RSA-and-ECDSA issuer for Ed25519 leaves too.
+	profile, err := issuance.NewProfile(
+		issuance.ProfileConfig{
+			AllowCTPoison:       true,
+			AllowSCTList:        true,
+			AllowCommonName:     true,
+			Policies:            []issuance.PolicyInformation{{OID: "2.23.140.1.2.1"}},
+			MaxValidityPeriod:   cmd.ConfigDuration{Duration: time.Hour * 8760},
+			MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+		},
+		issuance.IssuerConfig{
+			UseForECDSALeaves:   true,
+			UseForRSALeaves:     true,
+			UseForEd25519Leaves: true,
+			IssuerURL:           "http://not-example.com/issuer-url",
+			OCSPURL:             "http://not-example.com/ocsp",
+		},
+	)
+	test.AssertNotError(t, err, "Failed to create profile")
+	testCtx.boulderIssuers[1].Profile = profile
+
+	newCA := func(keyPolicy goodkey.KeyPolicy) *certificateAuthorityImpl {
+		ca, err := NewCertificateAuthorityImpl(
+			sa,
+			testCtx.pa,
+			testCtx.ocsp,
+			testCtx.boulderIssuers,
+			nil,
+			testCtx.certExpiry,
+			testCtx.certBackdate,
+			testCtx.serialPrefix,
+			testCtx.maxNames,
+			keyPolicy,
+			nil,
+			testCtx.logger,
+			testCtx.stats,
+			testCtx.signatureCount,
+			testCtx.signErrorCount,
+			testCtx.fc)
+		test.AssertNotError(t, err, "Failed to create CA")
+		return ca
+	}
+
+	// The CA rejects Ed25519 keys unless its key policy allows them.
+	ca := newCA(testCtx.keyPolicy)
+	_, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{Csr: csrDER, RegistrationID: arbitraryRegID})
+	test.AssertErrorIs(t, err, berrors.BadCSR)
+
+	keyPolicy := testCtx.keyPolicy
+	keyPolicy.AllowEd25519 = true
+	ca = newCA(keyPolicy)
+	result, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{Csr: csrDER, RegistrationID: arbitraryRegID})
+	test.AssertNotError(t, err, "Failed to issue precertificate for Ed25519 key")
+	cert, err := x509.ParseCertificate(result.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertDeepEquals(t, cert.PublicKey, pub)
+	test.AssertByteEquals(t, cert.RawIssuer, caCert.RawSubject)
+	test.AssertEquals(t, cert.KeyUsage, x509.KeyUsageDigitalSignature)
+}
+
 func TestECDSAAllowList(t *testing.T) {
 	req := &capb.IssueCertificateRequest{Csr: ECDSACSR, RegistrationID: arbitraryRegID}
 
@@ -650,6 +725,280 @@ func TestRejectValidityTooLong(t *testing.T) {
 	test.AssertErrorIs(t, err, berrors.InternalServer)
 }
 
+func TestRequestedValidity(t *testing.T) {
+	testCtx := setup(t)
+	sa := &mockSA{}
+	ca, err := NewCertificateAuthorityImpl(
+		sa,
+		testCtx.pa,
+		testCtx.ocsp,
+		testCtx.boulderIssuers,
+		nil,
+		testCtx.certExpiry,
+		testCtx.certBackdate,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		nil,
+		testCtx.logger,
+		testCtx.stats,
+		testCtx.signatureCount,
+		testCtx.signErrorCount,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	now := testCtx.fc.Now()
+	notAfter := now.Add(7 * 24 * time.Hour).Truncate(time.Second)
+	res, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		NotBefore:      now.UnixNano(),
+		NotAfter:       notAfter.UnixNano(),
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with a requested validity period")
+	cert, err := x509.ParseCertificate(res.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, cert.NotBefore, now.Truncate(time.Second).UTC())
+	test.AssertEquals(t, cert.NotAfter, notAfter.UTC())
+
+	// A notAfter later than the default validity period allows is rejected
+	_, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		NotAfter:       now.Add(testCtx.certExpiry).UnixNano(),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	// A notAfter before the notBefore is rejected
+	_, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		NotAfter:       now.Add(-2 * testCtx.certBackdate).UnixNano(),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	// A notBefore earlier than the backdated notBefore is moved later
+	res, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		NotBefore:      now.Add(-2 * testCtx.certBackdate).UnixNano(),
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with an early notBefore")
+	cert, err = x509.ParseCertificate(res.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, cert.NotBefore, now.Add(-testCtx.certBackdate).Truncate(time.Second).UTC())
+
+	// A notBefore of exactly the backdated notBefore is accepted
+	res, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		NotBefore:      now.Add(-testCtx.certBackdate).UnixNano(),
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with a backdated notBefore")
+	cert, err = x509.ParseCertificate(res.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, cert.NotBefore, now.Add(-testCtx.certBackdate).Truncate(time.Second).UTC())
+
+	// An order finalized well after the notBefore it requested is issued with
+	// the backdated notBefore of the time of finalization, and the requested
+	// notAfter
+	testCtx.fc.Add(5 * time.Hour)
+	finalized := testCtx.fc.Now()
+	res, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		NotBefore:      now.UnixNano(),
+		NotAfter:       notAfter.UnixNano(),
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate well after the requested notBefore")
+	cert, err = x509.ParseCertificate(res.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, cert.NotBefore, finalized.Add(-testCtx.certBackdate).Truncate(time.Second).UTC())
+	test.AssertEquals(t, cert.NotAfter, notAfter.UTC())
+}
+
+func TestCertProfiles(t *testing.T) {
+	testCtx := setup(t)
+	sa := &mockSA{}
+
+	shortLived := func(rsa, ecdsa bool) *issuance.Profile {
+		profile, err := issuance.NewProfile(
+			issuance.ProfileConfig{
+				AllowCTPoison:       true,
+				AllowSCTList:        true,
+				AllowCommonName:     true,
+				ExtKeyUsages:        []string{"serverAuth"},
+				ValidityPeriod:      cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+				MaxValidityPeriod:   cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+				MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+			},
+			issuance.IssuerConfig{
+				UseForECDSALeaves: ecdsa,
+				UseForRSALeaves:   rsa,
+				IssuerURL:         "http://not-example.com/issuer-url",
+				OCSPURL:           "http://not-example.com/ocsp",
+			},
+		)
+		test.AssertNotError(t, err, "Failed to create profile")
+		return profile
+	}
+
+	// Every issuer must offer the same profiles.
+	err := testCtx.boulderIssuers[0].AddProfile("shortlived", shortLived(false, true))
+	test.AssertNotError(t, err, "Failed to add profile")
+	_, err = NewCertificateAuthorityImpl(
+		sa,
+		testCtx.pa,
+		testCtx.ocsp,
+		testCtx.boulderIssuers,
+		nil,
+		testCtx.certExpiry,
+		testCtx.certBackdate,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		nil,
+		testCtx.logger,
+		testCtx.stats,
+		testCtx.signatureCount,
+		testCtx.signErrorCount,
+		testCtx.fc)
+	test.AssertError(t, err, "Created CA with issuers offering different profiles")
+
+	err = testCtx.boulderIssuers[1].AddProfile("shortlived", shortLived(true, true))
+	test.AssertNotError(t, err, "Failed to add profile")
+	ca, err := NewCertificateAuthorityImpl(
+		sa,
+		testCtx.pa,
+		testCtx.ocsp,
+		testCtx.boulderIssuers,
+		nil,
+		testCtx.certExpiry,
+		testCtx.certBackdate,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		nil,
+		testCtx.logger,
+		testCtx.stats,
+		testCtx.signatureCount,
+		testCtx.signErrorCount,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	precert, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		CertProfile:    "shortlived",
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with a named profile")
+	parsedPrecert, err := x509.ParseCertificate(precert.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, parsedPrecert.NotAfter.Sub(parsedPrecert.NotBefore), 7*24*time.Hour-time.Second)
+	test.AssertDeepEquals(t, parsedPrecert.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+
+	sctBytes, err := makeSCTs()
+	test.AssertNotError(t, err, "Failed to make SCTs")
+	cert, err := ca.IssueCertificateForPrecertificate(ctx, &capb.IssueCertificateForPrecertificateRequest{
+		DER:            precert.DER,
+		SCTs:           sctBytes,
+		RegistrationID: arbitraryRegID,
+		CertProfile:    "shortlived",
+	})
+	test.AssertNotError(t, err, "Failed to issue cert from precert with a named profile")
+	parsedCert, err := x509.ParseCertificate(cert.Der)
+	test.AssertNotError(t, err, "Failed to parse cert")
+	test.AssertDeepEquals(t, parsedCert.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+
+	_, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		CertProfile:    "unknown",
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+}
+
+func TestIssueShortLived(t *testing.T) {
+	testCtx := setup(t)
+	sa := &mockSA{}
+
+	shortLived := func(rsa, ecdsa bool) *issuance.Profile {
+		profile, err := issuance.NewProfile(
+			issuance.ProfileConfig{
+				AllowCTPoison:       true,
+				AllowSCTList:        true,
+				AllowCommonName:     true,
+				ValidityPeriod:      cmd.ConfigDuration{Duration: 6 * 24 * time.Hour},
+				MaxValidityPeriod:   cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+				MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+				ShortLived:          true,
+			},
+			issuance.IssuerConfig{
+				UseForECDSALeaves: ecdsa,
+				UseForRSALeaves:   rsa,
+				IssuerURL:         "http://not-example.com/issuer-url",
+				OCSPURL:           "http://not-example.com/ocsp",
+				CRLURL:            "http://not-example.com/crl/",
+			},
+		)
+		test.AssertNotError(t, err, "Failed to create profile")
+		return profile
+	}
+	err := testCtx.boulderIssuers[0].AddProfile("shortlived", shortLived(false, true))
+	test.AssertNotError(t, err, "Failed to add profile")
+	err = testCtx.boulderIssuers[1].AddProfile("shortlived", shortLived(true, true))
+	test.AssertNotError(t, err, "Failed to add profile")
+
+	ca, err := NewCertificateAuthorityImpl(
+		sa,
+		testCtx.pa,
+		testCtx.ocsp,
+		testCtx.boulderIssuers,
+		nil,
+		testCtx.certExpiry,
+		testCtx.certBackdate,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.keyPolicy,
+		nil,
+		testCtx.logger,
+		testCtx.stats,
+		testCtx.signatureCount,
+		testCtx.signErrorCount,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	precert, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+		CertProfile:    "shortlived",
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with a short-lived profile")
+	parsedPrecert, err := x509.ParseCertificate(precert.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, len(parsedPrecert.OCSPServer), 0)
+	test.AssertDeepEquals(t, parsedPrecert.CRLDistributionPoints,
+		[]string{"http://not-example.com/crl/" + issuance.CRLShardPath(issuance.GetIssuerNameID(parsedPrecert), 0)})
+
+	// No OCSP response is signed, and the SA is told the certificate is
+	// short-lived.
+	test.AssertMetricWithLabelsEquals(t, ca.signatureCount, prometheus.Labels{"purpose": "ocsp"}, 0)
+	test.AssertNotNil(t, sa.precertReq, "precertificate wasn't stored")
+	test.Assert(t, sa.precertReq.ShortLived, "precertificate should be stored as short-lived")
+	test.AssertEquals(t, len(sa.precertReq.Ocsp), 0)
+
+	// Certificates issued under the default profile still get an OCSP
+	// response.
+	_, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate with the default profile")
+	test.AssertMetricWithLabelsEquals(t, ca.signatureCount, prometheus.Labels{"purpose": "ocsp"}, 1)
+	test.Assert(t, !sa.precertReq.ShortLived, "precertificate shouldn't be stored as short-lived")
+	test.Assert(t, len(sa.precertReq.Ocsp) > 0, "precertificate should be stored with an OCSP response")
+}
+
 func issueCertificateSubTestProfileSelectionRSA(t *testing.T, i *TestCertificateIssuance) {
 	// Certificates for RSA keys should be marked as usable for signatures and encryption.
 	expectedKeyUsage := x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
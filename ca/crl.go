@@ -0,0 +1,200 @@
+package ca
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/miekg/pkcs11"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// crlImpl provides a backing implementation for the CRL gRPC service.
+type crlImpl struct {
+	capb.UnimplementedCRLGeneratorServer
+	issuers        map[issuance.IssuerNameID]*issuance.Issuer
+	lifetime       time.Duration
+	log            blog.Logger
+	signatureCount *prometheus.CounterVec
+	signErrorCount *prometheus.CounterVec
+}
+
+// NewCRLImpl returns a new CRL generator which signs CRLs with the given
+// issuers. Each CRL is valid for the given lifetime after its thisUpdate.
+func NewCRLImpl(
+	issuers []*issuance.Issuer,
+	lifetime time.Duration,
+	logger blog.Logger,
+	signatureCount *prometheus.CounterVec,
+	signErrorCount *prometheus.CounterVec,
+) (*crlImpl, error) {
+	if lifetime <= 0 {
+		return nil, errors.New("CRL lifetime must be positive")
+	}
+	// Per the CABF Baseline Requirements, Section 4.9.7, the nextUpdate of a
+	// CRL for subscriber certificates must be no more than ten days after its
+	// thisUpdate.
+	if lifetime > 10*24*time.Hour {
+		return nil, fmt.Errorf("CRL lifetime must be no more than 10 days, got %s", lifetime)
+	}
+
+	issuersByNameID := make(map[issuance.IssuerNameID]*issuance.Issuer, len(issuers))
+	for _, issuer := range issuers {
+		issuersByNameID[issuer.Cert.NameID()] = issuer
+	}
+
+	return &crlImpl{
+		issuers:        issuersByNameID,
+		lifetime:       lifetime,
+		log:            logger,
+		signatureCount: signatureCount,
+		signErrorCount: signErrorCount,
+	}, nil
+}
+
+// reasonCodeOID is the OID of the CRL entry reasonCode extension, RFC 5280
+// Section 5.3.1.
+var reasonCodeOID = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+// issuingDistributionPointOID is the OID of the CRL issuingDistributionPoint
+// extension, RFC 5280 Section 5.2.5.
+var issuingDistributionPointOID = asn1.ObjectIdentifier{2, 5, 29, 28}
+
+// distributionPointName and issuingDistributionPoint mirror the ASN.1
+// structures of the same names in RFC 5280, omitting the fields we never set.
+type distributionPointName struct {
+	FullName []asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+type issuingDistributionPoint struct {
+	DistributionPoint     distributionPointName `asn1:"optional,tag:0"`
+	OnlyContainsUserCerts bool                  `asn1:"optional,tag:1"`
+}
+
+// makeIDPExt returns a critical issuingDistributionPoint extension naming the
+// given URL, which scopes a CRL shard to the end-entity certificates whose
+// CRL Distribution Points extension contains that URL.
+func makeIDPExt(url string) (pkix.Extension, error) {
+	val, err := asn1.Marshal(issuingDistributionPoint{
+		DistributionPoint: distributionPointName{
+			FullName: []asn1.RawValue{
+				// GeneralName uniformResourceIdentifier [6] IA5String
+				{Class: asn1.ClassContextSpecific, Tag: 6, Bytes: []byte(url)},
+			},
+		},
+		OnlyContainsUserCerts: true,
+	})
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{Id: issuingDistributionPointOID, Critical: true, Value: val}, nil
+}
+
+// crlEntryToRevokedCertificate converts a CRLEntry into the form expected by
+// x509.CreateRevocationList.
+func crlEntryToRevokedCertificate(entry *corepb.CRLEntry) (pkix.RevokedCertificate, error) {
+	serial, err := core.StringToSerial(entry.Serial)
+	if err != nil {
+		return pkix.RevokedCertificate{}, err
+	}
+	if entry.RevokedAt == 0 {
+		return pkix.RevokedCertificate{}, fmt.Errorf("CRL entry for serial %s has no revocation time", entry.Serial)
+	}
+	revokedCert := pkix.RevokedCertificate{
+		SerialNumber:   serial,
+		RevocationTime: time.Unix(0, entry.RevokedAt),
+	}
+	// The Baseline Requirements, Section 7.2.2, forbid including the
+	// reasonCode extension when the reason is unspecified.
+	if entry.Reason != ocsp.Unspecified {
+		reasonBytes, err := asn1.Marshal(asn1.Enumerated(entry.Reason))
+		if err != nil {
+			return pkix.RevokedCertificate{}, err
+		}
+		revokedCert.Extensions = []pkix.Extension{{Id: reasonCodeOID, Value: reasonBytes}}
+	}
+	return revokedCert, nil
+}
+
+// GenerateCRL produces a new CRL for the requested issuer and shard containing
+// the given entries, and returns it DER encoded. The CRL number is derived from
+// the thisUpdate, so that CRLs generated later always have larger numbers.
+// CRLs for shards other than zero carry an issuingDistributionPoint extension
+// naming the shard's URL; the CRL for shard zero covers all certificates
+// issued without a shard and so has none.
+func (ci *crlImpl) GenerateCRL(ctx context.Context, req *capb.GenerateCRLRequest) (*capb.GenerateCRLResponse, error) {
+	if core.IsAnyNilOrZero(req, req.IssuerNameID, req.ThisUpdate) {
+		return nil, berrors.InternalServerError("Incomplete generate CRL request")
+	}
+
+	issuer, ok := ci.issuers[issuance.IssuerNameID(req.IssuerNameID)]
+	if !ok {
+		return nil, fmt.Errorf("This CA doesn't have an issuer cert with ID %d", req.IssuerNameID)
+	}
+
+	revokedCerts := make([]pkix.RevokedCertificate, 0, len(req.Entries))
+	for _, entry := range req.Entries {
+		revokedCert, err := crlEntryToRevokedCertificate(entry)
+		if err != nil {
+			return nil, err
+		}
+		revokedCerts = append(revokedCerts, revokedCert)
+	}
+
+	thisUpdate := time.Unix(0, req.ThisUpdate)
+	template := &x509.RevocationList{
+		RevokedCertificates: revokedCerts,
+		Number:              big.NewInt(req.ThisUpdate),
+		ThisUpdate:          thisUpdate,
+		NextUpdate:          thisUpdate.Add(ci.lifetime - time.Second),
+	}
+
+	// Every shard of an issuer with a CRL URL, including shard zero, is
+	// published at the URL in its certificates' CRL Distribution Points.
+	shardURL := issuer.CRLShardURL(req.CrlShard)
+	if shardURL == "" && req.CrlShard != 0 {
+		return nil, fmt.Errorf("issuer %s has no CRL shard %d", issuer.Name(), req.CrlShard)
+	}
+	if shardURL != "" {
+		idp, err := makeIDPExt(shardURL)
+		if err != nil {
+			return nil, err
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, idp)
+	}
+
+	ci.log.AuditInfof("Signing CRL: issuer=[%s] shard=[%d] number=[%s] thisUpdate=[%s] nextUpdate=[%s] entries=[%d]",
+		issuer.Name(), req.CrlShard, template.Number, template.ThisUpdate.Format(time.RFC3339), template.NextUpdate.Format(time.RFC3339),
+		len(revokedCerts))
+
+	crlDER, err := x509.CreateRevocationList(rand.Reader, template, issuer.Cert.Certificate, issuer.Signer)
+	if err != nil {
+		var pkcs11Error *pkcs11.Error
+		if errors.As(err, &pkcs11Error) {
+			ci.signErrorCount.WithLabelValues("HSM").Inc()
+		}
+		ci.log.AuditErrf("Signing CRL failed: issuer=[%s] shard=[%d] number=[%s] err=[%v]", issuer.Name(), req.CrlShard, template.Number, err)
+		return nil, berrors.InternalServerError("failed to sign CRL: %s", err)
+	}
+	ci.signatureCount.With(prometheus.Labels{"purpose": "crl", "issuer": issuer.Name()}).Inc()
+
+	hash := sha256.Sum256(crlDER)
+	ci.log.AuditInfof("Signing CRL success: issuer=[%s] shard=[%d] number=[%s] hash=[%x]", issuer.Name(), req.CrlShard, template.Number, hash)
+
+	return &capb.GenerateCRLResponse{Crl: crlDER}, nil
+}
@@ -0,0 +1,168 @@
+package ca
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/cmd"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/issuance"
+	"github.com/letsencrypt/boulder/test"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestNewCRLImplLifetime(t *testing.T) {
+	testCtx := setup(t)
+	_, err := NewCRLImpl(testCtx.boulderIssuers, 0, testCtx.logger, testCtx.signatureCount, testCtx.signErrorCount)
+	test.AssertError(t, err, "Created CRL impl with zero lifetime")
+	_, err = NewCRLImpl(testCtx.boulderIssuers, 11*24*time.Hour, testCtx.logger, testCtx.signatureCount, testCtx.signErrorCount)
+	test.AssertError(t, err, "Created CRL impl with lifetime longer than ten days")
+}
+
+func TestGenerateCRL(t *testing.T) {
+	testCtx := setup(t)
+	crli, err := NewCRLImpl(testCtx.boulderIssuers, 7*24*time.Hour, testCtx.logger, testCtx.signatureCount, testCtx.signErrorCount)
+	test.AssertNotError(t, err, "Failed to create CRL impl")
+
+	testCtx.fc.Set(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))
+	thisUpdate := testCtx.fc.Now()
+	revokedAt := thisUpdate.Add(-time.Hour).Truncate(time.Second)
+	res, err := crli.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert.NameID()),
+		ThisUpdate:   thisUpdate.UnixNano(),
+		Entries: []*corepb.CRLEntry{
+			{Serial: "000000000000000000000000000000000001", Reason: ocsp.Unspecified, RevokedAt: revokedAt.UnixNano()},
+			{Serial: "000000000000000000000000000000000002", Reason: ocsp.KeyCompromise, RevokedAt: revokedAt.UnixNano()},
+		},
+	})
+	test.AssertNotError(t, err, "Failed to generate CRL")
+
+	crl, err := x509.ParseCRL(res.Crl)
+	test.AssertNotError(t, err, "Failed to parse CRL")
+	err = caCert.CheckCRLSignature(crl)
+	test.AssertNotError(t, err, "CRL signature didn't validate")
+	test.AssertEquals(t, crl.TBSCertList.ThisUpdate.Unix(), thisUpdate.Unix())
+	test.AssertEquals(t, crl.TBSCertList.NextUpdate.Unix(), thisUpdate.Add(7*24*time.Hour-time.Second).Unix())
+
+	revoked := crl.TBSCertList.RevokedCertificates
+	test.AssertEquals(t, len(revoked), 2)
+	test.AssertEquals(t, revoked[0].SerialNumber.Cmp(big.NewInt(1)), 0)
+	test.AssertEquals(t, revoked[0].RevocationTime.Unix(), revokedAt.Unix())
+	test.AssertEquals(t, len(revoked[0].Extensions), 0)
+	test.AssertEquals(t, revoked[1].SerialNumber.Cmp(big.NewInt(2)), 0)
+	test.AssertEquals(t, len(revoked[1].Extensions), 1)
+	var reason asn1.Enumerated
+	_, err = asn1.Unmarshal(revoked[1].Extensions[0].Value, &reason)
+	test.AssertNotError(t, err, "Failed to unmarshal reasonCode")
+	test.AssertEquals(t, int(reason), ocsp.KeyCompromise)
+
+	// The CRL number is derived from thisUpdate, so later CRLs have larger
+	// numbers.
+	var number *big.Int
+	for _, ext := range crl.TBSCertList.Extensions {
+		if ext.Id.Equal(asn1.ObjectIdentifier{2, 5, 29, 20}) {
+			_, err = asn1.Unmarshal(ext.Value, &number)
+			test.AssertNotError(t, err, "Failed to unmarshal CRL number")
+		}
+	}
+	test.AssertNotNil(t, number, "CRL has no CRL number")
+	test.AssertEquals(t, number.Int64(), thisUpdate.UnixNano())
+}
+
+func TestGenerateCRLErrors(t *testing.T) {
+	testCtx := setup(t)
+	crli, err := NewCRLImpl(testCtx.boulderIssuers, 7*24*time.Hour, testCtx.logger, testCtx.signatureCount, testCtx.signErrorCount)
+	test.AssertNotError(t, err, "Failed to create CRL impl")
+
+	now := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	_, err = crli.GenerateCRL(ctx, &capb.GenerateCRLRequest{IssuerNameID: int64(caCert.NameID())})
+	test.AssertError(t, err, "Generated CRL without thisUpdate")
+
+	_, err = crli.GenerateCRL(ctx, &capb.GenerateCRLRequest{IssuerNameID: 1, ThisUpdate: now})
+	test.AssertError(t, err, "Generated CRL for unknown issuer")
+
+	_, err = crli.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert.NameID()),
+		ThisUpdate:   now,
+		Entries:      []*corepb.CRLEntry{{Serial: "not a serial", RevokedAt: now}},
+	})
+	test.AssertError(t, err, "Generated CRL with a malformed serial")
+
+	_, err = crli.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert.NameID()),
+		ThisUpdate:   now,
+		Entries:      []*corepb.CRLEntry{{Serial: "000000000000000000000000000000000001"}},
+	})
+	test.AssertError(t, err, "Generated CRL with an entry without revocation time")
+}
+
+func TestGenerateCRLShard(t *testing.T) {
+	testCtx := setup(t)
+	profile, err := issuance.NewProfile(
+		issuance.ProfileConfig{
+			MaxValidityPeriod:   cmd.ConfigDuration{Duration: time.Hour * 8760},
+			MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+		},
+		issuance.IssuerConfig{
+			UseForRSALeaves: true,
+			IssuerURL:       "http://not-example.com/issuer-url",
+			OCSPURL:         "http://not-example.com/ocsp",
+			CRLURL:          "http://not-example.com/crl/",
+			CRLShards:       2,
+		},
+	)
+	test.AssertNotError(t, err, "Failed to create profile")
+	issuer := &issuance.Issuer{Cert: caCert, Signer: caKey, Profile: profile, Clk: testCtx.fc}
+	crli, err := NewCRLImpl([]*issuance.Issuer{issuer}, 7*24*time.Hour, testCtx.logger, testCtx.signatureCount, testCtx.signErrorCount)
+	test.AssertNotError(t, err, "Failed to create CRL impl")
+
+	findIDP := func(shard int64) *pkix.Extension {
+		t.Helper()
+		res, err := crli.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+			IssuerNameID: int64(caCert.NameID()),
+			ThisUpdate:   time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+			CrlShard:     shard,
+		})
+		test.AssertNotError(t, err, "Failed to generate CRL")
+		crl, err := x509.ParseCRL(res.Crl)
+		test.AssertNotError(t, err, "Failed to parse CRL")
+		for _, ext := range crl.TBSCertList.Extensions {
+			if ext.Id.Equal(issuingDistributionPointOID) {
+				return &ext
+			}
+		}
+		return nil
+	}
+
+	idpURL := func(shard int64) string {
+		t.Helper()
+		ext := findIDP(shard)
+		test.AssertNotNil(t, ext, "CRL has no issuingDistributionPoint")
+		test.Assert(t, ext.Critical, "issuingDistributionPoint isn't critical")
+		var idp issuingDistributionPoint
+		rest, err := asn1.Unmarshal(ext.Value, &idp)
+		test.AssertNotError(t, err, "Failed to unmarshal issuingDistributionPoint")
+		test.AssertEquals(t, len(rest), 0)
+		test.Assert(t, idp.OnlyContainsUserCerts, "onlyContainsUserCerts isn't set")
+		test.AssertEquals(t, len(idp.DistributionPoint.FullName), 1)
+		return string(idp.DistributionPoint.FullName[0].Bytes)
+	}
+
+	// The CRL for certificates issued without a shard is published at the URL
+	// of shard zero, like any other shard.
+	test.AssertEquals(t, idpURL(0), fmt.Sprintf("http://not-example.com/crl/%d/0.crl", caCert.NameID()))
+	test.AssertEquals(t, idpURL(2), fmt.Sprintf("http://not-example.com/crl/%d/2.crl", caCert.NameID()))
+
+	_, err = crli.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert.NameID()),
+		ThisUpdate:   time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+		CrlShard:     3,
+	})
+	test.AssertError(t, err, "Generated CRL for a shard the issuer doesn't have")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 	"time"
@@ -110,6 +111,74 @@ func (oi *ocspImpl) Stop() {
 
 // GenerateOCSP produces a new OCSP response and returns it
 func (oi *ocspImpl) GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequest) (*capb.OCSPResponse, error) {
+	ocspResponse, err := oi.generateOCSP(req)
+	return &capb.OCSPResponse{Response: ocspResponse}, err
+}
+
+// ocspStreamMaxInFlight is the number of requests from a single
+// GenerateOCSPStream call which may be signed concurrently.
+const ocspStreamMaxInFlight = 64
+
+// GenerateOCSPStream produces a new OCSP response for each request received on
+// the stream, sending the results back in the order the requests arrived. Up
+// to ocspStreamMaxInFlight requests are signed concurrently. An error signing
+// an individual response is returned to the client alongside that request's
+// serial, and does not end the stream. The stream ends when the client closes
+// its side, or when sending or receiving fails.
+func (oi *ocspImpl) GenerateOCSPStream(stream capb.OCSPGenerator_GenerateOCSPStreamServer) error {
+	// results holds the eventual result of each request in the order the
+	// requests were received. Its capacity bounds the number of requests
+	// being signed at once.
+	results := make(chan chan *capb.OCSPStreamResponse, ocspStreamMaxInFlight)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(results)
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					recvErr <- err
+				}
+				return
+			}
+			result := make(chan *capb.OCSPStreamResponse, 1)
+			results <- result
+			go func() {
+				res := &capb.OCSPStreamResponse{Serial: req.Serial}
+				var err error
+				res.Response, err = oi.generateOCSP(req)
+				if err != nil {
+					res.Error = err.Error()
+				}
+				result <- res
+			}()
+		}
+	}()
+
+	for result := range results {
+		err := stream.Send(<-result)
+		if err != nil {
+			// Unblock the receiving goroutine, which will exit once the
+			// stream's context is canceled on return.
+			go func() {
+				for range results {
+				}
+			}()
+			return err
+		}
+	}
+
+	select {
+	case err := <-recvErr:
+		return err
+	default:
+		return nil
+	}
+}
+
+// generateOCSP signs a single OCSP response for GenerateOCSP and
+// GenerateOCSPStream, and returns it DER encoded.
+func (oi *ocspImpl) generateOCSP(req *capb.GenerateOCSPRequest) ([]byte, error) {
 	// req.Status, req.Reason, and req.RevokedAt are often 0, for non-revoked certs.
 	if core.IsAnyNilOrZero(req, req.Serial, req.IssuerID) {
 		return nil, berrors.InternalServerError("Incomplete generate OCSP request")
@@ -146,7 +215,15 @@ func (oi *ocspImpl) GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequ
 		oi.ocspLogQueue.enqueue(serial.Bytes(), now, ocsp.ResponseStatus(tbsResponse.Status))
 	}
 
-	ocspResponse, err := ocsp.CreateResponse(issuer.Cert.Certificate, issuer.Cert.Certificate, tbsResponse, issuer.Signer)
+	// If the issuer has a delegated OCSP responder, it signs the response and
+	// its certificate is included so that clients can verify the delegation.
+	responderCert, signer := issuer.Cert.Certificate, issuer.Signer
+	if issuer.OCSPSigner != nil {
+		responderCert, signer = issuer.OCSPSigner.Cert.Certificate, issuer.OCSPSigner.Signer
+		tbsResponse.Certificate = responderCert
+	}
+
+	ocspResponse, err := ocsp.CreateResponse(issuer.Cert.Certificate, responderCert, tbsResponse, signer)
 	if err == nil {
 		oi.signatureCount.With(prometheus.Labels{"purpose": "ocsp", "issuer": issuer.Name()}).Inc()
 	} else {
@@ -155,7 +232,7 @@ func (oi *ocspImpl) GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequ
 			oi.signErrorCount.WithLabelValues("HSM").Inc()
 		}
 	}
-	return &capb.OCSPResponse{Response: ocspResponse}, err
+	return ocspResponse, err
 }
 
 // ocspLogQueue accumulates OCSP logging events and writes several of them
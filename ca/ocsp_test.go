@@ -2,17 +2,27 @@ package ca
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
 	"testing"
 	"time"
 
 	capb "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/test"
 	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
 )
 
 func serial(t *testing.T) []byte {
@@ -107,6 +117,108 @@ func TestOCSP(t *testing.T) {
 	test.AssertNotError(t, err, "GenerateOCSP failed with fake-but-valid Serial")
 }
 
+func TestOCSPDelegatedSigner(t *testing.T) {
+	testCtx := setup(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "Failed to generate key")
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: "delegated responder"},
+		NotBefore:       testCtx.fc.Now().Add(-time.Hour),
+		NotAfter:        testCtx.fc.Now().Add(time.Hour),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}, Value: []byte{5, 0}}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert.Certificate, key.Public(), caKey)
+	test.AssertNotError(t, err, "Failed to create delegated responder cert")
+	parsedCert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "Failed to parse delegated responder cert")
+	responderCert, err := issuance.NewCertificate(parsedCert)
+	test.AssertNotError(t, err, "Failed to load delegated responder cert")
+
+	issuer := &issuance.Issuer{Cert: caCert, Signer: caKey, Clk: testCtx.fc}
+	err = issuer.SetOCSPSigner(responderCert, key)
+	test.AssertNotError(t, err, "Failed to set delegated OCSP signer")
+	ocspi, err := NewOCSPImpl([]*issuance.Issuer{issuer}, time.Hour, 0, time.Second, testCtx.logger, metrics.NoopRegisterer, testCtx.signatureCount, testCtx.signErrorCount, testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create OCSP impl")
+
+	res, err := ocspi.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
+		Serial:   "03DEADBEEFBADDECAFFADEFACECAFE30",
+		IssuerID: int64(caCert.NameID()),
+		Status:   string(core.OCSPStatusGood),
+	})
+	test.AssertNotError(t, err, "Failed to generate OCSP")
+
+	// Parsing with the issuer checks both that the responder cert was issued
+	// by it and that the responder cert signed the response.
+	parsed, err := ocsp.ParseResponse(res.Response, caCert.Certificate)
+	test.AssertNotError(t, err, "Failed to parse / validate delegated OCSP response")
+	test.AssertNotNil(t, parsed.Certificate, "Response doesn't include the responder cert")
+	test.AssertByteEquals(t, parsed.Certificate.Raw, der)
+	test.AssertByteEquals(t, parsed.RawResponderName, responderCert.RawSubject)
+	test.AssertEquals(t, issuance.GetOCSPIssuerNameID(parsed), caCert.NameID())
+}
+
+// fakeOCSPStream is an in-memory server side of a GenerateOCSPStream call,
+// which receives the given requests and records the responses sent.
+type fakeOCSPStream struct {
+	grpc.ServerStream
+	reqs []*capb.GenerateOCSPRequest
+	sent []*capb.OCSPStreamResponse
+}
+
+func (f *fakeOCSPStream) Recv() (*capb.GenerateOCSPRequest, error) {
+	if len(f.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := f.reqs[0]
+	f.reqs = f.reqs[1:]
+	return req, nil
+}
+
+func (f *fakeOCSPStream) Send(res *capb.OCSPStreamResponse) error {
+	f.sent = append(f.sent, res)
+	return nil
+}
+
+func TestGenerateOCSPStream(t *testing.T) {
+	testCtx := setup(t)
+	issuerID := int64(caCert.NameID())
+
+	var reqs []*capb.GenerateOCSPRequest
+	for i := 1; i <= 100; i++ {
+		reqs = append(reqs, &capb.GenerateOCSPRequest{
+			Serial:   fmt.Sprintf("0000000000000000000000000000000000%02x", i),
+			IssuerID: issuerID,
+			Status:   string(core.OCSPStatusGood),
+		})
+	}
+	// A request which can't be signed shouldn't prevent signing the rest.
+	reqs[50] = &capb.GenerateOCSPRequest{Serial: "BADDECAF", IssuerID: issuerID, Status: string(core.OCSPStatusGood)}
+	stream := &fakeOCSPStream{reqs: reqs}
+
+	err := testCtx.ocsp.GenerateOCSPStream(stream)
+	test.AssertNotError(t, err, "GenerateOCSPStream failed")
+	test.AssertEquals(t, len(stream.sent), 100)
+
+	// Responses are sent in the same order as the requests.
+	for i, res := range stream.sent {
+		if i == 50 {
+			test.AssertEquals(t, res.Serial, "BADDECAF")
+			test.AssertEquals(t, len(res.Response), 0)
+			test.Assert(t, res.Error != "", "Expected an error for a malformed serial")
+			continue
+		}
+		test.AssertEquals(t, res.Error, "")
+		parsed, err := ocsp.ParseResponse(res.Response, caCert.Certificate)
+		test.AssertNotError(t, err, "Failed to parse / validate OCSP response")
+		test.AssertEquals(t, core.SerialToString(parsed.SerialNumber), res.Serial)
+		test.AssertEquals(t, res.Serial, fmt.Sprintf("0000000000000000000000000000000000%02x", i+1))
+	}
+}
+
 // Set up an ocspLogQueue with a very long period and a large maxLen,
 // to ensure any buffered entries get flushed on `.stop()`.
 func TestOcspLogFlushOnExit(t *testing.T) {
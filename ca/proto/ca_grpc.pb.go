@@ -182,6 +182,11 @@ var CertificateAuthority_ServiceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OCSPGeneratorClient interface {
 	GenerateOCSP(ctx context.Context, in *GenerateOCSPRequest, opts ...grpc.CallOption) (*OCSPResponse, error)
+	// GenerateOCSPStream signs one OCSP response for each request received on
+	// the stream, and sends the results back in the same order as the requests.
+	// A failure to sign one response is reported in that item's error field
+	// rather than terminating the stream.
+	GenerateOCSPStream(ctx context.Context, opts ...grpc.CallOption) (OCSPGenerator_GenerateOCSPStreamClient, error)
 }
 
 type oCSPGeneratorClient struct {
@@ -201,11 +206,47 @@ func (c *oCSPGeneratorClient) GenerateOCSP(ctx context.Context, in *GenerateOCSP
 	return out, nil
 }
 
+func (c *oCSPGeneratorClient) GenerateOCSPStream(ctx context.Context, opts ...grpc.CallOption) (OCSPGenerator_GenerateOCSPStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &OCSPGenerator_ServiceDesc.Streams[0], "/ca.OCSPGenerator/GenerateOCSPStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &oCSPGeneratorGenerateOCSPStreamClient{stream}
+	return x, nil
+}
+
+type OCSPGenerator_GenerateOCSPStreamClient interface {
+	Send(*GenerateOCSPRequest) error
+	Recv() (*OCSPStreamResponse, error)
+	grpc.ClientStream
+}
+
+type oCSPGeneratorGenerateOCSPStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *oCSPGeneratorGenerateOCSPStreamClient) Send(m *GenerateOCSPRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *oCSPGeneratorGenerateOCSPStreamClient) Recv() (*OCSPStreamResponse, error) {
+	m := new(OCSPStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // OCSPGeneratorServer is the server API for OCSPGenerator service.
 // All implementations must embed UnimplementedOCSPGeneratorServer
 // for forward compatibility
 type OCSPGeneratorServer interface {
 	GenerateOCSP(context.Context, *GenerateOCSPRequest) (*OCSPResponse, error)
+	// GenerateOCSPStream signs one OCSP response for each request received on
+	// the stream, and sends the results back in the same order as the requests.
+	// A failure to sign one response is reported in that item's error field
+	// rather than terminating the stream.
+	GenerateOCSPStream(OCSPGenerator_GenerateOCSPStreamServer) error
 	mustEmbedUnimplementedOCSPGeneratorServer()
 }
 
@@ -216,6 +257,9 @@ type UnimplementedOCSPGeneratorServer struct {
 func (UnimplementedOCSPGeneratorServer) GenerateOCSP(context.Context, *GenerateOCSPRequest) (*OCSPResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateOCSP not implemented")
 }
+func (UnimplementedOCSPGeneratorServer) GenerateOCSPStream(OCSPGenerator_GenerateOCSPStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateOCSPStream not implemented")
+}
 func (UnimplementedOCSPGeneratorServer) mustEmbedUnimplementedOCSPGeneratorServer() {}
 
 // UnsafeOCSPGeneratorServer may be embedded to opt out of forward compatibility for this service.
@@ -247,6 +291,32 @@ func _OCSPGenerator_GenerateOCSP_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OCSPGenerator_GenerateOCSPStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(OCSPGeneratorServer).GenerateOCSPStream(&oCSPGeneratorGenerateOCSPStreamServer{stream})
+}
+
+type OCSPGenerator_GenerateOCSPStreamServer interface {
+	Send(*OCSPStreamResponse) error
+	Recv() (*GenerateOCSPRequest, error)
+	grpc.ServerStream
+}
+
+type oCSPGeneratorGenerateOCSPStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *oCSPGeneratorGenerateOCSPStreamServer) Send(m *OCSPStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *oCSPGeneratorGenerateOCSPStreamServer) Recv() (*GenerateOCSPRequest, error) {
+	m := new(GenerateOCSPRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // OCSPGenerator_ServiceDesc is the grpc.ServiceDesc for OCSPGenerator service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +329,99 @@ var OCSPGenerator_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _OCSPGenerator_GenerateOCSP_Handler,
 		},
 	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateOCSPStream",
+			Handler:       _OCSPGenerator_GenerateOCSPStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "ca.proto",
+}
+
+// CRLGeneratorClient is the client API for CRLGenerator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CRLGeneratorClient interface {
+	GenerateCRL(ctx context.Context, in *GenerateCRLRequest, opts ...grpc.CallOption) (*GenerateCRLResponse, error)
+}
+
+type cRLGeneratorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCRLGeneratorClient(cc grpc.ClientConnInterface) CRLGeneratorClient {
+	return &cRLGeneratorClient{cc}
+}
+
+func (c *cRLGeneratorClient) GenerateCRL(ctx context.Context, in *GenerateCRLRequest, opts ...grpc.CallOption) (*GenerateCRLResponse, error) {
+	out := new(GenerateCRLResponse)
+	err := c.cc.Invoke(ctx, "/ca.CRLGenerator/GenerateCRL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CRLGeneratorServer is the server API for CRLGenerator service.
+// All implementations must embed UnimplementedCRLGeneratorServer
+// for forward compatibility
+type CRLGeneratorServer interface {
+	GenerateCRL(context.Context, *GenerateCRLRequest) (*GenerateCRLResponse, error)
+	mustEmbedUnimplementedCRLGeneratorServer()
+}
+
+// UnimplementedCRLGeneratorServer must be embedded to have forward compatible implementations.
+type UnimplementedCRLGeneratorServer struct {
+}
+
+func (UnimplementedCRLGeneratorServer) GenerateCRL(context.Context, *GenerateCRLRequest) (*GenerateCRLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateCRL not implemented")
+}
+func (UnimplementedCRLGeneratorServer) mustEmbedUnimplementedCRLGeneratorServer() {}
+
+// UnsafeCRLGeneratorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CRLGeneratorServer will
+// result in compilation errors.
+type UnsafeCRLGeneratorServer interface {
+	mustEmbedUnimplementedCRLGeneratorServer()
+}
+
+func RegisterCRLGeneratorServer(s grpc.ServiceRegistrar, srv CRLGeneratorServer) {
+	s.RegisterService(&CRLGenerator_ServiceDesc, srv)
+}
+
+func _CRLGenerator_GenerateCRL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateCRLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRLGeneratorServer).GenerateCRL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ca.CRLGenerator/GenerateCRL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRLGeneratorServer).GenerateCRL(ctx, req.(*GenerateCRLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CRLGenerator_ServiceDesc is the grpc.ServiceDesc for CRLGenerator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CRLGenerator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ca.CRLGenerator",
+	HandlerType: (*CRLGeneratorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateCRL",
+			Handler:    _CRLGenerator_GenerateCRL_Handler,
+		},
+	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ca.proto",
 }
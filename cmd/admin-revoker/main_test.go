@@ -92,6 +92,8 @@ func TestRevokeBatch(t *testing.T) {
 		nil,
 		0,
 		nil,
+		nil,
+		nil,
 		&mockPurger{},
 		[]*issuance.Certificate{issuer},
 	)
@@ -539,6 +541,8 @@ func setup(t *testing.T) testCtx {
 		nil,
 		0,
 		nil,
+		nil,
+		nil,
 		&mockPurger{},
 		[]*issuance.Certificate{issuer},
 	)
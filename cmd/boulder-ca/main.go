@@ -3,12 +3,14 @@ package notmain
 import (
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"sync"
 
 	"github.com/beeker1121/goque"
 	"github.com/honeycombio/beeline-go"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
@@ -33,12 +35,22 @@ type Config struct {
 
 		GRPCCA            *cmd.GRPCServerConfig
 		GRPCOCSPGenerator *cmd.GRPCServerConfig
+		GRPCCRLGenerator  *cmd.GRPCServerConfig
 
 		SAService *cmd.GRPCClientConfig
 
 		// Issuance contains all information necessary to load and initialize issuers.
 		Issuance struct {
-			Profile      issuance.ProfileConfig
+			Profile issuance.ProfileConfig
+			// Profiles are additional certificate profiles, keyed by name,
+			// which clients may select when creating an order. Every issuer
+			// offers every profile.
+			Profiles map[string]issuance.ProfileConfig
+			// ProfilesFile is the path of a JSON file containing the default
+			// profile and the named profiles, which is shared with the RA and
+			// WFE. If set, it replaces Profile and Profiles, which must then
+			// be left unset.
+			ProfilesFile string
 			Issuers      []issuance.IssuerConfig
 			IgnoredLints []string
 		}
@@ -59,6 +71,10 @@ type Config struct {
 		// than the minTimeToExpiry field for the OCSP Updater.
 		LifespanOCSP cmd.ConfigDuration
 
+		// LifespanCRL is how long CRLs are valid for, measured from their
+		// thisUpdate. It must be no more than ten days.
+		LifespanCRL cmd.ConfigDuration
+
 		// GoodKey is an embedded config stanza for the goodkey library.
 		GoodKey goodkey.Config
 
@@ -93,9 +109,17 @@ type Config struct {
 	Beeline cmd.BeelineConfig
 }
 
-func loadBoulderIssuers(profileConfig issuance.ProfileConfig, issuerConfigs []issuance.IssuerConfig, ignoredLints []string) ([]*issuance.Issuer, error) {
+func loadBoulderIssuers(profileConfig issuance.ProfileConfig, namedProfileConfigs map[string]issuance.ProfileConfig, issuerConfigs []issuance.IssuerConfig, ignoredLints []string) ([]*issuance.Issuer, error) {
 	issuers := make([]*issuance.Issuer, 0, len(issuerConfigs))
+	crlURLs := make(map[string]bool)
 	for _, issuerConfig := range issuerConfigs {
+		if issuerConfig.CRLURL != "" {
+			if crlURLs[issuerConfig.CRLURL] {
+				return nil, fmt.Errorf("CRL URL %q is used by more than one issuer", issuerConfig.CRLURL)
+			}
+			crlURLs[issuerConfig.CRLURL] = true
+		}
+
 		profile, err := issuance.NewProfile(profileConfig, issuerConfig)
 		if err != nil {
 			return nil, err
@@ -116,6 +140,28 @@ func loadBoulderIssuers(profileConfig issuance.ProfileConfig, issuerConfigs []is
 			return nil, err
 		}
 
+		if issuerConfig.OCSPSigner != nil {
+			ocspCert, ocspSigner, err := issuance.LoadIssuer(*issuerConfig.OCSPSigner)
+			if err != nil {
+				return nil, fmt.Errorf("loading delegated OCSP signer: %w", err)
+			}
+			err = issuer.SetOCSPSigner(ocspCert, ocspSigner)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for name, namedProfileConfig := range namedProfileConfigs {
+			namedProfile, err := issuance.NewProfile(namedProfileConfig, issuerConfig)
+			if err != nil {
+				return nil, fmt.Errorf("loading certificate profile %q: %w", name, err)
+			}
+			err = issuer.AddProfile(name, namedProfile)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		issuers = append(issuers, issuer)
 	}
 	return issuers, nil
@@ -124,6 +170,7 @@ func loadBoulderIssuers(profileConfig issuance.ProfileConfig, issuerConfigs []is
 func main() {
 	caAddr := flag.String("ca-addr", "", "CA gRPC listen address override")
 	ocspAddr := flag.String("ocsp-addr", "", "OCSP gRPC listen address override")
+	crlAddr := flag.String("crl-addr", "", "CRL gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
 	flag.Parse()
@@ -145,6 +192,9 @@ func main() {
 	if *ocspAddr != "" {
 		c.CA.GRPCOCSPGenerator.Address = *ocspAddr
 	}
+	if *crlAddr != "" && c.CA.GRPCCRLGenerator != nil {
+		c.CA.GRPCCRLGenerator.Address = *crlAddr
+	}
 	if *debugAddr != "" {
 		c.CA.DebugAddr = *debugAddr
 	}
@@ -189,8 +239,30 @@ func main() {
 	err = pa.SetHostnamePolicyFile(c.CA.HostnamePolicyFile)
 	cmd.FailOnError(err, "Couldn't load hostname policy file")
 
+	if c.PA.IPAddresses != nil {
+		err = pa.SetIPAddressPolicy(c.PA.IPAddresses.AllowedRanges, c.PA.IPAddresses.AllowPrivate)
+		cmd.FailOnError(err, "Couldn't load IP address policy")
+		// Certificates for private IP addresses fail this lint, so it is
+		// ignored only when the IP address policy explicitly permits them.
+		if c.PA.IPAddresses.AllowPrivate {
+			c.CA.Issuance.IgnoredLints = append(c.CA.Issuance.IgnoredLints, "e_ext_san_contains_reserved_ip")
+		}
+	}
+
+	profiles := &issuance.ProfilesConfig{
+		Profile:  c.CA.Issuance.Profile,
+		Profiles: c.CA.Issuance.Profiles,
+	}
+	if c.CA.Issuance.ProfilesFile != "" {
+		if len(c.CA.Issuance.Profiles) > 0 || c.CA.Issuance.Profile.MaxValidityPeriod.Duration != 0 {
+			cmd.Fail("Error in CA config: profilesFile can't be used with profile or profiles")
+		}
+		profiles, err = issuance.LoadProfilesConfig(c.CA.Issuance.ProfilesFile)
+		cmd.FailOnError(err, "Couldn't load certificate profiles")
+	}
+
 	var boulderIssuers []*issuance.Issuer
-	boulderIssuers, err = loadBoulderIssuers(c.CA.Issuance.Profile, c.CA.Issuance.Issuers, c.CA.Issuance.IgnoredLints)
+	boulderIssuers, err = loadBoulderIssuers(profiles.Profile, profiles.Profiles, c.CA.Issuance.Issuers, c.CA.Issuance.IgnoredLints)
 	cmd.FailOnError(err, "Couldn't load issuers")
 
 	tlsConfig, err := c.CA.TLS.Load()
@@ -259,6 +331,34 @@ func main() {
 		wg.Done()
 	}()
 
+	// The CRL generator is optional, so that it can be rolled out to CAs
+	// independently of the crl-updater.
+	var crlSrv *grpc.Server
+	var crlHealth *health.Server
+	if c.CA.GRPCCRLGenerator != nil {
+		crli, err := ca.NewCRLImpl(
+			boulderIssuers,
+			c.CA.LifespanCRL.Duration,
+			logger,
+			signatureCount,
+			signErrorCount,
+		)
+		cmd.FailOnError(err, "Failed to create CRL impl")
+
+		var crlListener net.Listener
+		crlSrv, crlListener, err = bgrpc.NewServer(c.CA.GRPCCRLGenerator, tlsConfig, serverMetrics, clk)
+		cmd.FailOnError(err, "Unable to setup CRL gRPC server")
+		capb.RegisterCRLGeneratorServer(crlSrv, crli)
+		crlHealth = health.NewServer()
+		healthpb.RegisterHealthServer(crlSrv, crlHealth)
+		wg.Add(1)
+		go func() {
+			cmd.FailOnError(cmd.FilterShutdownErrors(crlSrv.Serve(crlListener)),
+				"CRLGenerator gRPC service failed")
+			wg.Done()
+		}()
+	}
+
 	cai, err := ca.NewCertificateAuthorityImpl(
 		sa,
 		pa,
@@ -296,9 +396,15 @@ func main() {
 	go cmd.CatchSignals(logger, func() {
 		caHealth.Shutdown()
 		ocspHealth.Shutdown()
+		if crlHealth != nil {
+			crlHealth.Shutdown()
+		}
 		ecdsaAllowList.Stop()
 		caSrv.GracefulStop()
 		ocspSrv.GracefulStop()
+		if crlSrv != nil {
+			crlSrv.GracefulStop()
+		}
 		wg.Wait()
 		ocspi.Stop()
 	})
@@ -67,6 +67,21 @@ type Config struct {
 
 		OrderLifetime cmd.ConfigDuration
 
+		// CertificateProfiles lists the names of the CA's certificate profiles
+		// which clients may request in a newOrder request, each mapped to the
+		// account IDs permitted to request it. An empty list permits all
+		// accounts. Orders which don't request a profile use the CA's default
+		// profile.
+		CertificateProfiles map[string][]int64
+
+		// CertificateProfilesFile is the path of the JSON file containing the
+		// CA's certificate profiles, the same file as the CA's "profilesFile".
+		// Every name in CertificateProfiles must be one of its named profiles.
+		// Clients may only request a validity period using the notBefore and
+		// notAfter fields of a newOrder request if it is set, and the period
+		// is bounded by the MaxValidityPeriod of the order's profile.
+		CertificateProfilesFile string
+
 		// CTLogGroups contains groupings of CT logs which we want SCTs from.
 		// When we retrieve SCTs we will submit the certificate to each log
 		// in a group and the first SCT returned will be used. This allows
@@ -142,6 +157,11 @@ func main() {
 	err = pa.SetHostnamePolicyFile(c.RA.HostnamePolicyFile)
 	cmd.FailOnError(err, "Couldn't load hostname policy file")
 
+	if c.PA.IPAddresses != nil {
+		err = pa.SetIPAddressPolicy(c.PA.IPAddresses.AllowedRanges, c.PA.IPAddresses.AllowPrivate)
+		cmd.FailOnError(err, "Couldn't load IP address policy")
+	}
+
 	tlsConfig, err := c.RA.TLS.Load()
 	cmd.FailOnError(err, "TLS config")
 
@@ -228,6 +248,20 @@ func main() {
 		cmd.Fail("Error in RA config: MaxNames must not be 0")
 	}
 
+	var profiles *issuance.ProfilesConfig
+	if c.RA.CertificateProfilesFile != "" {
+		profiles, err = issuance.LoadProfilesConfig(c.RA.CertificateProfilesFile)
+		cmd.FailOnError(err, "Couldn't load certificate profiles")
+		var names []string
+		for name := range c.RA.CertificateProfiles {
+			names = append(names, name)
+		}
+		err = profiles.CheckNames(names)
+		cmd.FailOnError(err, "Error in RA config: certificateProfiles")
+	} else if len(c.RA.CertificateProfiles) > 0 {
+		cmd.Fail("Error in RA config: certificateProfiles requires certificateProfilesFile")
+	}
+
 	rai := ra.NewRegistrationAuthorityImpl(
 		clk,
 		logger,
@@ -241,6 +275,8 @@ func main() {
 		pubc,
 		caaClient,
 		c.RA.OrderLifetime.Duration,
+		profiles,
+		c.RA.CertificateProfiles,
 		ctp,
 		apc,
 		issuerCerts,
@@ -1,10 +1,17 @@
 package notmain
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	netmail "net/mail"
 	"os"
 	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
@@ -13,8 +20,11 @@ import (
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/features"
 	bgrpc "github.com/letsencrypt/boulder/grpc"
+	bmail "github.com/letsencrypt/boulder/mail"
+	rocsp_config "github.com/letsencrypt/boulder/rocsp/config"
 	"github.com/letsencrypt/boulder/va"
 	vapb "github.com/letsencrypt/boulder/va/proto"
+	"github.com/miekg/dns"
 )
 
 type Config struct {
@@ -40,17 +50,126 @@ type Config struct {
 		// will be turned into 1.
 		DNSTries    int
 		DNSResolver string
-		// Deprecated, replaced by singular DNSResolver above.
+		// DNSResolvers is a static list of resolvers, used if DNSResolver is
+		// unset. Each entry is either a host:port address of a plain DNS
+		// server, a "tls://host[:port]" URI of a DNS over TLS server, or an
+		// "https://host[:port][/path]" URL of a DNS over HTTPS server.
 		DNSResolvers              []string
 		DNSTimeout                string
 		DNSAllowLoopbackAddresses bool
+		// DNSTLS optionally provides the client certificate and trusted roots
+		// used to connect to DNS over TLS and DNS over HTTPS resolvers. If
+		// unset, no client certificate is sent and the system roots are
+		// trusted.
+		DNSTLS *cmd.TLSConfig
+		// DNSSECTrustAnchors optionally enables in-process DNSSEC validation
+		// of the resolvers' responses, building the chain of trust from these
+		// DS records in presentation format (usually those of the root zone).
+		// Responses failing validation are treated as DNS errors. If unset,
+		// the resolvers are trusted to validate DNSSEC themselves.
+		DNSSECTrustAnchors []string
 
-		RemoteVAs                   []cmd.GRPCClientConfig
+		RemoteVAs []struct {
+			cmd.GRPCClientConfig
+			// Perspective optionally labels the network perspective (for
+			// instance, the zone) from which the remote VA validates.
+			Perspective string
+		}
 		MaxRemoteValidationFailures int
+		// RemoteVAQuorum optionally requires, for each perspective it names,
+		// at least that many of the remote VAs labelled with the perspective
+		// to succeed, in addition to no more than MaxRemoteValidationFailures
+		// remote VAs failing overall. For example, {"zone-a": 1, "zone-b": 1}
+		// with MaxRemoteValidationFailures of 1 requires a success from each
+		// zone and at most one failure. The results of each perspective are
+		// recorded in the first validation record.
+		RemoteVAQuorum map[string]int
 
 		Features map[string]bool
 
 		AccountURIPrefixes []string
+
+		// EgressProxy optionally makes http-01 (including redirects) and
+		// tls-alpn-01 connections through a proxy, given as a URL of the
+		// form "http://[user:pass@]host:port" for an HTTP CONNECT proxy or
+		// "socks5://[user:pass@]host:port" for a SOCKS5 proxy. The VA still
+		// resolves validation targets itself, and asks the proxy to connect
+		// to the address it selected.
+		EgressProxy string
+
+		// HTTP01 optionally overrides the VA's policy for following redirects
+		// and reading responses during http-01 validation. Each refusal by
+		// the policy is counted by the http01_policy_rejections metric.
+		HTTP01 *struct {
+			// MaxRedirects is the number of redirects followed. Defaults to 10.
+			MaxRedirects int
+			// RedirectPorts are the ports a redirect target may specify
+			// explicitly. Defaults to the HTTPPort and HTTPSPort.
+			RedirectPorts []int
+			// VerifyHTTPSRedirects requires the targets of HTTPS redirects
+			// to present a certificate, trusted by the system roots, which
+			// is valid for their hostname.
+			VerifyHTTPSRedirects bool
+			// MaxResponseSize is the number of bytes of the response read,
+			// which the body must be shorter than. Defaults to 128.
+			MaxResponseSize int
+			// DeniedRedirectCIDRs are networks, such as "192.0.2.0/24", to
+			// which redirects aren't followed, in addition to the private
+			// ranges the resolver refuses.
+			DeniedRedirectCIDRs []string
+		}
+
+		// CAACache optionally enables caching of CAA lookups. If unset, CAA
+		// is looked up in the DNS for every check.
+		CAACache *struct {
+			// Redis, if set, stores the cache in Redis so that it is shared
+			// between VAs. Otherwise it is held in memory.
+			Redis *rocsp_config.RedisConfig
+			// MaxEntries is the number of names for which CAA lookups are
+			// held by the in-memory cache. Required unless Redis is set.
+			MaxEntries int
+			// ValidationMaxAge is how old a cached lookup may be when
+			// checking CAA while validating a challenge. It defaults to, and
+			// may not exceed, 1 hour, since the RA relies on that check for
+			// up to 7 hours and CAA may be at most 8 hours old at issuance.
+			// Rechecks before issuance never use the cache, and a cached
+			// lookup is never used beyond the TTL of its records.
+			ValidationMaxAge cmd.ConfigDuration
+			// NegativeTTL is how long the absence of CAA records at a name
+			// is cached. If unset, it isn't cached.
+			NegativeTTL cmd.ConfigDuration
+		}
+
+		// AdminGRPC optionally serves the VADebug service, which performs dry
+		// run validations for support staff, on a separate listener. Its
+		// ClientNames are the SANs of the administrative client certificates
+		// allowed to use it, and must be set.
+		AdminGRPC *cmd.GRPCServerConfig
+
+		// CAAIodef optionally enables reporting issuance refused because of
+		// CAA to the targets of the CAA set's iodef records. It should only be
+		// set for the primary VA, since remote VAs would report the same
+		// refusals again.
+		CAAIodef *struct {
+			// SMTPConfig configures the mail server through which reports to
+			// mailto: targets are sent. If Server is unset, they aren't sent.
+			cmd.SMTPConfig
+			From string
+			// Path to a file containing a list of trusted root certificates
+			// for use during the SMTP connection.
+			SMTPTrustedRootFile string
+			// Window is the period within which reports are deduplicated and
+			// rate limited. Each target is sent at most one report per window
+			// for each name and account, and at most MaxReportsPerDomain
+			// reports are sent about the names under each registered domain.
+			Window              cmd.ConfigDuration
+			MaxReportsPerDomain int
+			// QueueSize is the number of reports which may be waiting to be
+			// sent. Further reports are dropped.
+			QueueSize int
+			// Timeout bounds each report POSTed to an https: target.
+			Timeout cmd.ConfigDuration
+		}
 	}
 
 	Syslog  cmd.SyslogConfig
@@ -64,6 +183,7 @@ type Config struct {
 
 func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
+	adminAddr := flag.String("admin-addr", "", "Admin gRPC listen address override, if AdminGRPC is configured")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
 	flag.Parse()
@@ -82,6 +202,9 @@ func main() {
 	if *grpcAddr != "" {
 		c.VA.GRPC.Address = *grpcAddr
 	}
+	if *adminAddr != "" && c.VA.AdminGRPC != nil {
+		c.VA.AdminGRPC.Address = *adminAddr
+	}
 	if *debugAddr != "" {
 		c.VA.DebugAddr = *debugAddr
 	}
@@ -132,11 +255,29 @@ func main() {
 		cmd.FailOnError(err, "Couldn't parse static DNS server(s)")
 	}
 
+	var dnsTLSConfig *tls.Config
+	if c.VA.DNSTLS != nil {
+		dnsTLSConfig, err = c.VA.DNSTLS.Load()
+		cmd.FailOnError(err, "Couldn't load DNS TLS config")
+		// Load pins the version and cipher suite used between Boulder
+		// components, which resolvers needn't support.
+		dnsTLSConfig.MaxVersion = 0
+		dnsTLSConfig.CipherSuites = nil
+	}
+
+	var trustAnchors []*dns.DS
+	if len(c.VA.DNSSECTrustAnchors) > 0 {
+		trustAnchors, err = bdns.ParseTrustAnchors(c.VA.DNSSECTrustAnchors)
+		cmd.FailOnError(err, "Couldn't parse DNSSEC trust anchors")
+	}
+
 	var resolver bdns.Client
 	if !(c.VA.DNSAllowLoopbackAddresses || c.Common.DNSAllowLoopbackAddresses) {
 		resolver = bdns.New(
 			dnsTimeout,
 			servers,
+			dnsTLSConfig,
+			trustAnchors,
 			scope,
 			clk,
 			dnsTries,
@@ -145,6 +286,8 @@ func main() {
 		resolver = bdns.NewTest(
 			dnsTimeout,
 			servers,
+			dnsTLSConfig,
+			trustAnchors,
 			scope,
 			clk,
 			dnsTries,
@@ -159,29 +302,120 @@ func main() {
 	if len(c.VA.RemoteVAs) > 0 {
 		for _, rva := range c.VA.RemoteVAs {
 			rva := rva
-			vaConn, err := bgrpc.ClientSetup(&rva, tlsConfig, clientMetrics, clk)
+			vaConn, err := bgrpc.ClientSetup(&rva.GRPCClientConfig, tlsConfig, clientMetrics, clk)
 			cmd.FailOnError(err, "Unable to create remote VA client")
 			remotes = append(
 				remotes,
 				va.RemoteVA{
-					VAClient: vapb.NewVAClient(vaConn),
-					Address:  rva.ServerAddress,
+					VAClient:    vapb.NewVAClient(vaConn),
+					Address:     rva.ServerAddress,
+					Perspective: rva.Perspective,
 				},
 			)
 		}
 	}
 
+	var caaCache *va.CAACache
+	if c.VA.CAACache != nil {
+		var store va.CAACacheStore
+		if c.VA.CAACache.Redis != nil {
+			rdb, err := rocsp_config.MakeRedisClient(c.VA.CAACache.Redis)
+			cmd.FailOnError(err, "Unable to create Redis client for CAA cache")
+			store = va.NewRedisCAACacheStore(rdb, c.VA.CAACache.Redis.Timeout.Duration, clk)
+		} else {
+			if c.VA.CAACache.MaxEntries <= 0 {
+				cmd.Fail("CAACache.MaxEntries must be positive for an in-memory CAA cache")
+			}
+			store = va.NewMemoryCAACacheStore(c.VA.CAACache.MaxEntries, clk)
+		}
+		caaCache, err = va.NewCAACache(
+			store,
+			c.VA.CAACache.ValidationMaxAge.Duration,
+			c.VA.CAACache.NegativeTTL.Duration,
+			clk,
+			scope)
+		cmd.FailOnError(err, "Unable to create CAA cache")
+	}
+
+	var iodefReporter *va.IodefReporter
+	if c.VA.CAAIodef != nil {
+		conf := c.VA.CAAIodef
+		if conf.Window.Duration <= 0 || conf.MaxReportsPerDomain <= 0 || conf.QueueSize <= 0 || conf.Timeout.Duration <= 0 {
+			cmd.Fail("CAAIodef.Window, MaxReportsPerDomain, QueueSize and Timeout must be positive")
+		}
+		var mailer bmail.Mailer
+		if conf.Server != "" {
+			var smtpRoots *x509.CertPool
+			if conf.SMTPTrustedRootFile != "" {
+				pem, err := ioutil.ReadFile(conf.SMTPTrustedRootFile)
+				cmd.FailOnError(err, "Loading trusted roots file")
+				smtpRoots = x509.NewCertPool()
+				if !smtpRoots.AppendCertsFromPEM(pem) {
+					cmd.FailOnError(nil, "Failed to parse root certs PEM")
+				}
+			}
+			fromAddress, err := netmail.ParseAddress(conf.From)
+			cmd.FailOnError(err, fmt.Sprintf("Could not parse from address: %s", conf.From))
+			smtpPassword, err := conf.PasswordConfig.Pass()
+			cmd.FailOnError(err, "Failed to load SMTP password")
+			mailer = bmail.New(
+				conf.Server,
+				conf.Port,
+				conf.Username,
+				smtpPassword,
+				smtpRoots,
+				*fromAddress,
+				logger,
+				scope,
+				500*time.Millisecond,
+				5*time.Minute)
+		}
+		iodefReporter = va.NewIodefReporter(
+			mailer,
+			resolver,
+			c.VA.IssuerDomain,
+			conf.Window.Duration,
+			conf.MaxReportsPerDomain,
+			conf.QueueSize,
+			conf.Timeout.Duration,
+			clk,
+			logger,
+			scope)
+	}
+
+	var egressProxy *va.EgressProxy
+	if c.VA.EgressProxy != "" {
+		egressProxy, err = va.ParseEgressProxy(c.VA.EgressProxy)
+		cmd.FailOnError(err, "Unable to parse egress proxy")
+	}
+
+	var http01Policy *va.HTTP01Policy
+	if c.VA.HTTP01 != nil {
+		http01Policy, err = va.NewHTTP01Policy(
+			c.VA.HTTP01.MaxRedirects,
+			c.VA.HTTP01.RedirectPorts,
+			c.VA.HTTP01.VerifyHTTPSRedirects,
+			c.VA.HTTP01.MaxResponseSize,
+			c.VA.HTTP01.DeniedRedirectCIDRs)
+		cmd.FailOnError(err, "Unable to configure http-01 policy")
+	}
+
 	vai, err := va.NewValidationAuthorityImpl(
 		pc,
 		resolver,
 		remotes,
 		c.VA.MaxRemoteValidationFailures,
+		c.VA.RemoteVAQuorum,
 		c.VA.UserAgent,
 		c.VA.IssuerDomain,
 		scope,
 		clk,
 		logger,
-		c.VA.AccountURIPrefixes)
+		c.VA.AccountURIPrefixes,
+		caaCache,
+		iodefReporter,
+		egressProxy,
+		http01Policy)
 	cmd.FailOnError(err, "Unable to create VA server")
 
 	serverMetrics := bgrpc.NewServerMetrics(scope)
@@ -194,10 +428,33 @@ func main() {
 	hs := health.NewServer()
 	healthpb.RegisterHealthServer(grpcSrv, hs)
 
+	// The VADebug service is optional, and is only served to the
+	// administrative clients named in its own configuration.
+	var adminSrv *grpc.Server
+	if c.VA.AdminGRPC != nil {
+		if len(c.VA.AdminGRPC.ClientNames) == 0 {
+			cmd.Fail("AdminGRPC.ClientNames must list the administrative clients allowed to use it")
+		}
+		var adminListener net.Listener
+		adminSrv, adminListener, err = bgrpc.NewServer(c.VA.AdminGRPC, tlsConfig, serverMetrics, clk)
+		cmd.FailOnError(err, "Unable to setup VA admin gRPC server")
+		vapb.RegisterVADebugServer(adminSrv, vai)
+		go func() {
+			cmd.FailOnError(cmd.FilterShutdownErrors(adminSrv.Serve(adminListener)),
+				"VA admin gRPC service failed")
+		}()
+	}
+
 	go cmd.CatchSignals(logger, func() {
 		servers.Stop()
 		hs.Shutdown()
 		grpcSrv.GracefulStop()
+		if adminSrv != nil {
+			adminSrv.GracefulStop()
+		}
+		if iodefReporter != nil {
+			iodefReporter.Stop()
+		}
 	})
 
 	err = cmd.FilterShutdownErrors(grpcSrv.Serve(l))
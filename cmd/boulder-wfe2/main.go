@@ -92,6 +92,15 @@ type Config struct {
 		// DirectoryWebsite is used for the /directory response's "meta" element's
 		// "website" field.
 		DirectoryWebsite string
+		// DirectoryProfiles is used for the /directory response's "meta"
+		// element's "profiles" field. It maps the names of the certificate
+		// profiles clients may request to human-readable descriptions, and
+		// should match the RA's "certificateProfiles" configuration value.
+		DirectoryProfiles map[string]string
+		// CertificateProfilesFile is the path of the JSON file containing the
+		// CA's certificate profiles, the same file as the CA's "profilesFile".
+		// Every name in DirectoryProfiles must be one of its named profiles.
+		CertificateProfilesFile string
 
 		// ACMEv2 requests (outside some registration/revocation messages) use a JWS with
 		// a KeyID header containing the full account URL. For new accounts this
@@ -447,6 +456,19 @@ func main() {
 	wfe.AllowOrigins = c.WFE.AllowOrigins
 	wfe.DirectoryCAAIdentity = c.WFE.DirectoryCAAIdentity
 	wfe.DirectoryWebsite = c.WFE.DirectoryWebsite
+	if c.WFE.CertificateProfilesFile != "" {
+		profiles, err := issuance.LoadProfilesConfig(c.WFE.CertificateProfilesFile)
+		cmd.FailOnError(err, "Couldn't load certificate profiles")
+		var names []string
+		for name := range c.WFE.DirectoryProfiles {
+			names = append(names, name)
+		}
+		err = profiles.CheckNames(names)
+		cmd.FailOnError(err, "Error in WFE config: directoryProfiles")
+	} else if len(c.WFE.DirectoryProfiles) > 0 {
+		cmd.Fail("Error in WFE config: directoryProfiles requires certificateProfilesFile")
+	}
+	wfe.DirectoryProfiles = c.WFE.DirectoryProfiles
 	wfe.LegacyKeyIDPrefix = c.WFE.LegacyKeyIDPrefix
 
 	logger.Infof("WFE using key policy: %#v", kp)
@@ -117,6 +117,20 @@ type SMTPConfig struct {
 type PAConfig struct {
 	DBConfig
 	Challenges map[core.AcmeChallenge]bool
+	// IPAddresses configures issuance for IP address identifiers (RFC 8738).
+	// If omitted, IP address identifiers are refused.
+	IPAddresses *IPAddressPolicyConfig
+}
+
+// IPAddressPolicyConfig specifies which IP addresses the policy authority is
+// willing to issue for.
+type IPAddressPolicyConfig struct {
+	// AllowedRanges is a list of CIDR ranges that IP address identifiers must
+	// fall within. If empty, any address is permitted.
+	AllowedRanges []string
+	// AllowPrivate permits IP address identifiers in private and otherwise
+	// reserved ranges, e.g. 10.0.0.0/8 or fc00::/7.
+	AllowPrivate bool
 }
 
 // CheckChallenges checks whether the list of challenges in the PA config
@@ -0,0 +1,157 @@
+package notmain
+
+import (
+	"flag"
+	"os"
+
+	"github.com/honeycombio/beeline-go"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/crl/updater"
+	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/issuance"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+type Config struct {
+	CRLUpdater struct {
+		cmd.ServiceConfig
+
+		SAService           *cmd.GRPCClientConfig
+		CRLGeneratorService *cmd.GRPCClientConfig
+
+		// IssuerCerts is a list of paths to issuer certificates on disk. A CRL
+		// is produced for each of them, so the CA must have a signer for each.
+		IssuerCerts []string
+
+		// NumShards is the number of CRL shards of each issuer, and must
+		// match the crlShards of every issuer in the CA's config. CRLs are
+		// produced for shards 1 through NumShards, and for shard 0, which
+		// holds the certificates issued without a shard.
+		NumShards int
+
+		// UpdatePeriod is how often a new CRL is produced for each issuer. It
+		// must be comfortably shorter than the CA's LifespanCRL, so that a
+		// fresh CRL is always published before the previous one expires.
+		UpdatePeriod cmd.ConfigDuration
+
+		// LookbackPeriod is how long revoked certificates remain on the CRL
+		// after they expire. It must be at least the UpdatePeriod, so that
+		// every revoked certificate appears on at least one CRL after expiry.
+		LookbackPeriod cmd.ConfigDuration
+
+		// CertificateLifetime is the longest validity period of any
+		// certificate issued by these issuers.
+		CertificateLifetime cmd.ConfigDuration
+
+		// UpdateTimeout bounds the time spent producing and storing a single
+		// CRL. Defaults to the UpdatePeriod.
+		UpdateTimeout cmd.ConfigDuration
+
+		// Exactly one of OutputDirectory and UploadURL must be set.
+		// OutputDirectory is a local directory into which each CRL is written
+		// as <issuerNameID>/<shard>.crl. UploadURL is a base URL, ending in a
+		// slash, beneath which each CRL is uploaded to the same relative path
+		// with an HTTP PUT.
+		OutputDirectory string
+		UploadURL       string
+
+		Features map[string]bool
+	}
+
+	Syslog  cmd.SyslogConfig
+	Beeline cmd.BeelineConfig
+}
+
+func main() {
+	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	flag.Parse()
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c Config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	conf := c.CRLUpdater
+	err = features.Set(conf.Features)
+	cmd.FailOnError(err, "Failed to set feature flags")
+
+	if *debugAddr != "" {
+		conf.DebugAddr = *debugAddr
+	}
+
+	bc, err := c.Beeline.Load()
+	cmd.FailOnError(err, "Failed to load Beeline config")
+	beeline.Init(bc)
+	defer beeline.Close()
+
+	stats, logger := cmd.StatsAndLogging(c.Syslog, conf.DebugAddr)
+	defer logger.AuditPanic()
+	logger.Info(cmd.VersionString())
+
+	clk := cmd.Clock()
+
+	issuers := make([]*issuance.Certificate, 0, len(conf.IssuerCerts))
+	for _, filepath := range conf.IssuerCerts {
+		cert, err := issuance.LoadCertificate(filepath)
+		cmd.FailOnError(err, "Failed to load issuer cert")
+		issuers = append(issuers, cert)
+	}
+
+	var storer updater.Storer
+	switch {
+	case conf.OutputDirectory != "" && conf.UploadURL != "":
+		cmd.Fail("Only one of outputDirectory and uploadURL may be set")
+	case conf.OutputDirectory != "":
+		storer, err = updater.NewLocalStorer(conf.OutputDirectory)
+		cmd.FailOnError(err, "Failed to create local CRL storer")
+	case conf.UploadURL != "":
+		storer, err = updater.NewHTTPStorer(conf.UploadURL, nil)
+		cmd.FailOnError(err, "Failed to create HTTP CRL storer")
+	default:
+		cmd.Fail("One of outputDirectory and uploadURL must be set")
+	}
+
+	tlsConfig, err := conf.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+	clientMetrics := bgrpc.NewClientMetrics(stats)
+
+	saConn, err := bgrpc.ClientSetup(conf.SAService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	sac := sapb.NewStorageAuthorityClient(saConn)
+
+	caConn, err := bgrpc.ClientSetup(conf.CRLGeneratorService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to CRLGenerator")
+	cgc := capb.NewCRLGeneratorClient(caConn)
+
+	u, err := updater.New(
+		issuers,
+		conf.NumShards,
+		sac,
+		cgc,
+		storer,
+		conf.UpdatePeriod.Duration,
+		conf.LookbackPeriod.Duration,
+		conf.CertificateLifetime.Duration,
+		conf.UpdateTimeout.Duration,
+		stats,
+		logger,
+		clk,
+	)
+	cmd.FailOnError(err, "Failed to create crl-updater")
+
+	go cmd.CatchSignals(logger, nil)
+	for {
+		u.Tick()
+	}
+}
+
+func init() {
+	cmd.RegisterCommand("crl-updater", main)
+}
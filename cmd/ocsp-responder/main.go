@@ -12,17 +12,21 @@ import (
 
 	"github.com/honeycombio/beeline-go"
 	"github.com/honeycombio/beeline-go/wrappers/hnynethttp"
+	"github.com/jmhodges/clock"
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	capb "github.com/letsencrypt/boulder/ca/proto"
 	"github.com/letsencrypt/boulder/cmd"
 	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics/measured_http"
 	"github.com/letsencrypt/boulder/ocsp/responder"
 	rocsp_config "github.com/letsencrypt/boulder/rocsp/config"
 	"github.com/letsencrypt/boulder/sa"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
 )
 
 type Config struct {
@@ -42,8 +46,9 @@ type Config struct {
 
 		Path          string
 		ListenAddress string
-		// MaxAge is the max-age to set in the Cache-Control response
-		// header. It is a time.Duration formatted string.
+		// MaxAge is the longest max-age to set in the Cache-Control response
+		// header. The max-age is otherwise the time remaining until the
+		// response's nextUpdate. It is a time.Duration formatted string.
 		MaxAge cmd.ConfigDuration
 
 		// When to timeout a request. This should be slightly lower than the
@@ -57,12 +62,42 @@ type Config struct {
 		Features map[string]bool
 
 		Redis rocsp_config.RedisConfig
+
+		// LiveSigning, if set, causes responses missing from Redis to be
+		// signed on demand by the CA and written back to Redis, in place of
+		// looking them up in the database. It requires Redis to be configured,
+		// with credentials which are allowed to write, and DB and Source are
+		// then only used if Source is a file URL.
+		LiveSigning *LiveSigningConfig
 	}
 
 	Syslog  cmd.SyslogConfig
 	Beeline cmd.BeelineConfig
 }
 
+// LiveSigningConfig configures signing OCSP responses on demand when they are
+// missing from Redis.
+type LiveSigningConfig struct {
+	TLS                  cmd.TLSConfig
+	SAService            *cmd.GRPCClientConfig
+	OCSPGeneratorService *cmd.GRPCClientConfig
+
+	// Issuers is a map from filenames to short issuer IDs, which must match
+	// those used by the other OCSP components.
+	Issuers map[string]int
+
+	// MaxSigningRate is the maximum number of responses signed per second, and
+	// MaxSigningBurst is the number which may be signed at once after a quiet
+	// period.
+	MaxSigningRate  float64
+	MaxSigningBurst int
+
+	// SigningTimeout is how long signing a response may take. It isn't tied
+	// to the request which caused the signing, since other requests may be
+	// waiting for the same response. Defaults to the responder's Timeout.
+	SigningTimeout cmd.ConfigDuration
+}
+
 func main() {
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
 	flag.Parse()
@@ -108,26 +143,35 @@ as generated by Boulder's ceremony command.
 		source, err = responder.NewMemorySourceFromFile(filename, logger)
 		cmd.FailOnError(err, fmt.Sprintf("Couldn't read file: %s", url.Path))
 	} else {
-		// Set DB.DBConnect as a fallback if DB.DBConnectFile isn't present.
-		config.DB.DBConnect = config.Source
-
-		dbMap, err := sa.InitWrappedDb(config.DB, stats, logger)
-		cmd.FailOnError(err, "While initializing dbMap")
-
-		source, err = responder.NewDbSource(dbMap, stats, logger)
-		cmd.FailOnError(err, "Could not create database source")
-
-		// Set up the redis source and the combined multiplex source if there is a
-		// config for it. Otherwise just pass through the existing mysql source.
-		if c.OCSPResponder.Redis.Addrs != nil {
-			rocspReader, err := rocsp_config.MakeReadClient(&c.OCSPResponder.Redis, clk, stats)
-			cmd.FailOnError(err, "Could not make redis client")
-
-			rocspSource, err := responder.NewRedisSource(rocspReader, stats, logger)
-			cmd.FailOnError(err, "Could not create redis source")
-
-			source, err = responder.NewMultiSource(source, rocspSource, stats, logger)
-			cmd.FailOnError(err, "Could not create multiplex source")
+		if config.LiveSigning != nil {
+			// Responses missing from Redis are signed on demand, so the
+			// database of pre-signed responses isn't needed.
+			if config.Redis.Addrs == nil {
+				cmd.Fail("Live signing requires a Redis config")
+			}
+			source = newLiveSigningSource(&config.Redis, config.LiveSigning, config.Timeout.Duration, clk, stats, logger)
+		} else {
+			// Set DB.DBConnect as a fallback if DB.DBConnectFile isn't present.
+			config.DB.DBConnect = config.Source
+
+			dbMap, err := sa.InitWrappedDb(config.DB, stats, logger)
+			cmd.FailOnError(err, "While initializing dbMap")
+
+			source, err = responder.NewDbSource(dbMap, stats, logger)
+			cmd.FailOnError(err, "Could not create database source")
+
+			// Set up the redis source and the combined multiplex source if there is a
+			// config for it. Otherwise just pass through the existing mysql source.
+			if c.OCSPResponder.Redis.Addrs != nil {
+				rocspReader, err := rocsp_config.MakeReadClient(&c.OCSPResponder.Redis, clk, stats)
+				cmd.FailOnError(err, "Could not make redis client")
+
+				rocspSource, err := responder.NewRedisSource(rocspReader, stats, logger)
+				cmd.FailOnError(err, "Could not create redis source")
+
+				source, err = responder.NewMultiSource(source, rocspSource, stats, logger)
+				cmd.FailOnError(err, "Could not create multiplex source")
+			}
 		}
 
 		// Load the certificate from the file path.
@@ -144,11 +188,12 @@ as generated by Boulder's ceremony command.
 			source,
 			stats,
 			logger,
+			clk,
 		)
 		cmd.FailOnError(err, "Could not create filtered source")
 	}
 
-	m := mux(c.OCSPResponder.Path, source, c.OCSPResponder.Timeout.Duration, stats, logger)
+	m := mux(c.OCSPResponder.Path, source, c.OCSPResponder.Timeout.Duration, c.OCSPResponder.MaxAge.Duration, stats, logger)
 	srv := &http.Server{
 		Addr:    c.OCSPResponder.ListenAddress,
 		Handler: m,
@@ -175,6 +220,50 @@ as generated by Boulder's ceremony command.
 	<-done
 }
 
+// newLiveSigningSource returns a Source which serves responses from Redis, and
+// signs those missing from Redis on demand. Signing takes up to the configured
+// SigningTimeout, or requestTimeout if that isn't set.
+func newLiveSigningSource(redisConfig *rocsp_config.RedisConfig, config *LiveSigningConfig, requestTimeout time.Duration, clk clock.Clock, stats prometheus.Registerer, logger blog.Logger) responder.Source {
+	rocspWriter, err := rocsp_config.MakeClient(redisConfig, clk, stats)
+	cmd.FailOnError(err, "Could not make redis client")
+
+	redisSource, err := responder.NewRedisSource(rocspWriter.Client, stats, logger)
+	cmd.FailOnError(err, "Could not create redis source")
+
+	issuers, err := rocsp_config.LoadIssuers(config.Issuers)
+	cmd.FailOnError(err, "Could not load issuers")
+
+	tlsConfig, err := config.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+	clientMetrics := bgrpc.NewClientMetrics(stats)
+
+	saConn, err := bgrpc.ClientSetup(config.SAService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	caConn, err := bgrpc.ClientSetup(config.OCSPGeneratorService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to CA")
+
+	signingTimeout := config.SigningTimeout.Duration
+	if signingTimeout == 0 {
+		signingTimeout = requestTimeout
+	}
+
+	source, err := responder.NewLiveSigningSource(
+		redisSource,
+		sapb.NewStorageAuthorityClient(saConn),
+		capb.NewOCSPGeneratorClient(caConn),
+		rocspWriter,
+		issuers,
+		signingTimeout,
+		config.MaxSigningRate,
+		config.MaxSigningBurst,
+		stats,
+		logger,
+		clk,
+	)
+	cmd.FailOnError(err, "Could not create live signing source")
+	return source
+}
+
 // ocspMux partially implements the interface defined for http.ServeMux but doesn't implement
 // the path cleaning its Handler method does. Notably http.ServeMux will collapse repeated
 // slashes into a single slash which breaks the base64 encoding that is used in OCSP GET
@@ -188,8 +277,8 @@ func (om *ocspMux) Handler(_ *http.Request) (http.Handler, string) {
 	return om.handler, "/"
 }
 
-func mux(responderPath string, source responder.Source, timeout time.Duration, stats prometheus.Registerer, logger blog.Logger) http.Handler {
-	stripPrefix := http.StripPrefix(responderPath, responder.NewResponder(source, timeout, stats, logger))
+func mux(responderPath string, source responder.Source, timeout time.Duration, maxAge time.Duration, stats prometheus.Registerer, logger blog.Logger) http.Handler {
+	stripPrefix := http.StripPrefix(responderPath, responder.NewResponder(source, timeout, maxAge, stats, logger))
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "GET" && r.URL.Path == "/" {
 			w.Header().Set("Cache-Control", "max-age=43200") // Cache for 12 hours
@@ -92,10 +92,13 @@ func (t orphanType) String() string {
 // "orphaning", "(pre)?certificate", "cert=[\w+]", "issuerID=[\d+]", and "regID=[\d]".
 // For example:
 // `[AUDIT] Failed RPC to store at SA, orphaning precertificate: serial=[04asdf1234], cert=[MIIdeafbeef], issuerID=[112358], regID=[1001], orderID=[1002], err=[Timed out]`
-// The orphan-finder does not care about the serial, error, or orderID.
+// The orphan-finder does not care about the serial, error, or orderID. Lines
+// for precertificates may also contain "crlShard=[\d+]"; if absent, the
+// certificate was issued without a CRL shard.
 type parsedLine struct {
 	certDER  []byte
 	issuerID int64
+	crlShard int64
 	regID    int64
 }
 
@@ -103,6 +106,7 @@ var (
 	derOrphan        = regexp.MustCompile(`cert=\[([0-9a-f]+)\]`)
 	regOrphan        = regexp.MustCompile(`regID=\[(\d+)\]`)
 	issuerOrphan     = regexp.MustCompile(`issuerID=\[(\d+)\]`)
+	crlShardOrphan   = regexp.MustCompile(`crlShard=\[(\d+)\]`)
 	errAlreadyExists = fmt.Errorf("Certificate already exists in DB")
 )
 
@@ -182,10 +186,20 @@ func parseLogLine(line string, logger blog.Logger) (parsedLine, error) {
 		return parsedLine{}, fmt.Errorf("unable to parse issuerID from [%s]: %s", line, err)
 	}
 
+	var crlShard int64
+	crlShardStr := crlShardOrphan.FindStringSubmatch(line)
+	if len(crlShardStr) > 1 {
+		crlShard, err = strconv.ParseInt(crlShardStr[1], 10, 64)
+		if err != nil {
+			return parsedLine{}, fmt.Errorf("unable to parse crlShard from [%s]: %s", line, err)
+		}
+	}
+
 	return parsedLine{
 		certDER:  der,
 		regID:    regID,
 		issuerID: issuerID,
+		crlShard: crlShard,
 	}, nil
 }
 
@@ -332,6 +346,7 @@ func (opf *orphanFinder) storeLogLine(ctx context.Context, line string) (found b
 			Ocsp:     response,
 			Issued:   issuedDate.UnixNano(),
 			IssuerID: parsed.issuerID,
+			CrlShard: parsed.crlShard,
 		})
 	default:
 		// Shouldn't happen but be defensive anyway
@@ -306,3 +306,20 @@ func TestNotOrphan(t *testing.T) {
 		}
 	}
 }
+
+func TestParseLogLineCRLShard(t *testing.T) {
+	line := "[AUDIT] Failed RPC to store at SA, orphaning precertificate: " +
+		"serial=[unused], cert=[deadbeef], issuerID=[1], crlShard=[7], regID=[2], orderID=[3], err=[context deadline exceeded]"
+	parsed, err := parseLogLine(line, blog.UseMock())
+	test.AssertNotError(t, err, "parsing log line")
+	test.AssertEquals(t, parsed.issuerID, int64(1))
+	test.AssertEquals(t, parsed.crlShard, int64(7))
+	test.AssertEquals(t, parsed.regID, int64(2))
+
+	// Lines logged before CRL sharding have no crlShard.
+	line = "[AUDIT] Failed RPC to store at SA, orphaning precertificate: " +
+		"serial=[unused], cert=[deadbeef], issuerID=[1], regID=[2], orderID=[3], err=[context deadline exceeded]"
+	parsed, err = parseLogLine(line, blog.UseMock())
+	test.AssertNotError(t, err, "parsing log line")
+	test.AssertEquals(t, parsed.crlShard, int64(0))
+}
@@ -3,6 +3,7 @@ package notmain
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -62,40 +63,23 @@ func getStartingID(ctx context.Context, clk clock.Clock, db *sql.DB) (int64, err
 }
 
 func (cl *client) loadFromDB(ctx context.Context, speed ProcessingSpeed, startFromID int64) error {
-	prevID := startFromID
-	var err error
-	if prevID == 0 {
-		prevID, err = getStartingID(ctx, cl.clk, cl.db)
-		if err != nil {
-			return fmt.Errorf("getting starting ID: %w", err)
-		}
-	}
-
-	// Find the current maximum id in certificateStatus. We do this because the table is always
-	// growing. If we scanned until we saw a batch with no rows, we would scan forever.
-	var maxID *int64
-	err = cl.db.QueryRowContext(
-		ctx,
-		"SELECT MAX(id) FROM certificateStatus",
-	).Scan(&maxID)
+	prevID, maxID, err := cl.scanRange(ctx, startFromID)
 	if err != nil {
-		return fmt.Errorf("selecting maxID: %w", err)
-	}
-	if maxID == nil {
-		return fmt.Errorf("no entries in certificateStatus")
+		return err
 	}
 
 	// Limit the rate of reading rows.
 	frequency := time.Duration(float64(time.Second) / float64(time.Duration(speed.RowsPerSecond)))
 	// a set of all inflight certificate statuses, indexed by their `ID`.
 	inflightIDs := newInflight()
-	statusesToSign := cl.scanFromDB(ctx, prevID, *maxID, frequency, inflightIDs)
+	statusesToSign := cl.scanFromDB(ctx, prevID, maxID, frequency, inflightIDs)
 
 	results := make(chan processResult, speed.ParallelSigns)
-	var runningSigners int32
+	signed := cl.signResponses(ctx, statusesToSign, speed.ParallelSigns, results)
+	var runningStorers int32
 	for i := 0; i < speed.ParallelSigns; i++ {
-		atomic.AddInt32(&runningSigners, 1)
-		go cl.signAndStoreResponses(ctx, statusesToSign, results, &runningSigners)
+		atomic.AddInt32(&runningStorers, 1)
+		go cl.storeResponses(ctx, signed, results, &runningStorers)
 	}
 
 	var successCount, errorCount int64
@@ -127,6 +111,35 @@ func (cl *client) loadFromDB(ctx context.Context, speed ProcessingSpeed, startFr
 	return nil
 }
 
+// scanRange returns the IDs in certificateStatus to scan between: from
+// startFromID or, if that is zero, from the first row which may belong to a
+// currently-valid certificate, up to the current maximum ID.
+func (cl *client) scanRange(ctx context.Context, startFromID int64) (int64, int64, error) {
+	prevID := startFromID
+	var err error
+	if prevID == 0 {
+		prevID, err = getStartingID(ctx, cl.clk, cl.db)
+		if err != nil {
+			return 0, 0, fmt.Errorf("getting starting ID: %w", err)
+		}
+	}
+
+	// Find the current maximum id in certificateStatus. We do this because the table is always
+	// growing. If we scanned until we saw a batch with no rows, we would scan forever.
+	var maxID *int64
+	err = cl.db.QueryRowContext(
+		ctx,
+		"SELECT MAX(id) FROM certificateStatus",
+	).Scan(&maxID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("selecting maxID: %w", err)
+	}
+	if maxID == nil {
+		return 0, 0, fmt.Errorf("no entries in certificateStatus")
+	}
+	return prevID, *maxID, nil
+}
+
 // scanFromDB scans certificateStatus rows from the DB, starting with `minID`, and writes them to
 // its output channel at a maximum frequency of `frequency`. When it's read all available rows, it
 // closes its output channel and exits.
@@ -179,6 +192,12 @@ func (cl *client) scanFromDBOneBatch(ctx context.Context, prevID int64, frequenc
 			return -1, fmt.Errorf("scanning row %d (previous ID %d): %w", scanned, previousID, err)
 		}
 		scanned++
+		previousID = status.ID
+		// Short-lived certificates never get OCSP responses, so there is
+		// nothing to load or verify for them.
+		if status.IsShortLived {
+			continue
+		}
 		inflightIDs.add(uint64(status.ID))
 		// Emit a log line every 100000 rows. For our current ~215M rows, that
 		// will emit about 2150 log lines. This probably strikes a good balance
@@ -187,42 +206,177 @@ func (cl *client) scanFromDBOneBatch(ctx context.Context, prevID int64, frequenc
 			cl.logger.Infof("scanned %d certificateStatus rows. minimum inflight ID %d", scanned, inflightIDs.min())
 		}
 		output <- status
-		previousID = status.ID
 	}
 	return previousID, nil
 }
 
-// signAndStoreResponses consumes cert statuses on its input channel and writes them to its output
-// channel. Before returning, it atomically decrements the provided runningSigners int. If the
-// result is 0, indicating this was the last running signer, it closes its output channel.
-func (cl *client) signAndStoreResponses(ctx context.Context, input <-chan *sa.CertStatusMetadata, output chan processResult, runningSigners *int32) {
-	defer func() {
-		if atomic.AddInt32(runningSigners, -1) <= 0 {
-			close(output)
+// signedStatus is a cert status along with a newly signed OCSP response for it.
+type signedStatus struct {
+	status   *sa.CertStatusMetadata
+	response []byte
+}
+
+// maxSignStreamAttempts is the number of GenerateOCSPStream calls made by
+// signResponses. When a stream breaks, the statuses it didn't answer are sent
+// again on a new stream, up to this many times in total.
+const maxSignStreamAttempts = 3
+
+// signResponses consumes cert statuses on its input channel and sends them to
+// the CA to be signed over a GenerateOCSPStream call, keeping up to inFlight
+// requests outstanding at a time. If the stream breaks, the statuses it didn't
+// answer are sent again on a new one. The signed responses are written to the
+// returned channel, which is closed once the input is exhausted. Statuses
+// which could not be signed are written to output as failures.
+func (cl *client) signResponses(ctx context.Context, input <-chan *sa.CertStatusMetadata, inFlight int, output chan<- processResult) <-chan signedStatus {
+	signed := make(chan signedStatus)
+	go func() {
+		defer close(signed)
+		var retry []*sa.CertStatusMetadata
+		for attempt := 1; ; attempt++ {
+			unanswered, err := cl.signStream(ctx, retry, input, inFlight, output, signed)
+			if err == nil {
+				return
+			}
+			cl.logger.Infof("OCSP signing stream failed with %d requests unanswered: %s", len(unanswered), err)
+			if attempt >= maxSignStreamAttempts || ctx.Err() != nil {
+				for _, status := range unanswered {
+					output <- processResult{id: uint64(status.ID), err: err}
+				}
+				for status := range input {
+					output <- processResult{id: uint64(status.ID), err: err}
+				}
+				return
+			}
+			retry = unanswered
+		}
+	}()
+	return signed
+}
+
+// signStream sends the statuses in retry, followed by those received from
+// input, to the CA over a single GenerateOCSPStream call, and writes the
+// signed responses to signed. If the stream breaks, it stops reading from
+// input and returns the error along with the statuses which weren't answered,
+// in order.
+func (cl *client) signStream(ctx context.Context, retry []*sa.CertStatusMetadata, input <-chan *sa.CertStatusMetadata, inFlight int, output chan<- processResult, signed chan<- signedStatus) ([]*sa.CertStatusMetadata, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := cl.ocspGenerator.GenerateOCSPStream(ctx)
+	if err != nil {
+		return retry, err
+	}
+
+	// pending holds the statuses whose requests have been sent, in the order
+	// they were sent. The CA responds in the same order. The receiver closes
+	// stop if the stream breaks. Once pending is closed, unsent holds the
+	// statuses the sender took but didn't send.
+	pending := make(chan *sa.CertStatusMetadata, inFlight)
+	stop := make(chan struct{})
+	var unsent []*sa.CertStatusMetadata
+	var sendErr error
+	go func() {
+		defer close(pending)
+		send := func(status *sa.CertStatusMetadata) bool {
+			err := stream.Send(&capb.GenerateOCSPRequest{
+				Serial:    status.Serial,
+				IssuerID:  status.IssuerID,
+				Status:    string(status.Status),
+				Reason:    int32(status.RevokedReason),
+				RevokedAt: status.RevokedDate.UnixNano(),
+			})
+			if err != nil {
+				sendErr = err
+				unsent = append(unsent, status)
+				return false
+			}
+			select {
+			case pending <- status:
+				return true
+			case <-stop:
+				unsent = append(unsent, status)
+				return false
+			}
+		}
+		for i, status := range retry {
+			if !send(status) {
+				unsent = append(unsent, retry[i+1:]...)
+				return
+			}
+		}
+		for {
+			select {
+			case <-stop:
+				return
+			case status, ok := <-input:
+				if !ok {
+					_ = stream.CloseSend()
+					return
+				}
+				if !send(status) {
+					return
+				}
+			}
 		}
 	}()
-	for status := range input {
-		ocspReq := &capb.GenerateOCSPRequest{
-			Serial:    status.Serial,
-			IssuerID:  status.IssuerID,
-			Status:    string(status.Status),
-			Reason:    int32(status.RevokedReason),
-			RevokedAt: status.RevokedDate.UnixNano(),
+
+	var unanswered []*sa.CertStatusMetadata
+	var recvErr error
+	for status := range pending {
+		if recvErr != nil {
+			unanswered = append(unanswered, status)
+			continue
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			// The stream is broken, so none of the outstanding requests
+			// will be answered on it.
+			recvErr = err
+			close(stop)
+			cancel()
+			unanswered = append(unanswered, status)
+			continue
+		}
+		if res.Error != "" {
+			err = errors.New(res.Error)
+		} else if res.Serial != status.Serial {
+			err = fmt.Errorf("received response for serial %s, expected %s", res.Serial, status.Serial)
 		}
-		result, err := cl.ocspGenerator.GenerateOCSP(ctx, ocspReq)
 		if err != nil {
 			output <- processResult{id: uint64(status.ID), err: err}
 			continue
 		}
-		// ttl is the lifetime of the certificate
-		ttl := cl.clk.Now().Sub(status.NotAfter)
+		signed <- signedStatus{status: status, response: res.Response}
+	}
+	unanswered = append(unanswered, unsent...)
+	if recvErr != nil {
+		return unanswered, recvErr
+	}
+	if sendErr != nil {
+		return unanswered, sendErr
+	}
+	return nil, nil
+}
+
+// storeResponses consumes signed responses on its input channel, stores them in
+// Redis, and writes the results to its output channel. Before returning, it
+// atomically decrements the provided runningStorers int. If the result is 0,
+// indicating this was the last running storer, it closes its output channel.
+func (cl *client) storeResponses(ctx context.Context, input <-chan signedStatus, output chan processResult, runningStorers *int32) {
+	defer func() {
+		if atomic.AddInt32(runningStorers, -1) <= 0 {
+			close(output)
+		}
+	}()
+	for signed := range input {
+		status := signed.status
+		ttl := responseTTL(status.NotAfter, cl.clk.Now())
 		issuer, err := rocsp_config.FindIssuerByID(status.IssuerID, cl.issuers)
 		if err != nil {
 			output <- processResult{id: uint64(status.ID), err: err}
 			continue
 		}
 
-		err = cl.redis.StoreResponse(ctx, result.Response, issuer.ShortID(), ttl)
+		err = cl.redis.StoreResponse(ctx, signed.response, issuer.ShortID(), ttl)
 		if err != nil {
 			output <- processResult{id: uint64(status.ID), err: err}
 		} else {
@@ -231,6 +385,12 @@ func (cl *client) signAndStoreResponses(ctx context.Context, input <-chan *sa.Ce
 	}
 }
 
+// responseTTL returns how long a response for a certificate expiring at
+// notAfter should be kept in Redis: the certificate's remaining lifetime.
+func responseTTL(notAfter, now time.Time) time.Duration {
+	return notAfter.Sub(now)
+}
+
 type expiredError struct {
 	serial string
 	ago    time.Duration
@@ -2,7 +2,9 @@ package notmain
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"testing"
 	"time"
@@ -116,6 +118,11 @@ func TestStoreResponse(t *testing.T) {
 	test.AssertNotError(t, err, "storing response")
 }
 
+func TestResponseTTL(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	test.AssertEquals(t, responseTTL(now.Add(200*time.Hour), now), 200*time.Hour)
+}
+
 type mockOCSPGenerator struct{}
 
 func (mog mockOCSPGenerator) GenerateOCSP(ctx context.Context, in *capb.GenerateOCSPRequest, opts ...grpc.CallOption) (*capb.OCSPResponse, error) {
@@ -125,6 +132,103 @@ func (mog mockOCSPGenerator) GenerateOCSP(ctx context.Context, in *capb.Generate
 
 }
 
+func (mog mockOCSPGenerator) GenerateOCSPStream(ctx context.Context, opts ...grpc.CallOption) (capb.OCSPGenerator_GenerateOCSPStreamClient, error) {
+	return &mockOCSPStream{serials: make(chan string, 100)}, nil
+}
+
+// mockOCSPStream answers each request with a fixed response, in order.
+type mockOCSPStream struct {
+	grpc.ClientStream
+	serials chan string
+}
+
+func (m *mockOCSPStream) Send(req *capb.GenerateOCSPRequest) error {
+	m.serials <- req.Serial
+	return nil
+}
+
+func (m *mockOCSPStream) CloseSend() error {
+	close(m.serials)
+	return nil
+}
+
+func (m *mockOCSPStream) Recv() (*capb.OCSPStreamResponse, error) {
+	serial, ok := <-m.serials
+	if !ok {
+		return nil, io.EOF
+	}
+	return &capb.OCSPStreamResponse{Serial: serial, Response: []byte("phthpbt")}, nil
+}
+
+// mockOCSPGeneratorBreaksStream is a mock OCSP generator whose first stream
+// breaks after answering a number of requests.
+type mockOCSPGeneratorBreaksStream struct {
+	mockOCSPGenerator
+	answer  int
+	streams int
+}
+
+func (mog *mockOCSPGeneratorBreaksStream) GenerateOCSPStream(ctx context.Context, opts ...grpc.CallOption) (capb.OCSPGenerator_GenerateOCSPStreamClient, error) {
+	mog.streams++
+	stream := &mockOCSPStream{serials: make(chan string, 100)}
+	if mog.streams == 1 {
+		return &brokenOCSPStream{mockOCSPStream: stream, answer: mog.answer}, nil
+	}
+	return stream, nil
+}
+
+// brokenOCSPStream is a mockOCSPStream which fails after answering a number
+// of requests, as when the connection to the CA is lost.
+type brokenOCSPStream struct {
+	*mockOCSPStream
+	answer int
+}
+
+func (s *brokenOCSPStream) Recv() (*capb.OCSPStreamResponse, error) {
+	if s.answer == 0 {
+		return nil, errors.New("transport is closing")
+	}
+	s.answer--
+	return s.mockOCSPStream.Recv()
+}
+
+func TestSignResponsesBrokenStream(t *testing.T) {
+	generator := &mockOCSPGeneratorBreaksStream{answer: 2}
+	cl := client{
+		ocspGenerator: generator,
+		clk:           clock.NewFake(),
+		logger:        blog.NewMock(),
+	}
+
+	input := make(chan *sa.CertStatusMetadata)
+	go func() {
+		defer close(input)
+		for i := 1; i <= 5; i++ {
+			input <- &sa.CertStatusMetadata{CertificateStatus: core.CertificateStatus{
+				ID:     int64(i),
+				Serial: fmt.Sprintf("%036x", i),
+				Status: core.OCSPStatusGood,
+			}}
+		}
+	}()
+
+	output := make(chan processResult, 5)
+	var ids []int64
+	for signed := range cl.signResponses(context.Background(), input, 2, output) {
+		test.AssertEquals(t, string(signed.response), "phthpbt")
+		ids = append(ids, signed.status.ID)
+	}
+	close(output)
+	for result := range output {
+		t.Errorf("unexpected failure for status %d: %s", result.id, result.err)
+	}
+
+	// The requests left unanswered by the broken stream should have been
+	// sent again, in order, on a second stream.
+	test.AssertEquals(t, generator.streams, 2)
+	test.AssertDeepEquals(t, ids, []int64{1, 2, 3, 4, 5})
+}
+
 func TestLoadFromDB(t *testing.T) {
 	redisClient, clk := makeClient()
 
@@ -33,8 +33,8 @@ type Config struct {
 		// E1 -> 1, R3 -> 3, etc.
 		Issuers map[string]int
 
-		// If using load-from-db, this provides credentials to connect to the DB
-		// and the CA. Otherwise, it's optional.
+		// If using load-from-db or verify, this provides credentials to connect
+		// to the DB and the CA. Otherwise, it's optional.
 		LoadFromDB *LoadFromDBConfig
 	}
 	Syslog cmd.SyslogConfig
@@ -54,14 +54,16 @@ type LoadFromDBConfig struct {
 }
 
 type ProcessingSpeed struct {
-	// If using load-from-db, this limits how many items per second we
+	// If using load-from-db or verify, this limits how many items per second we
 	// scan from the DB. We might go slower than this depending on how fast
 	// we read rows from the DB, but we won't go faster. Defaults to 2000.
 	RowsPerSecond int
 	// If using load-from-db, this controls how many parallel requests to
-	// boulder-ca for OCSP signing we can make. Defaults to 100.
+	// boulder-ca for OCSP signing we can make. If using verify, it also
+	// controls how many entries are checked against Redis in parallel.
+	// Defaults to 100.
 	ParallelSigns int
-	// If using load-from-db, the LIMIT on our scanning queries. We have to
+	// If using load-from-db or verify, the LIMIT on our scanning queries. We have to
 	// apply a limit because MariaDB will cut off our response at some
 	// threshold of total bytes transferred (1 GB by default). Defaults to 10000.
 	ScanBatchSize int
@@ -80,7 +82,9 @@ func main() {
 
 func main2() error {
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
-	startFromID := flag.Int64("start-from-id", 0, "For load-from-db, the first ID in the certificateStatus table to scan")
+	startFromID := flag.Int64("start-from-id", 0, "For load-from-db and verify, the first ID in the certificateStatus table to scan")
+	maxResponseAge := flag.Duration("max-response-age", 72*time.Hour, "For verify, the age beyond which a response in Redis is reported as stale")
+	repair := flag.Bool("repair", false, "For verify, sign and store a new response for each entry found to be missing or wrong")
 	flag.Parse()
 	if *configFile == "" {
 		flag.Usage()
@@ -174,6 +178,14 @@ func main2() error {
 		if err != nil {
 			return fmt.Errorf("loading OCSP responses from DB: %w", err)
 		}
+	case "verify":
+		if c.ROCSPTool.LoadFromDB == nil {
+			return fmt.Errorf("config field LoadFromDB was missing")
+		}
+		err = cl.verify(ctx, c.ROCSPTool.LoadFromDB.Speed, *startFromID, *maxResponseAge, *repair)
+		if err != nil {
+			return fmt.Errorf("verifying OCSP responses against DB: %w", err)
+		}
 	case "scan-metadata":
 		results := cl.redis.ScanMetadata(ctx, "*")
 		for r := range results {
@@ -199,10 +211,11 @@ func main2() error {
 }
 
 func helpExit() {
-	fmt.Fprintf(os.Stderr, "Usage: %s [store|copy-from-db|scan-metadata|scan-responses] --config path/to/config.json\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Usage: %s [store|copy-from-db|verify|scan-metadata|scan-responses] --config path/to/config.json\n", os.Args[0])
 	fmt.Fprintln(os.Stderr, "  store -- for each filename on command line, read the file as an OCSP response and store it in Redis")
 	fmt.Fprintln(os.Stderr, "  get -- for each serial on command line, fetch that serial's response and pretty-print it")
 	fmt.Fprintln(os.Stderr, "  load-from-db -- scan the database for all OCSP entries for unexpired certificates, and store in Redis")
+	fmt.Fprintln(os.Stderr, "  verify -- scan the database for all OCSP entries for unexpired certificates, and check that Redis holds a fresh, correctly signed response matching each. With -repair, store new responses where it does not")
 	fmt.Fprintln(os.Stderr, "  scan-metadata -- scan Redis for metadata entries. For each entry, print the serial and the age in hours")
 	fmt.Fprintln(os.Stderr, "  scan-responses -- scan Redis for OCSP response entries. For each entry, print the serial and base64-encoded response")
 	fmt.Fprintln(os.Stderr)
@@ -0,0 +1,218 @@
+package notmain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/rocsp"
+	rocsp_config "github.com/letsencrypt/boulder/rocsp/config"
+	"github.com/letsencrypt/boulder/sa"
+	"golang.org/x/crypto/ocsp"
+)
+
+// Outcomes of comparing a certificateStatus row with what is stored in Redis.
+const (
+	verifyOK               = "ok"
+	verifyExpired          = "expired"
+	verifyMissing          = "missing"
+	verifyMetadataMissing  = "metadata_missing"
+	verifyBadResponse      = "bad_response"
+	verifyWrongStatus      = "wrong_status"
+	verifyWrongReason      = "wrong_reason"
+	verifyStale            = "stale"
+	verifyMetadataMismatch = "metadata_mismatch"
+	// verifyError means the comparison itself could not be made, for instance
+	// because Redis was unreachable. Such entries are not repaired.
+	verifyError = "error"
+)
+
+// verifyResult is the outcome of checking a single certificateStatus row.
+// If the outcome is a problem, err describes it.
+type verifyResult struct {
+	status  *sa.CertStatusMetadata
+	outcome string
+	err     error
+}
+
+// repairable returns true if the entry in Redis is missing or wrong, and a
+// newly signed response should be stored in its place.
+func (vr verifyResult) repairable() bool {
+	return vr.outcome != verifyOK && vr.outcome != verifyExpired && vr.outcome != verifyError
+}
+
+// verify scans certificateStatus for unexpired certificates and checks that
+// Redis holds a correctly signed response for each of them, which matches its
+// status and revocation reason, and was produced no more than maxAge ago. Each
+// problem found is logged. If repair is true, a new response is signed and
+// stored for each entry which is missing or wrong.
+func (cl *client) verify(ctx context.Context, speed ProcessingSpeed, startFromID int64, maxAge time.Duration, repair bool) error {
+	prevID, maxID, err := cl.scanRange(ctx, startFromID)
+	if err != nil {
+		return err
+	}
+
+	frequency := time.Duration(float64(time.Second) / float64(time.Duration(speed.RowsPerSecond)))
+	inflightIDs := newInflight()
+	statuses := cl.scanFromDB(ctx, prevID, maxID, frequency, inflightIDs)
+
+	results := make(chan verifyResult, speed.ParallelSigns)
+	var runningCheckers int32
+	for i := 0; i < speed.ParallelSigns; i++ {
+		atomic.AddInt32(&runningCheckers, 1)
+		go cl.checkResponses(ctx, statuses, maxAge, results, &runningCheckers)
+	}
+
+	// Entries which need repair are signed and stored concurrently with the
+	// rest of the scan, using the same pipeline as load-from-db.
+	toRepair := make(chan *sa.CertStatusMetadata)
+	repairsDone := make(chan struct{})
+	var repairCount, repairErrorCount int64
+	if repair {
+		repairResults := make(chan processResult, speed.ParallelSigns)
+		signed := cl.signResponses(ctx, toRepair, speed.ParallelSigns, repairResults)
+		var runningStorers int32
+		for i := 0; i < speed.ParallelSigns; i++ {
+			atomic.AddInt32(&runningStorers, 1)
+			go cl.storeResponses(ctx, signed, repairResults, &runningStorers)
+		}
+		go func() {
+			defer close(repairsDone)
+			for result := range repairResults {
+				if result.err != nil {
+					repairErrorCount++
+					cl.logger.Errf("repairing certificateStatus ID %d: %s", result.id, result.err)
+				} else {
+					repairCount++
+				}
+			}
+		}()
+	}
+
+	counts := make(map[string]int64)
+	for result := range results {
+		inflightIDs.remove(uint64(result.status.ID))
+		counts[result.outcome]++
+		if result.outcome != verifyOK && result.outcome != verifyExpired {
+			cl.logger.Warningf("serial %s (certificateStatus ID %d): %s: %s",
+				result.status.Serial, result.status.ID, result.outcome, result.err)
+		}
+		if repair && result.repairable() {
+			toRepair <- result.status
+		}
+	}
+	close(toRepair)
+	if repair {
+		<-repairsDone
+	}
+
+	cl.logger.Infof("done. skipped %d expired; verified %d ok, %d missing, %d metadata missing, %d bad responses, "+
+		"%d wrong status, %d wrong reason, %d stale, %d metadata mismatches, %d errors",
+		counts[verifyExpired], counts[verifyOK], counts[verifyMissing], counts[verifyMetadataMissing], counts[verifyBadResponse],
+		counts[verifyWrongStatus], counts[verifyWrongReason], counts[verifyStale], counts[verifyMetadataMismatch],
+		counts[verifyError])
+	if repair {
+		cl.logger.Infof("repaired %d entries, %d errors", repairCount, repairErrorCount)
+	}
+	if inflightIDs.len() != 0 {
+		return fmt.Errorf("inflightIDs non-empty! has %d items, lowest %d", inflightIDs.len(), inflightIDs.min())
+	}
+	return nil
+}
+
+// checkResponses consumes cert statuses on its input channel, checks each
+// against Redis, and writes the results to its output channel. Statuses for
+// expired certificates are skipped. Before returning, it atomically
+// decrements the provided runningCheckers int. If the result is 0, indicating
+// this was the last running checker, it closes its output channel.
+func (cl *client) checkResponses(ctx context.Context, input <-chan *sa.CertStatusMetadata, maxAge time.Duration, output chan<- verifyResult, runningCheckers *int32) {
+	defer func() {
+		if atomic.AddInt32(runningCheckers, -1) <= 0 {
+			close(output)
+		}
+	}()
+	for status := range input {
+		if status.IsExpired || !cl.clk.Now().Before(status.NotAfter) {
+			output <- verifyResult{status: status, outcome: verifyExpired}
+			continue
+		}
+		outcome, err := cl.checkResponse(ctx, status, maxAge)
+		output <- verifyResult{status: status, outcome: outcome, err: err}
+	}
+}
+
+// checkResponse fetches the response and metadata stored in Redis for the
+// given status and compares them to it.
+func (cl *client) checkResponse(ctx context.Context, status *sa.CertStatusMetadata, maxAge time.Duration) (string, error) {
+	issuer, err := rocsp_config.FindIssuerByID(status.IssuerID, cl.issuers)
+	if err != nil {
+		return verifyError, err
+	}
+
+	respBytes, err := cl.redis.GetResponse(ctx, status.Serial)
+	if err != nil {
+		if errors.Is(err, rocsp.ErrRedisNotFound) {
+			return verifyMissing, errors.New("no response in Redis")
+		}
+		return verifyError, err
+	}
+
+	metadata, err := cl.redis.GetMetadata(ctx, status.Serial)
+	if err != nil {
+		if errors.Is(err, rocsp.ErrRedisNotFound) {
+			return verifyMetadataMissing, errors.New("no metadata in Redis")
+		}
+		return verifyError, err
+	}
+
+	return compareResponse(status, respBytes, metadata, issuer, cl.clk.Now(), maxAge)
+}
+
+// compareResponse checks that respBytes is an OCSP response for the given
+// status, signed by issuer, that agrees with it on status and revocation
+// reason and was produced no more than maxAge before now. It also checks that
+// the metadata agrees with the response. It returns verifyOK if all of these
+// hold, and otherwise the outcome for the first check which failed along with
+// a description of the problem.
+func compareResponse(status *sa.CertStatusMetadata, respBytes []byte, metadata *rocsp.Metadata, issuer *rocsp_config.ShortIDIssuer, now time.Time, maxAge time.Duration) (string, error) {
+	resp, err := ocsp.ParseResponse(respBytes, issuer.Certificate.Certificate)
+	if err != nil {
+		return verifyBadResponse, fmt.Errorf("parsing response: %w", err)
+	}
+
+	serial := core.SerialToString(resp.SerialNumber)
+	if serial != status.Serial {
+		return verifyBadResponse, fmt.Errorf("response is for serial %s", serial)
+	}
+
+	expectedStatus := ocsp.Good
+	if status.Status == core.OCSPStatusRevoked {
+		expectedStatus = ocsp.Revoked
+	}
+	if resp.Status != expectedStatus {
+		return verifyWrongStatus, fmt.Errorf("response has status %d, expected %d", resp.Status, expectedStatus)
+	}
+	if expectedStatus == ocsp.Revoked && resp.RevocationReason != int(status.RevokedReason) {
+		return verifyWrongReason, fmt.Errorf("response has revocation reason %d, expected %d", resp.RevocationReason, status.RevokedReason)
+	}
+
+	if age := now.Sub(resp.ThisUpdate); age > maxAge {
+		return verifyStale, fmt.Errorf("response was produced %s ago", age)
+	}
+	if !resp.NextUpdate.IsZero() && !now.Before(resp.NextUpdate) {
+		return verifyStale, fmt.Errorf("response expired at %s", resp.NextUpdate)
+	}
+
+	if metadata.ShortIssuerID != issuer.ShortID() {
+		return verifyMetadataMismatch, fmt.Errorf("metadata has short issuer ID %d, expected %d", metadata.ShortIssuerID, issuer.ShortID())
+	}
+	// Metadata stores thisUpdate with a resolution of one second.
+	if !metadata.ThisUpdate.Equal(resp.ThisUpdate.Truncate(time.Second)) {
+		return verifyMetadataMismatch, fmt.Errorf("metadata has thisUpdate %s, response has %s", metadata.ThisUpdate, resp.ThisUpdate)
+	}
+
+	return verifyOK, nil
+}
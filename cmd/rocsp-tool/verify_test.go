@@ -0,0 +1,170 @@
+package notmain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/revocation"
+	"github.com/letsencrypt/boulder/rocsp"
+	rocsp_config "github.com/letsencrypt/boulder/rocsp/config"
+	"github.com/letsencrypt/boulder/sa"
+	"github.com/letsencrypt/boulder/test"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestCompareResponse(t *testing.T) {
+	clk := clock.NewFake()
+	clk.Set(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	issuers, err := rocsp_config.LoadIssuers(map[string]int{
+		"../../test/hierarchy/int-e1.cert.pem": 23,
+		"../../test/hierarchy/int-r3.cert.pem": 99,
+	})
+	test.AssertNotError(t, err, "loading issuers")
+	var e1, r3 *rocsp_config.ShortIDIssuer
+	for i := range issuers {
+		switch issuers[i].ShortID() {
+		case 23:
+			e1 = &issuers[i]
+		case 99:
+			r3 = &issuers[i]
+		}
+	}
+
+	issuerKey, err := test.LoadSigner("../../test/hierarchy/int-e1.key.pem")
+	test.AssertNotError(t, err, "loading int-e1 key")
+
+	serial := big.NewInt(1337)
+	sign := func(template ocsp.Response) []byte {
+		template.SerialNumber = serial
+		if template.ThisUpdate.IsZero() {
+			template.ThisUpdate = clk.Now().Add(-time.Hour)
+		}
+		if template.NextUpdate.IsZero() {
+			template.NextUpdate = template.ThisUpdate.Add(96 * time.Hour)
+		}
+		resp, err := ocsp.CreateResponse(e1.Certificate.Certificate, e1.Certificate.Certificate, template, issuerKey)
+		test.AssertNotError(t, err, "creating OCSP response")
+		return resp
+	}
+	status := func(s core.OCSPStatus, reason revocation.Reason) *sa.CertStatusMetadata {
+		return &sa.CertStatusMetadata{CertificateStatus: core.CertificateStatus{
+			Serial:        core.SerialToString(serial),
+			Status:        s,
+			RevokedReason: reason,
+		}}
+	}
+	metadata := &rocsp.Metadata{ShortIssuerID: 23, ThisUpdate: clk.Now().Add(-time.Hour)}
+
+	testCases := []struct {
+		name     string
+		status   *sa.CertStatusMetadata
+		response []byte
+		metadata *rocsp.Metadata
+		issuer   *rocsp_config.ShortIDIssuer
+		expected string
+	}{
+		{
+			name:     "good",
+			status:   status(core.OCSPStatusGood, 0),
+			response: sign(ocsp.Response{Status: ocsp.Good}),
+			metadata: metadata,
+			issuer:   e1,
+			expected: verifyOK,
+		},
+		{
+			name:     "revoked",
+			status:   status(core.OCSPStatusRevoked, 1),
+			response: sign(ocsp.Response{Status: ocsp.Revoked, RevocationReason: 1, RevokedAt: clk.Now().Add(-2 * time.Hour)}),
+			metadata: metadata,
+			issuer:   e1,
+			expected: verifyOK,
+		},
+		{
+			name:     "garbage",
+			status:   status(core.OCSPStatusGood, 0),
+			response: []byte("phthpbt"),
+			metadata: metadata,
+			issuer:   e1,
+			expected: verifyBadResponse,
+		},
+		{
+			name:     "wrong issuer",
+			status:   status(core.OCSPStatusGood, 0),
+			response: sign(ocsp.Response{Status: ocsp.Good}),
+			metadata: metadata,
+			issuer:   r3,
+			expected: verifyBadResponse,
+		},
+		{
+			name:     "wrong serial",
+			status:   &sa.CertStatusMetadata{CertificateStatus: core.CertificateStatus{Serial: "00000000000000000000000000000000abcd", Status: core.OCSPStatusGood}},
+			response: sign(ocsp.Response{Status: ocsp.Good}),
+			metadata: metadata,
+			issuer:   e1,
+			expected: verifyBadResponse,
+		},
+		{
+			name:     "good in Redis, revoked in DB",
+			status:   status(core.OCSPStatusRevoked, 1),
+			response: sign(ocsp.Response{Status: ocsp.Good}),
+			metadata: metadata,
+			issuer:   e1,
+			expected: verifyWrongStatus,
+		},
+		{
+			name:     "wrong reason",
+			status:   status(core.OCSPStatusRevoked, 1),
+			response: sign(ocsp.Response{Status: ocsp.Revoked, RevocationReason: 4, RevokedAt: clk.Now().Add(-2 * time.Hour)}),
+			metadata: metadata,
+			issuer:   e1,
+			expected: verifyWrongReason,
+		},
+		{
+			name:     "too old",
+			status:   status(core.OCSPStatusGood, 0),
+			response: sign(ocsp.Response{Status: ocsp.Good, ThisUpdate: clk.Now().Add(-80 * time.Hour)}),
+			metadata: &rocsp.Metadata{ShortIssuerID: 23, ThisUpdate: clk.Now().Add(-80 * time.Hour)},
+			issuer:   e1,
+			expected: verifyStale,
+		},
+		{
+			name:     "past nextUpdate",
+			status:   status(core.OCSPStatusGood, 0),
+			response: sign(ocsp.Response{Status: ocsp.Good, NextUpdate: clk.Now().Add(-time.Minute)}),
+			metadata: metadata,
+			issuer:   e1,
+			expected: verifyStale,
+		},
+		{
+			name:     "metadata for wrong issuer",
+			status:   status(core.OCSPStatusGood, 0),
+			response: sign(ocsp.Response{Status: ocsp.Good}),
+			metadata: &rocsp.Metadata{ShortIssuerID: 99, ThisUpdate: clk.Now().Add(-time.Hour)},
+			issuer:   e1,
+			expected: verifyMetadataMismatch,
+		},
+		{
+			name:     "metadata for older response",
+			status:   status(core.OCSPStatusGood, 0),
+			response: sign(ocsp.Response{Status: ocsp.Good}),
+			metadata: &rocsp.Metadata{ShortIssuerID: 23, ThisUpdate: clk.Now().Add(-2 * time.Hour)},
+			issuer:   e1,
+			expected: verifyMetadataMismatch,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outcome, err := compareResponse(tc.status, tc.response, tc.metadata, tc.issuer, clk.Now(), 72*time.Hour)
+			test.AssertEquals(t, outcome, tc.expected)
+			if tc.expected == verifyOK {
+				test.AssertNotError(t, err, "unexpected error")
+			} else {
+				test.AssertError(t, err, "expected a description of the problem")
+			}
+		})
+	}
+}
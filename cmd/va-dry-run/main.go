@@ -0,0 +1,165 @@
+package notmain
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/core"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/probs"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+const usageString = `
+usage:
+  va-dry-run -config <path> -domain <name> -type <challenge type> -key-authorization <key authorization> [-ip] [-reg-id <id>]
+
+Performs a validation of the given challenge against a name without creating an
+order, and prints the validation records, problem, any tls-alpn-01 certificates
+and the CAA set found. Nothing is written to the database.
+
+flags:
+`
+
+type Config struct {
+	VADryRun struct {
+		// TLS is the client certificate used to connect to the VA's admin
+		// listener, whose SAN must be in its AdminGRPC.ClientNames.
+		TLS       cmd.TLSConfig
+		VAService *cmd.GRPCClientConfig
+	}
+
+	Syslog cmd.SyslogConfig
+}
+
+// certificate describes a certificate presented for a tls-alpn-01 challenge.
+type certificate struct {
+	Subject    string
+	Issuer     string
+	Serial     string
+	NotBefore  time.Time
+	NotAfter   time.Time
+	Names      []string
+	Extensions []string
+}
+
+// report is the result of a dry run validation, as printed.
+type report struct {
+	Records             []core.ValidationRecord
+	Problem             *probs.ProblemDetails `json:",omitempty"`
+	TLSALPNCertificates []certificate         `json:",omitempty"`
+	TLSALPNProtocol     string                `json:",omitempty"`
+	CAARecords          []string              `json:",omitempty"`
+	CAADNSSEC           string                `json:",omitempty"`
+}
+
+func newReport(result *vapb.DryRunValidationResult) (*report, error) {
+	r := &report{
+		TLSALPNProtocol: result.TlsALPNProtocol,
+		CAARecords:      result.CaaRecords,
+		CAADNSSEC:       result.CaaDNSSEC,
+	}
+	for _, pbRecord := range result.Records {
+		record, err := bgrpc.PBToValidationRecord(pbRecord)
+		if err != nil {
+			return nil, err
+		}
+		r.Records = append(r.Records, record)
+	}
+	prob, err := bgrpc.PBToProblemDetails(result.Problem)
+	if err != nil {
+		return nil, err
+	}
+	r.Problem = prob
+	for _, der := range result.TlsALPNCertificates {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parsing tls-alpn-01 certificate: %w", err)
+		}
+		c := certificate{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			Serial:    core.SerialToString(cert.SerialNumber),
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+			Names:     cert.DNSNames,
+		}
+		for _, ip := range cert.IPAddresses {
+			c.Names = append(c.Names, ip.String())
+		}
+		for _, ext := range cert.Extensions {
+			desc := ext.Id.String()
+			if ext.Critical {
+				desc += " (critical)"
+			}
+			c.Extensions = append(c.Extensions, desc)
+		}
+		r.TLSALPNCertificates = append(r.TLSALPNCertificates, c)
+	}
+	return r, nil
+}
+
+func main() {
+	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	domain := flag.String("domain", "", "Name (or IP address, with -ip) to validate")
+	isIP := flag.Bool("ip", false, "Whether -domain is an IP address identifier")
+	challengeType := flag.String("type", "", "Challenge type to perform, e.g. http-01")
+	keyAuthorization := flag.String("key-authorization", "", "Key authorization the subscriber is serving")
+	regID := flag.Int64("reg-id", 0, "Optional account ID, for dns-account-01 and CAA accounturi checks")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usageString)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *configFile == "" || *domain == "" || *challengeType == "" || *keyAuthorization == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c Config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	logger := cmd.NewLogger(c.Syslog)
+	defer logger.AuditPanic()
+
+	tlsConfig, err := c.VADryRun.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+
+	clk := cmd.Clock()
+	clientMetrics := bgrpc.NewClientMetrics(metrics.NoopRegisterer)
+	conn, err := bgrpc.ClientSetup(c.VADryRun.VAService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to VA")
+	vac := vapb.NewVADebugClient(conn)
+
+	typeIdentifier := string(identifier.DNS)
+	if *isIP {
+		typeIdentifier = string(identifier.IP)
+	}
+	result, err := vac.DryRunValidation(context.Background(), &vapb.DryRunValidationRequest{
+		Domain:           *domain,
+		TypeIdentifier:   typeIdentifier,
+		ChallengeType:    *challengeType,
+		KeyAuthorization: *keyAuthorization,
+		RegID:            *regID,
+	})
+	cmd.FailOnError(err, "Dry run validation failed")
+
+	r, err := newReport(result)
+	cmd.FailOnError(err, "Failed to interpret dry run validation result")
+	out, err := json.MarshalIndent(r, "", "  ")
+	cmd.FailOnError(err, "Failed to marshal dry run validation result")
+	fmt.Println(string(out))
+}
+
+func init() {
+	cmd.RegisterCommand("va-dry-run", main)
+}
@@ -0,0 +1,66 @@
+package notmain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+func TestNewReport(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1337),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2020, 1, 8, 0, 0, 0, 0, time.UTC),
+		DNSNames:     []string{"example.com"},
+		ExtraExtensions: []pkix.Extension{
+			{Id: []int{1, 3, 6, 1, 5, 5, 7, 1, 31}, Critical: true, Value: []byte{0x04, 0x00}},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating certificate")
+
+	r, err := newReport(&vapb.DryRunValidationResult{
+		Records: []*corepb.ValidationRecord{{
+			Hostname:          "example.com",
+			Port:              "443",
+			AddressesResolved: [][]byte{net.ParseIP("10.0.0.1").To4()},
+			AddressUsed:       []byte("10.0.0.1"),
+		}},
+		Problem: &corepb.ProblemDetails{
+			ProblemType: string(probs.CAAProblem),
+			Detail:      "CAA record for example.com prevents issuance",
+			HttpStatus:  403,
+		},
+		TlsALPNCertificates: [][]byte{der},
+		TlsALPNProtocol:     "acme-tls/1",
+		CaaRecords:          []string{"example.com.\t3600\tIN\tCAA\t0 issue \"ca.example\""},
+	})
+	test.AssertNotError(t, err, "newReport failed")
+	test.AssertEquals(t, len(r.Records), 1)
+	test.AssertEquals(t, r.Records[0].AddressUsed.String(), "10.0.0.1")
+	test.AssertEquals(t, r.Problem.Type, probs.CAAProblem)
+	test.AssertEquals(t, len(r.TLSALPNCertificates), 1)
+	cert := r.TLSALPNCertificates[0]
+	test.AssertEquals(t, cert.Subject, "CN=example.com")
+	test.AssertDeepEquals(t, cert.Names, []string{"example.com"})
+	test.AssertEquals(t, cert.Serial, "000000000000000000000000000000000539")
+	test.AssertContains(t, cert.Extensions[len(cert.Extensions)-1], "1.3.6.1.5.5.7.1.31 (critical)")
+	test.AssertEquals(t, len(r.CAARecords), 1)
+
+	_, err = newReport(&vapb.DryRunValidationResult{TlsALPNCertificates: [][]byte{{0x30}}})
+	test.AssertError(t, err, "newReport should fail on a malformed certificate")
+}
@@ -31,3 +31,9 @@ func TLSALPNChallenge01(token string) Challenge {
 func TrustedJWTChallenge01(token string) Challenge {
 	return newChallenge(ChallengeTypeTrustedJWT, token)
 }
+
+// DNSAccountChallenge01 constructs a random dns-account-01 challenge. If token is empty a
+// random token will be generated, otherwise the provided token is used.
+func DNSAccountChallenge01(token string) Challenge {
+	return newChallenge(ChallengeTypeDNSAccount01, token)
+}
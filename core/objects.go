@@ -54,16 +54,17 @@ type AcmeChallenge string
 // These types are the available challenges
 // TODO(#5009): Make this a custom type as well.
 const (
-	ChallengeTypeHTTP01     = AcmeChallenge("http-01")
-	ChallengeTypeDNS01      = AcmeChallenge("dns-01")
-	ChallengeTypeTLSALPN01  = AcmeChallenge("tls-alpn-01")
-	ChallengeTypeTrustedJWT = AcmeChallenge("trusted-jwt-01")
+	ChallengeTypeHTTP01       = AcmeChallenge("http-01")
+	ChallengeTypeDNS01        = AcmeChallenge("dns-01")
+	ChallengeTypeTLSALPN01    = AcmeChallenge("tls-alpn-01")
+	ChallengeTypeTrustedJWT   = AcmeChallenge("trusted-jwt-01")
+	ChallengeTypeDNSAccount01 = AcmeChallenge("dns-account-01")
 )
 
 // IsValid tests whether the challenge is a known challenge
 func (c AcmeChallenge) IsValid() bool {
 	switch c {
-	case ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01, ChallengeTypeTrustedJWT:
+	case ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01, ChallengeTypeTrustedJWT, ChallengeTypeDNSAccount01:
 		return true
 	default:
 		return false
@@ -175,11 +176,45 @@ type ValidationRecord struct {
 	//   ...
 	// }
 	AddressesTried []net.IP `json:"addressesTried,omitempty"`
+	// AddressErrors contains, in the order they were tried, each address which
+	// failed and the error from it. Continuing the example above, it would be
+	// [ { address: ::1, error: "dial tcp [::1]:80: connect: connection refused" } ].
+	// If the `AddressUsed` failed as well, its error is the last.
+	AddressErrors []AddressError `json:"addressErrors,omitempty"`
 
 	// OldTLS is true if any request in the validation chain used HTTPS and negotiated
 	// a TLS version lower than 1.2.
 	// TODO(#6011): Remove once TLS 1.0 and 1.1 support is gone.
 	OldTLS bool `json:"oldTLS,omitempty"`
+
+	// DNSSEC is the DNSSEC state ("secure", "insecure" or "bogus") of the DNS
+	// lookups made for the validation, if the VA validated them with DNSSEC.
+	DNSSEC string `json:"dnssec,omitempty"`
+
+	// Proxy is the egress proxy through which the VA connected to the
+	// validation target, if it was configured to use one.
+	Proxy string `json:"proxy,omitempty"`
+
+	// Perspectives summarizes, for each labelled perspective of remote VAs,
+	// the remote results which corroborated or contradicted the validation.
+	// It's set only on the first record, and only when the remote results
+	// were enforced.
+	Perspectives []PerspectiveResult `json:"perspectives,omitempty"`
+}
+
+// AddressError is the error from a validation attempt against a single
+// address.
+type AddressError struct {
+	Address net.IP `json:"address"`
+	Error   string `json:"error"`
+}
+
+// PerspectiveResult counts the remote VAs in a perspective which succeeded
+// and failed to validate a challenge.
+type PerspectiveResult struct {
+	Perspective string `json:"perspective"`
+	Successes   int    `json:"successes"`
+	Failures    int    `json:"failures"`
 }
 
 func looksLikeKeyAuthorization(str string) error {
@@ -280,7 +315,7 @@ func (ch Challenge) RecordsSane() bool {
 			ch.ValidationRecord[0].AddressUsed == nil || len(ch.ValidationRecord[0].AddressesResolved) == 0 {
 			return false
 		}
-	case ChallengeTypeDNS01:
+	case ChallengeTypeDNS01, ChallengeTypeDNSAccount01:
 		if len(ch.ValidationRecord) > 1 {
 			return false
 		}
@@ -513,6 +548,18 @@ type CertificateStatus struct {
 	// TODO(#5152): Change this to an issuance.Issuer(Name)ID after it no longer
 	// has to support both IssuerNameIDs and IssuerIDs.
 	IssuerID int64
+
+	// CRLShard is the CRL shard to which the certificate was assigned at
+	// issuance, and whose URL is in its CRL Distribution Points extension.
+	// Zero means the certificate was issued without a shard, and appears on
+	// its issuer's unsharded CRL.
+	CRLShard int64 `db:"crlShard"`
+
+	// IsShortLived is true if the certificate was issued under a short-lived
+	// profile. Such certificates have no OCSP URL and never get OCSP
+	// responses, so the OCSP updater skips them, but their revocation is
+	// still recorded here for CRLs.
+	IsShortLived bool `db:"isShortLived"`
 }
 
 // FQDNSet contains the SHA256 hash of the lowercased, comma joined dNSNames
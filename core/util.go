@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
@@ -90,6 +91,15 @@ func Fingerprint256(data []byte) string {
 
 type Sha256Digest [sha256.Size]byte
 
+// DNSAccountLabel returns the account-specific label under which a
+// dns-account-01 challenge's TXT record is published: an underscore followed
+// by the lowercase, unpadded base32 encoding of the first 10 bytes of the
+// SHA-256 digest of the account URL.
+func DNSAccountLabel(accountURL string) string {
+	digest := sha256.Sum256([]byte(accountURL))
+	return "_" + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(digest[:10]))
+}
+
 // KeyDigest produces a Base64-encoded SHA256 digest of a
 // provided public key.
 func KeyDigest(key crypto.PublicKey) (Sha256Digest, error) {
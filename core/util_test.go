@@ -91,6 +91,11 @@ func TestKeyDigest(t *testing.T) {
 	test.Assert(t, err != nil, "Should have rejected unknown key type")
 }
 
+func TestDNSAccountLabel(t *testing.T) {
+	// The example from draft-ietf-acme-dns-account-label.
+	test.AssertEquals(t, DNSAccountLabel("https://example.com/acme/acct/ExampleAccount"), "_ujmmovf2vn55tgye")
+}
+
 func TestKeyDigestEquals(t *testing.T) {
 	var jwk1, jwk2 jose.JSONWebKey
 	err := json.Unmarshal([]byte(JWK1JSON), &jwk1)
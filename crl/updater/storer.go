@@ -0,0 +1,118 @@
+package updater
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/letsencrypt/boulder/issuance"
+)
+
+// Storer publishes DER encoded CRLs somewhere they can be served from.
+type Storer interface {
+	StoreCRL(ctx context.Context, issuerNameID issuance.IssuerNameID, shard int64, crl []byte) error
+}
+
+// localStorer writes CRLs to files in a local directory.
+type localStorer struct {
+	dir string
+}
+
+// NewLocalStorer returns a Storer which writes each CRL to a file in the given
+// directory, replacing the previous CRL for the same issuer and shard. The directory is
+// created if it does not already exist.
+func NewLocalStorer(dir string) (Storer, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%q is not a directory", dir)
+	}
+	return &localStorer{dir: dir}, nil
+}
+
+// StoreCRL writes the CRL to a temporary file and renames it into place, so
+// that readers never observe a partially written CRL.
+func (ls *localStorer) StoreCRL(_ context.Context, issuerNameID issuance.IssuerNameID, shard int64, crl []byte) error {
+	target := filepath.Join(ls.dir, filepath.FromSlash(issuance.CRLShardPath(issuerNameID, shard)))
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(target), "crl-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(crl)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+// httpStorer uploads CRLs to an HTTP server.
+type httpStorer struct {
+	baseURL *url.URL
+	client  *http.Client
+}
+
+// NewHTTPStorer returns a Storer which uploads each CRL with an HTTP PUT to
+// a path beneath the given base URL.
+func NewHTTPStorer(baseURL string, client *http.Client) (Storer, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.New("CRL upload URL must be http or https")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpStorer{baseURL: u, client: client}, nil
+}
+
+func (hs *httpStorer) StoreCRL(ctx context.Context, issuerNameID issuance.IssuerNameID, shard int64, crl []byte) error {
+	target := hs.baseURL.ResolveReference(&url.URL{Path: issuance.CRLShardPath(issuerNameID, shard)})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target.String(), bytes.NewReader(crl))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/pkix-crl")
+
+	resp, err := hs.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("uploading CRL to %s: unexpected status %s", target, resp.Status)
+	}
+	return nil
+}
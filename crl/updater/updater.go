@@ -0,0 +1,195 @@
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// revokedCertsGetter is the subset of the SA's gRPC client which the updater
+// relies on.
+type revokedCertsGetter interface {
+	GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest, opts ...grpc.CallOption) (*sapb.RevokedCerts, error)
+}
+
+// CRLUpdater periodically produces a fresh set of CRLs for each configured
+// issuer, one per shard, and hands them off to a Storer.
+type CRLUpdater struct {
+	issuers []*issuance.Certificate
+	// numShards is the number of CRL shards of each issuer. CRLs are produced
+	// for shards zero, which holds the certificates issued without a shard,
+	// through numShards.
+	numShards int
+
+	sa     revokedCertsGetter
+	ca     capb.CRLGeneratorClient
+	storer Storer
+
+	// updatePeriod is how often a new CRL is produced for each issuer.
+	updatePeriod time.Duration
+	// lookbackPeriod is how long revoked certificates remain on the CRL after
+	// they expire, so that they appear on at least one CRL after expiry.
+	lookbackPeriod time.Duration
+	// certificateLifetime is the longest validity period of any certificate
+	// issued by these issuers, which bounds how far into the future we need to
+	// look for unexpired certificates.
+	certificateLifetime time.Duration
+	// updateTimeout bounds the time spent producing a single CRL.
+	updateTimeout time.Duration
+
+	tickHistogram  *prometheus.HistogramVec
+	updatedCounter *prometheus.CounterVec
+	entriesGauge   *prometheus.GaugeVec
+
+	log blog.Logger
+	clk clock.Clock
+}
+
+// New returns a CRLUpdater for the given issuers.
+func New(
+	issuers []*issuance.Certificate,
+	numShards int,
+	sa revokedCertsGetter,
+	ca capb.CRLGeneratorClient,
+	storer Storer,
+	updatePeriod time.Duration,
+	lookbackPeriod time.Duration,
+	certificateLifetime time.Duration,
+	updateTimeout time.Duration,
+	stats prometheus.Registerer,
+	log blog.Logger,
+	clk clock.Clock,
+) (*CRLUpdater, error) {
+	if len(issuers) == 0 {
+		return nil, errors.New("must have at least one issuer")
+	}
+	if numShards < 0 {
+		return nil, errors.New("number of shards must not be negative")
+	}
+	if updatePeriod <= 0 {
+		return nil, errors.New("update period must be positive")
+	}
+	if certificateLifetime <= 0 {
+		return nil, errors.New("certificate lifetime must be positive")
+	}
+	if lookbackPeriod < updatePeriod {
+		// Otherwise a certificate which is revoked and then expires between two
+		// updates could never appear on any CRL.
+		return nil, fmt.Errorf("lookback period (%s) must be at least the update period (%s)", lookbackPeriod, updatePeriod)
+	}
+	if updateTimeout <= 0 {
+		updateTimeout = updatePeriod
+	}
+
+	tickHistogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "crl_updater_ticks",
+		Help:    "A histogram of crl-updater tick latencies labeled by result",
+		Buckets: []float64{0.01, 0.2, 0.5, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000},
+	}, []string{"result"})
+	stats.MustRegister(tickHistogram)
+	updatedCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "crl_updater_generated",
+		Help: "A counter of CRL shard generation and storage attempts labeled by issuer and result",
+	}, []string{"issuer", "result"})
+	stats.MustRegister(updatedCounter)
+	entriesGauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "crl_updater_entries",
+		Help: "The number of entries in the most recently generated CRL labeled by issuer and shard",
+	}, []string{"issuer", "shard"})
+	stats.MustRegister(entriesGauge)
+
+	return &CRLUpdater{
+		issuers:             issuers,
+		numShards:           numShards,
+		sa:                  sa,
+		ca:                  ca,
+		storer:              storer,
+		updatePeriod:        updatePeriod,
+		lookbackPeriod:      lookbackPeriod,
+		certificateLifetime: certificateLifetime,
+		updateTimeout:       updateTimeout,
+		tickHistogram:       tickHistogram,
+		updatedCounter:      updatedCounter,
+		entriesGauge:        entriesGauge,
+		log:                 log,
+		clk:                 clk,
+	}, nil
+}
+
+// Tick produces and stores a new CRL for each shard of each issuer, then
+// sleeps until the next update is due.
+func (cu *CRLUpdater) Tick() {
+	start := cu.clk.Now()
+	result := "success"
+	for _, issuer := range cu.issuers {
+		for shard := int64(0); shard <= int64(cu.numShards); shard++ {
+			err := cu.updateShard(context.Background(), start, issuer, shard)
+			if err != nil {
+				cu.log.AuditErrf("Generating CRL failed: issuer=[%s] shard=[%d] err=[%s]", issuer.Subject.CommonName, shard, err)
+				result = "failed"
+			}
+		}
+	}
+	end := cu.clk.Now()
+	cu.tickHistogram.WithLabelValues(result).Observe(end.Sub(start).Seconds())
+	cu.clk.Sleep(start.Add(cu.updatePeriod).Sub(end))
+}
+
+// updateShard produces and stores a single CRL for the given issuer and shard,
+// covering every certificate in that shard which is unexpired (or recently
+// expired) and which was revoked before thisUpdate.
+func (cu *CRLUpdater) updateShard(ctx context.Context, thisUpdate time.Time, issuer *issuance.Certificate, shard int64) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, cu.updateTimeout)
+	defer cancel()
+
+	issuerName := issuer.Subject.CommonName
+	defer func() {
+		result := "success"
+		if err != nil {
+			result = "failed"
+		}
+		cu.updatedCounter.WithLabelValues(issuerName, result).Inc()
+	}()
+
+	revoked, err := cu.sa.GetRevokedCerts(ctx, &sapb.GetRevokedCertsRequest{
+		IssuerNameID:  int64(issuer.NameID()),
+		ExpiresAfter:  thisUpdate.Add(-cu.lookbackPeriod).UnixNano(),
+		ExpiresBefore: thisUpdate.Add(cu.certificateLifetime).UnixNano(),
+		RevokedBefore: thisUpdate.UnixNano(),
+		CrlShard:      shard,
+	})
+	if err != nil {
+		return fmt.Errorf("getting revoked certificates: %w", err)
+	}
+
+	crl, err := cu.ca.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(issuer.NameID()),
+		ThisUpdate:   thisUpdate.UnixNano(),
+		Entries:      revoked.Entries,
+		CrlShard:     shard,
+	})
+	if err != nil {
+		return fmt.Errorf("generating CRL: %w", err)
+	}
+
+	err = cu.storer.StoreCRL(ctx, issuer.NameID(), shard, crl.Crl)
+	if err != nil {
+		return fmt.Errorf("storing CRL: %w", err)
+	}
+
+	cu.entriesGauge.WithLabelValues(issuerName, strconv.FormatInt(shard, 10)).Set(float64(len(revoked.Entries)))
+	cu.log.Infof("Stored CRL: issuer=[%s] shard=[%d] thisUpdate=[%s] entries=[%d]",
+		issuerName, shard, thisUpdate.Format(time.RFC3339), len(revoked.Entries))
+	return nil
+}
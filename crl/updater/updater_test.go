@@ -0,0 +1,279 @@
+package updater
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/cmd"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/issuance"
+	"github.com/letsencrypt/boulder/linter"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type fakeSA struct {
+	reqs    []*sapb.GetRevokedCertsRequest
+	entries []*corepb.CRLEntry
+	err     error
+}
+
+func (f *fakeSA) GetRevokedCerts(_ context.Context, req *sapb.GetRevokedCertsRequest, _ ...grpc.CallOption) (*sapb.RevokedCerts, error) {
+	f.reqs = append(f.reqs, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &sapb.RevokedCerts{Entries: f.entries}, nil
+}
+
+type fakeCA struct {
+	reqs []*capb.GenerateCRLRequest
+}
+
+func (f *fakeCA) GenerateCRL(_ context.Context, req *capb.GenerateCRLRequest, _ ...grpc.CallOption) (*capb.GenerateCRLResponse, error) {
+	f.reqs = append(f.reqs, req)
+	return &capb.GenerateCRLResponse{Crl: []byte{byte(len(req.Entries))}}, nil
+}
+
+type shardKey struct {
+	issuerNameID issuance.IssuerNameID
+	shard        int64
+}
+
+type fakeStorer struct {
+	stored map[shardKey][]byte
+}
+
+func (f *fakeStorer) StoreCRL(_ context.Context, issuerNameID issuance.IssuerNameID, shard int64, crl []byte) error {
+	f.stored[shardKey{issuerNameID, shard}] = crl
+	return nil
+}
+
+func setup(t *testing.T, sa *fakeSA) (*CRLUpdater, *fakeCA, *fakeStorer, clock.FakeClock) {
+	t.Helper()
+	issuer, err := issuance.LoadCertificate("../../test/test-ca.pem")
+	test.AssertNotError(t, err, "loading issuer")
+
+	fc := clock.NewFake()
+	fc.Set(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))
+	ca := &fakeCA{}
+	storer := &fakeStorer{stored: make(map[shardKey][]byte)}
+
+	cu, err := New(
+		[]*issuance.Certificate{issuer},
+		2,
+		sa,
+		ca,
+		storer,
+		6*time.Hour,
+		24*time.Hour,
+		90*24*time.Hour,
+		time.Minute,
+		metrics.NoopRegisterer,
+		blog.NewMock(),
+		fc,
+	)
+	test.AssertNotError(t, err, "creating updater")
+	return cu, ca, storer, fc
+}
+
+func TestNewValidation(t *testing.T) {
+	issuer, err := issuance.LoadCertificate("../../test/test-ca.pem")
+	test.AssertNotError(t, err, "loading issuer")
+	issuers := []*issuance.Certificate{issuer}
+	fc := clock.NewFake()
+
+	_, err = New(nil, 0, &fakeSA{}, &fakeCA{}, &fakeStorer{}, time.Hour, time.Hour, time.Hour, 0, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertError(t, err, "created updater without issuers")
+	_, err = New(issuers, 0, &fakeSA{}, &fakeCA{}, &fakeStorer{}, 0, time.Hour, time.Hour, 0, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertError(t, err, "created updater with zero update period")
+	_, err = New(issuers, 0, &fakeSA{}, &fakeCA{}, &fakeStorer{}, time.Hour, time.Minute, time.Hour, 0, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertError(t, err, "created updater with lookback shorter than update period")
+	_, err = New(issuers, 0, &fakeSA{}, &fakeCA{}, &fakeStorer{}, time.Hour, time.Hour, 0, 0, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertError(t, err, "created updater with zero certificate lifetime")
+	_, err = New(issuers, -1, &fakeSA{}, &fakeCA{}, &fakeStorer{}, time.Hour, time.Hour, time.Hour, 0, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertError(t, err, "created updater with negative number of shards")
+}
+
+func TestTick(t *testing.T) {
+	sa := &fakeSA{entries: []*corepb.CRLEntry{{Serial: "1"}, {Serial: "2"}}}
+	cu, ca, storer, fc := setup(t, sa)
+	start := fc.Now()
+
+	cu.Tick()
+
+	// One CRL is produced for the unsharded certificates and one for each of
+	// the two shards.
+	nameID := cu.issuers[0].NameID()
+	test.AssertEquals(t, len(sa.reqs), 3)
+	for i, req := range sa.reqs {
+		test.AssertEquals(t, req.IssuerNameID, int64(nameID))
+		test.AssertEquals(t, req.CrlShard, int64(i))
+		test.AssertEquals(t, req.ExpiresAfter, start.Add(-24*time.Hour).UnixNano())
+		test.AssertEquals(t, req.ExpiresBefore, start.Add(90*24*time.Hour).UnixNano())
+		test.AssertEquals(t, req.RevokedBefore, start.UnixNano())
+	}
+
+	test.AssertEquals(t, len(ca.reqs), 3)
+	for i, req := range ca.reqs {
+		test.AssertEquals(t, req.CrlShard, int64(i))
+		test.AssertEquals(t, req.ThisUpdate, start.UnixNano())
+		test.AssertEquals(t, len(req.Entries), 2)
+	}
+
+	test.AssertEquals(t, len(storer.stored), 3)
+	test.AssertByteEquals(t, storer.stored[shardKey{nameID, 0}], []byte{2})
+	test.AssertByteEquals(t, storer.stored[shardKey{nameID, 2}], []byte{2})
+	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, map[string]string{"result": "success"}, 3)
+
+	// Tick sleeps until the next update is due, so the next CRL has a later
+	// thisUpdate and therefore a larger CRL number.
+	test.AssertEquals(t, fc.Now(), start.Add(6*time.Hour))
+	cu.Tick()
+	test.AssertEquals(t, len(ca.reqs), 6)
+	test.Assert(t, ca.reqs[3].ThisUpdate > ca.reqs[0].ThisUpdate, "second CRL's thisUpdate was not later")
+}
+
+func TestTickSAFailure(t *testing.T) {
+	sa := &fakeSA{err: errors.New("oops")}
+	cu, ca, storer, _ := setup(t, sa)
+
+	cu.Tick()
+
+	test.AssertEquals(t, len(ca.reqs), 0)
+	test.AssertEquals(t, len(storer.stored), 0)
+	test.AssertMetricWithLabelsEquals(t, cu.updatedCounter, map[string]string{"result": "failed"}, 3)
+}
+
+func TestLocalStorer(t *testing.T) {
+	parent, err := ioutil.TempDir("", "crl-updater")
+	test.AssertNotError(t, err, "creating temp dir")
+	defer os.RemoveAll(parent)
+
+	notADir := filepath.Join(parent, "file")
+	err = ioutil.WriteFile(notADir, nil, 0644)
+	test.AssertNotError(t, err, "creating file")
+	_, err = NewLocalStorer(notADir)
+	test.AssertError(t, err, "created storer for a file")
+
+	dir := filepath.Join(parent, "crls")
+	ls, err := NewLocalStorer(dir)
+	test.AssertNotError(t, err, "creating storer")
+	err = ls.StoreCRL(context.Background(), 1234, 7, []byte{1, 2, 3})
+	test.AssertNotError(t, err, "storing CRL")
+	err = ls.StoreCRL(context.Background(), 1234, 7, []byte{4, 5, 6})
+	test.AssertNotError(t, err, "replacing CRL")
+
+	contents, err := ioutil.ReadFile(filepath.Join(dir, "1234", "7.crl"))
+	test.AssertNotError(t, err, "reading CRL")
+	test.AssertByteEquals(t, contents, []byte{4, 5, 6})
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, "1234"))
+	test.AssertNotError(t, err, "listing directory")
+	test.AssertEquals(t, len(files), 1)
+}
+
+func TestHTTPStorer(t *testing.T) {
+	var gotPath, gotMethod, gotType string
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotMethod, gotType = r.URL.Path, r.Method, r.Header.Get("Content-Type")
+		gotBody, _ = ioutil.ReadAll(r.Body)
+		if r.URL.Path == "/crls/5/0.crl" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	_, err := NewHTTPStorer("ftp://example.com/", nil)
+	test.AssertError(t, err, "created storer with non-HTTP URL")
+
+	hs, err := NewHTTPStorer(srv.URL+"/crls/", srv.Client())
+	test.AssertNotError(t, err, "creating storer")
+	err = hs.StoreCRL(context.Background(), 1234, 7, []byte{1, 2, 3})
+	test.AssertNotError(t, err, "storing CRL")
+	test.AssertEquals(t, gotMethod, http.MethodPut)
+	test.AssertEquals(t, gotPath, "/crls/1234/7.crl")
+	test.AssertEquals(t, gotType, "application/pkix-crl")
+	test.AssertByteEquals(t, gotBody, []byte{1, 2, 3})
+
+	err = hs.StoreCRL(context.Background(), 5, 0, []byte{1, 2, 3})
+	test.AssertError(t, err, "storing CRL succeeded despite server error")
+}
+
+func TestHTTPStorerMatchesCRLDistributionPoint(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+	}))
+	defer srv.Close()
+
+	cert, signer, err := issuance.LoadIssuer(issuance.IssuerLoc{
+		File:     "../../test/test-ca.key",
+		CertFile: "../../test/test-ca.pem",
+	})
+	test.AssertNotError(t, err, "loading issuer")
+	lints, err := linter.New(cert.Certificate, signer, []string{"w_ct_sct_policy_count_unsatisfied"})
+	test.AssertNotError(t, err, "creating linter")
+	profile, err := issuance.NewProfile(
+		issuance.ProfileConfig{
+			MaxValidityPeriod:   cmd.ConfigDuration{Duration: time.Hour},
+			MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+		},
+		issuance.IssuerConfig{
+			UseForECDSALeaves: true,
+			IssuerURL:         "http://issuer-url",
+			OCSPURL:           "http://ocsp-url",
+			CRLURL:            srv.URL + "/crls/",
+		},
+	)
+	test.AssertNotError(t, err, "creating profile")
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	issuer, err := issuance.NewIssuer(cert, signer, profile, lints, fc)
+	test.AssertNotError(t, err, "creating issuer")
+
+	// A certificate from an unsharded issuer points at the CRL of shard zero
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	serial := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	der, err := issuer.Issue(&issuance.IssuanceRequest{
+		PublicKey: key.Public(),
+		Serial:    serial,
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(time.Hour - time.Second),
+	})
+	test.AssertNotError(t, err, "issuing certificate")
+	leaf, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing certificate")
+	test.AssertEquals(t, len(leaf.CRLDistributionPoints), 1)
+	shard, err := issuer.CRLShard("", new(big.Int).SetBytes(serial))
+	test.AssertNotError(t, err, "getting CRL shard")
+
+	// Uploading that shard's CRL beneath the CRL URL stores it at the
+	// distribution point
+	hs, err := NewHTTPStorer(srv.URL+"/crls/", srv.Client())
+	test.AssertNotError(t, err, "creating storer")
+	err = hs.StoreCRL(context.Background(), issuer.Cert.NameID(), shard, []byte{1, 2, 3})
+	test.AssertNotError(t, err, "storing CRL")
+	test.AssertEquals(t, srv.URL+gotPath, leaf.CRLDistributionPoints[0])
+}
@@ -5,6 +5,7 @@ import (
 	"crypto"
 	"crypto/x509"
 	"errors"
+	"net"
 	"strings"
 
 	"github.com/letsencrypt/boulder/core"
@@ -31,6 +32,9 @@ var goodSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
 	x509.ECDSAWithSHA256: true,
 	x509.ECDSAWithSHA384: true,
 	x509.ECDSAWithSHA512: true,
+	// Ed25519 signatures can only be made by Ed25519 keys, which the key
+	// policy rejects unless configured to allow them.
+	x509.PureEd25519: true,
 }
 
 var (
@@ -38,14 +42,13 @@ var (
 	unsupportedSigAlg    = berrors.BadCSRError("signature algorithm not supported")
 	invalidSig           = berrors.BadCSRError("invalid signature on CSR")
 	invalidEmailPresent  = berrors.BadCSRError("CSR contains one or more email address fields")
-	invalidIPPresent     = berrors.BadCSRError("CSR contains one or more IP address fields")
-	invalidNoDNS         = berrors.BadCSRError("at least one DNS name is required")
+	invalidNoDNS         = berrors.BadCSRError("at least one DNS name or IP address is required")
 	invalidAllSANTooLong = berrors.BadCSRError("CSR doesn't contain a SAN short enough to fit in CN")
 )
 
 // VerifyCSR checks the validity of a x509.CertificateRequest. Before doing checks it normalizes
 // the CSR which lowers the case of DNS names and subject CN, and hoist a DNS name into the CN
-// if it is empty.
+// if it is empty. CSRs containing only IP addresses may have an empty CN.
 func VerifyCSR(ctx context.Context, csr *x509.CertificateRequest, maxNames int, keyPolicy *goodkey.KeyPolicy, pa core.PolicyAuthority) error {
 	normalizeCSR(csr)
 	key, ok := csr.PublicKey.(crypto.PublicKey)
@@ -69,24 +72,24 @@ func VerifyCSR(ctx context.Context, csr *x509.CertificateRequest, maxNames int,
 	if len(csr.EmailAddresses) > 0 {
 		return invalidEmailPresent
 	}
-	if len(csr.IPAddresses) > 0 {
-		return invalidIPPresent
-	}
-	if len(csr.DNSNames) == 0 && csr.Subject.CommonName == "" {
+	if len(csr.DNSNames) == 0 && len(csr.IPAddresses) == 0 && csr.Subject.CommonName == "" {
 		return invalidNoDNS
 	}
-	if csr.Subject.CommonName == "" {
+	if len(csr.DNSNames) > 0 && csr.Subject.CommonName == "" {
 		return invalidAllSANTooLong
 	}
 	if len(csr.Subject.CommonName) > maxCNLength {
 		return berrors.BadCSRError("CN was longer than %d bytes", maxCNLength)
 	}
-	if len(csr.DNSNames) > maxNames {
-		return berrors.BadCSRError("CSR contains more than %d DNS names", maxNames)
+	if len(csr.DNSNames)+len(csr.IPAddresses) > maxNames {
+		return berrors.BadCSRError("CSR contains more than %d DNS names and IP addresses", maxNames)
+	}
+	idents := make([]identifier.ACMEIdentifier, 0, len(csr.DNSNames)+len(csr.IPAddresses))
+	for _, dnsName := range csr.DNSNames {
+		idents = append(idents, identifier.DNSIdentifier(dnsName))
 	}
-	idents := make([]identifier.ACMEIdentifier, len(csr.DNSNames))
-	for i, dnsName := range csr.DNSNames {
-		idents[i] = identifier.DNSIdentifier(dnsName)
+	for _, ip := range csr.IPAddresses {
+		idents = append(idents, identifier.IPIdentifier(ip))
 	}
 	err = pa.WillingToIssueWildcards(idents)
 	if err != nil {
@@ -96,8 +99,20 @@ func VerifyCSR(ctx context.Context, csr *x509.CertificateRequest, maxNames int,
 }
 
 // normalizeCSR deduplicates and lowers the case of dNSNames and the subject CN.
-// It will also hoist a dNSName into the CN if it is empty.
+// It will also hoist a dNSName into the CN if it is empty. A CN containing an
+// IP address is moved into the iPAddresses instead of the dNSNames, and then
+// dropped, since an IP address is never hoisted into the CN.
 func normalizeCSR(csr *x509.CertificateRequest) {
+	if ip := net.ParseIP(csr.Subject.CommonName); ip != nil {
+		// Use the same 4-byte representation of IPv4 addresses as the x509
+		// package does when parsing iPAddress SANs.
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		csr.IPAddresses = append(csr.IPAddresses, ip)
+		csr.Subject.CommonName = ""
+	}
+	csr.IPAddresses = uniqueIPs(csr.IPAddresses)
 	if csr.Subject.CommonName == "" {
 		var forcedCN string
 		// Promote the first SAN that is less than maxCNLength (if any)
@@ -114,3 +129,21 @@ func normalizeCSR(csr *x509.CertificateRequest) {
 	csr.Subject.CommonName = strings.ToLower(csr.Subject.CommonName)
 	csr.DNSNames = core.UniqueLowerNames(csr.DNSNames)
 }
+
+// uniqueIPs returns the unique IP addresses in the provided slice, preserving
+// the order of their first occurrence.
+func uniqueIPs(ips []net.IP) []net.IP {
+	if len(ips) == 0 {
+		return ips
+	}
+	seen := make(map[string]bool, len(ips))
+	var unique []net.IP
+	for _, ip := range ips {
+		if seen[ip.String()] {
+			continue
+		}
+		seen[ip.String()] = true
+		unique = append(unique, ip)
+	}
+	return unique
+}
@@ -2,6 +2,7 @@ package csr
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -36,7 +37,7 @@ func (pa *mockPA) WillingToIssue(id identifier.ACMEIdentifier) error {
 
 func (pa *mockPA) WillingToIssueWildcards(idents []identifier.ACMEIdentifier) error {
 	for _, ident := range idents {
-		if ident.Value == "bad-name.com" || ident.Value == "other-bad-name.com" {
+		if ident.Value == "bad-name.com" || ident.Value == "other-bad-name.com" || ident.Value == "10.0.0.1" {
 			return errors.New("policy forbids issuing for identifier")
 		}
 	}
@@ -72,6 +73,9 @@ func TestVerifyCSR(t *testing.T) {
 	signedReqWithIPAddress := new(x509.CertificateRequest)
 	*signedReqWithIPAddress = *signedReq
 	signedReqWithIPAddress.IPAddresses = []net.IP{net.IPv4(1, 2, 3, 4)}
+	signedReqWithBadIPAddress := new(x509.CertificateRequest)
+	*signedReqWithBadIPAddress = *signedReq
+	signedReqWithBadIPAddress.IPAddresses = []net.IP{net.IPv4(10, 0, 0, 1)}
 	signedReqWithAllLongSANs := new(x509.CertificateRequest)
 	*signedReqWithAllLongSANs = *signedReq
 	signedReqWithAllLongSANs.DNSNames = []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.com"}
@@ -123,7 +127,7 @@ func TestVerifyCSR(t *testing.T) {
 			1,
 			testingPolicy,
 			&mockPA{},
-			berrors.BadCSRError("CSR contains more than 1 DNS names"),
+			berrors.BadCSRError("CSR contains more than 1 DNS names and IP addresses"),
 		},
 		{
 			signedReqWithBadNames,
@@ -144,7 +148,14 @@ func TestVerifyCSR(t *testing.T) {
 			100,
 			testingPolicy,
 			&mockPA{},
-			invalidIPPresent,
+			nil,
+		},
+		{
+			signedReqWithBadIPAddress,
+			100,
+			testingPolicy,
+			&mockPA{},
+			errors.New("policy forbids issuing for identifier"),
 		},
 		{
 			signedReqWithAllLongSANs,
@@ -161,6 +172,26 @@ func TestVerifyCSR(t *testing.T) {
 	}
 }
 
+func TestVerifyCSREd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "error generating test key")
+	reqBytes, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		PublicKey: pub,
+		DNSNames:  []string{"a.com"},
+	}, priv)
+	test.AssertNotError(t, err, "error generating test CSR")
+	req, err := x509.ParseCertificateRequest(reqBytes)
+	test.AssertNotError(t, err, "error parsing test CSR")
+
+	err = VerifyCSR(context.Background(), req, 100, testingPolicy, &mockPA{})
+	test.AssertErrorIs(t, err, berrors.BadCSR)
+
+	ed25519Policy := *testingPolicy
+	ed25519Policy.AllowEd25519 = true
+	err = VerifyCSR(context.Background(), req, 100, &ed25519Policy, &mockPA{})
+	test.AssertNotError(t, err, "Ed25519 CSR should have been accepted")
+}
+
 func TestNormalizeCSR(t *testing.T) {
 	tooLongString := strings.Repeat("a", maxCNLength+1)
 
@@ -215,3 +246,16 @@ func TestNormalizeCSR(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeCSRIPAddresses(t *testing.T) {
+	csr := &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: "10.0.0.1"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")},
+	}
+	normalizeCSR(csr)
+	test.AssertEquals(t, csr.Subject.CommonName, "")
+	test.AssertEquals(t, len(csr.DNSNames), 0)
+	test.AssertEquals(t, len(csr.IPAddresses), 2)
+	test.AssertEquals(t, csr.IPAddresses[0].String(), "10.0.0.1")
+	test.AssertEquals(t, csr.IPAddresses[1].String(), "::1")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"errors"
@@ -56,6 +57,8 @@ type Config struct {
 	// be trivially factored because the two factors are very close to each other.
 	// If this config value is empty (0), no factorization will be attempted.
 	FermatRounds int
+	// AllowEd25519 permits Ed25519 keys in addition to RSA and ECDSA keys.
+	AllowEd25519 bool
 }
 
 // ErrBadKey represents an error with a key. It is distinct from the various
@@ -79,13 +82,15 @@ type KeyPolicy struct {
 	AllowRSA           bool // Whether RSA keys should be allowed.
 	AllowECDSANISTP256 bool // Whether ECDSA NISTP256 keys should be allowed.
 	AllowECDSANISTP384 bool // Whether ECDSA NISTP384 keys should be allowed.
+	AllowEd25519       bool // Whether Ed25519 keys should be allowed.
 	weakRSAList        *WeakRSAKeys
 	blockedList        *blockedKeys
 	fermatRounds       int
 	dbCheck            BlockedKeyCheckFunc
 }
 
-// NewKeyPolicy returns a KeyPolicy that allows RSA, ECDSA256 and ECDSA384.
+// NewKeyPolicy returns a KeyPolicy that allows RSA, ECDSA256 and ECDSA384,
+// and Ed25519 if the config allows it.
 // weakKeyFile contains the path to a JSON file containing truncated modulus
 // hashes of known weak RSA keys. If this argument is empty RSA modulus hash
 // checking will be disabled. blockedKeyFile contains the path to a YAML file
@@ -97,6 +102,7 @@ func NewKeyPolicy(config *Config, bkc BlockedKeyCheckFunc) (KeyPolicy, error) {
 		AllowRSA:           true,
 		AllowECDSANISTP256: true,
 		AllowECDSANISTP384: true,
+		AllowEd25519:       config.AllowEd25519,
 		dbCheck:            bkc,
 	}
 	if config.WeakKeyFile != "" {
@@ -122,13 +128,14 @@ func NewKeyPolicy(config *Config, bkc BlockedKeyCheckFunc) (KeyPolicy, error) {
 
 // GoodKey returns true if the key is acceptable for both TLS use and account
 // key use (our requirements are the same for either one), according to basic
-// strength and algorithm checking. GoodKey only supports pointers: *rsa.PublicKey
-// and *ecdsa.PublicKey. It will reject non-pointer types.
+// strength and algorithm checking. GoodKey supports *rsa.PublicKey,
+// *ecdsa.PublicKey and ed25519.PublicKey. It will reject other types,
+// including non-pointer RSA and ECDSA keys.
 // TODO: Support JSONWebKeys once go-jose migration is done.
 func (policy *KeyPolicy) GoodKey(ctx context.Context, key crypto.PublicKey) error {
 	// Early rejection of unacceptable key types to guard subsequent checks.
 	switch t := key.(type) {
-	case *rsa.PublicKey, *ecdsa.PublicKey:
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
 		break
 	default:
 		return badKey("unsupported key type %T", t)
@@ -159,11 +166,24 @@ func (policy *KeyPolicy) GoodKey(ctx context.Context, key crypto.PublicKey) erro
 		return policy.goodKeyRSA(t)
 	case *ecdsa.PublicKey:
 		return policy.goodKeyECDSA(t)
+	case ed25519.PublicKey:
+		return policy.goodKeyEd25519(t)
 	default:
 		return badKey("unsupported key type %T", key)
 	}
 }
 
+// goodKeyEd25519 determines if an Ed25519 pubkey meets our requirements
+func (policy *KeyPolicy) goodKeyEd25519(key ed25519.PublicKey) error {
+	if !policy.AllowEd25519 {
+		return badKey("Ed25519 keys are not allowed")
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return badKey("Ed25519 key must be %d bytes, not %d", ed25519.PublicKeySize, len(key))
+	}
+	return nil
+}
+
 // GoodKeyECDSA determines if an ECDSA pubkey meets our requirements
 func (policy *KeyPolicy) goodKeyECDSA(key *ecdsa.PublicKey) (err error) {
 	// Check the curve.
@@ -3,6 +3,7 @@ package goodkey
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -165,6 +166,25 @@ func TestECDSAGoodKey(t *testing.T) {
 	}
 }
 
+func TestEd25519GoodKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "Error generating Ed25519 key")
+
+	err = testingPolicy.GoodKey(context.Background(), pub)
+	test.AssertError(t, err, "Should have rejected Ed25519 key when not allowed")
+	test.AssertEquals(t, err.Error(), "Ed25519 keys are not allowed")
+
+	policy, err := NewKeyPolicy(&Config{AllowEd25519: true}, nil)
+	test.AssertNotError(t, err, "NewKeyPolicy failed")
+	test.AssertNotError(t, policy.GoodKey(context.Background(), pub), "Should have accepted Ed25519 key")
+
+	err = policy.GoodKey(context.Background(), pub[:16])
+	test.AssertError(t, err, "Should have rejected truncated Ed25519 key")
+
+	err = policy.GoodKey(context.Background(), &pub)
+	test.AssertError(t, err, "Should have rejected pointer to Ed25519 key")
+}
+
 func TestECDSANotOnCurveX(t *testing.T) {
 	for _, curve := range validCurves {
 		// Change a public key so that it is no longer on the curve.
@@ -137,6 +137,25 @@ func ValidationRecordToPB(record core.ValidationRecord) (*corepb.ValidationRecor
 	if err != nil {
 		return nil, err
 	}
+	var addrErrs []*corepb.AddressError
+	for _, e := range record.AddressErrors {
+		addr, err := e.Address.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		addrErrs = append(addrErrs, &corepb.AddressError{
+			Address: addr,
+			Error:   e.Error,
+		})
+	}
+	var perspectives []*corepb.PerspectiveResult
+	for _, p := range record.Perspectives {
+		perspectives = append(perspectives, &corepb.PerspectiveResult{
+			Perspective: p.Perspective,
+			Successes:   int64(p.Successes),
+			Failures:    int64(p.Failures),
+		})
+	}
 	return &corepb.ValidationRecord{
 		Hostname:          record.Hostname,
 		Port:              record.Port,
@@ -144,6 +163,10 @@ func ValidationRecordToPB(record core.ValidationRecord) (*corepb.ValidationRecor
 		AddressUsed:       addrUsed,
 		Url:               record.URL,
 		AddressesTried:    addrsTried,
+		Dnssec:            record.DNSSEC,
+		Proxy:             record.Proxy,
+		Perspectives:      perspectives,
+		AddressErrors:     addrErrs,
 	}, nil
 }
 
@@ -164,6 +187,26 @@ func PBToValidationRecord(in *corepb.ValidationRecord) (record core.ValidationRe
 	if err != nil {
 		return
 	}
+	var addrErrs []core.AddressError
+	for _, e := range in.AddressErrors {
+		var addr net.IP
+		err = addr.UnmarshalText(e.Address)
+		if err != nil {
+			return
+		}
+		addrErrs = append(addrErrs, core.AddressError{
+			Address: addr,
+			Error:   e.Error,
+		})
+	}
+	var perspectives []core.PerspectiveResult
+	for _, p := range in.Perspectives {
+		perspectives = append(perspectives, core.PerspectiveResult{
+			Perspective: p.Perspective,
+			Successes:   int(p.Successes),
+			Failures:    int(p.Failures),
+		})
+	}
 	return core.ValidationRecord{
 		Hostname:          in.Hostname,
 		Port:              in.Port,
@@ -171,6 +214,10 @@ func PBToValidationRecord(in *corepb.ValidationRecord) (record core.ValidationRe
 		AddressUsed:       addrUsed,
 		URL:               in.Url,
 		AddressesTried:    addrsTried,
+		DNSSEC:            in.Dnssec,
+		Proxy:             in.Proxy,
+		Perspectives:      perspectives,
+		AddressErrors:     addrErrs,
 	}, nil
 }
 
@@ -306,6 +353,7 @@ func AuthzToPB(authz core.Authorization) (*corepb.Authorization, error) {
 		Status:         string(authz.Status),
 		Expires:        expires,
 		Challenges:     challs,
+		TypeIdentifier: string(authz.Identifier.Type),
 	}, nil
 }
 
@@ -319,9 +367,15 @@ func PBToAuthz(pb *corepb.Authorization) (core.Authorization, error) {
 		challs[i] = chall
 	}
 	expires := time.Unix(0, pb.Expires).UTC()
+	// Authorizations created before identifier types were stored are DNS
+	// type authorizations.
+	identType := identifier.DNS
+	if pb.TypeIdentifier != "" {
+		identType = identifier.IdentifierType(pb.TypeIdentifier)
+	}
 	authz := core.Authorization{
 		ID:             pb.Id,
-		Identifier:     identifier.ACMEIdentifier{Type: identifier.IdentifierType(pb.Identifier), Value: pb.Identifier},
+		Identifier:     identifier.ACMEIdentifier{Type: identType, Value: pb.Identifier},
 		RegistrationID: pb.RegistrationID,
 		Status:         core.AcmeStatus(pb.Status),
 		Expires:        &expires,
@@ -381,6 +435,7 @@ func CertStatusToPB(certStatus core.CertificateStatus) *corepb.CertificateStatus
 		NotAfter:              certStatus.NotAfter.UnixNano(),
 		IsExpired:             certStatus.IsExpired,
 		IssuerID:              certStatus.IssuerID,
+		IsShortLived:          certStatus.IsShortLived,
 	}
 }
 
@@ -396,6 +451,7 @@ func PBToCertStatus(pb *corepb.CertificateStatus) (core.CertificateStatus, error
 		NotAfter:              time.Unix(0, pb.NotAfter),
 		IsExpired:             pb.IsExpired,
 		IssuerID:              pb.IssuerID,
+		IsShortLived:          pb.IsShortLived,
 	}, nil
 }
 
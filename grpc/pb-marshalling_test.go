@@ -106,6 +106,13 @@ func TestValidationRecord(t *testing.T) {
 		AddressUsed:       ip,
 		URL:               "url",
 		AddressesTried:    []net.IP{ip},
+		AddressErrors: []core.AddressError{
+			{Address: net.ParseIP("::1"), Error: "dial tcp [::1]:80: connect: connection refused"},
+		},
+		Perspectives: []core.PerspectiveResult{
+			{Perspective: "zone-a", Successes: 2},
+			{Perspective: "zone-b", Successes: 1, Failures: 1},
+		},
 	}
 
 	pb, err := ValidationRecordToPB(vr)
@@ -54,6 +54,7 @@ func NewServer(c *cmd.GRPCServerConfig, tlsConfig *tls.Config, metrics serverMet
 	options := []grpc.ServerOption{
 		grpc.Creds(creds),
 		grpc.ChainUnaryInterceptor(allInterceptors...),
+		grpc.ChainStreamInterceptor(si.metrics.grpcMetrics.StreamServerInterceptor()),
 	}
 	if c.MaxConnectionAge.Duration > 0 {
 		options = append(options,
@@ -1,6 +1,8 @@
 // The identifier package defines types for RFC 8555 ACME identifiers.
 package identifier
 
+import "net"
+
 // IdentifierType is a named string type for registered ACME identifier types.
 // See https://tools.ietf.org/html/rfc8555#section-9.7.7
 type IdentifierType string
@@ -9,6 +11,8 @@ const (
 	// DNS is specified in RFC 8555 for DNS type identifiers.
 	DNS = IdentifierType("dns")
 	JWT = IdentifierType("jwt")
+	// IP is specified in RFC 8738 for IP address type identifiers.
+	IP = IdentifierType("ip")
 )
 
 // ACMEIdentifier is a struct encoding an identifier that can be validated. The
@@ -19,7 +23,8 @@ type ACMEIdentifier struct {
 	// Type is the registered IdentifierType of the identifier.
 	Type IdentifierType `json:"type"`
 	// Value is the value of the identifier. For a DNS type identifier it is
-	// a domain name.
+	// a domain name. For an IP type identifier it is the textual form of the
+	// address as produced by net.IP.String().
 	Value string `json:"value"`
 }
 
@@ -31,3 +36,29 @@ func DNSIdentifier(domain string) ACMEIdentifier {
 		Value: domain,
 	}
 }
+
+// IPIdentifier is a convenience function for creating an ACMEIdentifier with
+// Type IP for a given IP address.
+func IPIdentifier(ip net.IP) ACMEIdentifier {
+	return ACMEIdentifier{
+		Type:  IP,
+		Value: ip.String(),
+	}
+}
+
+// ForOrderName returns the identifier for a name in an existing order whose
+// identifier type is orderType. Orders may mix DNS and IP identifiers (RFC
+// 8738), but store only their names. Since an order is never created with a
+// DNS identifier holding an IP address, in an order of either type a name is
+// an IP identifier if it's an IP address, and a DNS identifier otherwise. It
+// must not be used to determine the type of identifiers requested by clients,
+// which is always given explicitly.
+func ForOrderName(orderType IdentifierType, name string) ACMEIdentifier {
+	if orderType != DNS && orderType != IP {
+		return ACMEIdentifier{Type: orderType, Value: name}
+	}
+	if net.ParseIP(name) != nil {
+		return ACMEIdentifier{Type: IP, Value: name}
+	}
+	return DNSIdentifier(name)
+}
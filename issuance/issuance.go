@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -17,6 +18,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -44,6 +47,27 @@ type ProfileConfig struct {
 	Policies            []PolicyInformation
 	MaxValidityPeriod   cmd.ConfigDuration
 	MaxValidityBackdate cmd.ConfigDuration
+
+	// ValidityPeriod is the validity period of certificates issued under this
+	// profile. If unset, the CA's default validity period is used.
+	ValidityPeriod cmd.ConfigDuration
+
+	// ExtKeyUsages lists the extended key usages included in certificates
+	// issued under this profile, by name: "serverAuth" and/or "clientAuth". If
+	// empty, both are included.
+	ExtKeyUsages []string
+
+	// OmitOCSPURL and OmitCRLURL leave the issuer's OCSP URL (in the AIA
+	// extension) and CRL URL (in the CRLDP extension) out of certificates
+	// issued under this profile.
+	OmitOCSPURL bool
+	OmitCRLURL  bool
+
+	// ShortLived marks certificates issued under this profile as short-lived.
+	// Such certificates are revoked only by CRL: they omit the OCSP URL and
+	// no OCSP responses are ever generated for them. A short-lived profile
+	// must include a CRL URL and must not allow must-staple.
+	ShortLived bool
 }
 
 // PolicyInformation describes a policy
@@ -58,16 +82,74 @@ type PolicyQualifier struct {
 	Value string
 }
 
+// ProfilesConfig describes the default certificate profile and the named
+// profiles which clients may select when creating an order. It's kept in a
+// file of its own, which the CA, RA and WFE all load, so that they agree on
+// which profiles exist and what they allow.
+type ProfilesConfig struct {
+	Profile  ProfileConfig
+	Profiles map[string]ProfileConfig
+}
+
+// LoadProfilesConfig reads a ProfilesConfig from the given JSON file.
+func LoadProfilesConfig(filename string) (*ProfilesConfig, error) {
+	var pc ProfilesConfig
+	err := cmd.ReadConfigFile(filename, &pc)
+	if err != nil {
+		return nil, fmt.Errorf("loading certificate profiles from %q: %w", filename, err)
+	}
+	return &pc, nil
+}
+
+// Lookup returns the named profile, or the default profile if the name is
+// empty. It returns false if there is no profile with the given name.
+func (pc *ProfilesConfig) Lookup(name string) (ProfileConfig, bool) {
+	if name == "" {
+		return pc.Profile, true
+	}
+	profile, ok := pc.Profiles[name]
+	return profile, ok
+}
+
+// CheckNames returns an error if any of the given names isn't the name of one
+// of the named profiles.
+func (pc *ProfilesConfig) CheckNames(names []string) error {
+	for _, name := range names {
+		if _, ok := pc.Profiles[name]; !ok {
+			return fmt.Errorf("unknown certificate profile %q", name)
+		}
+	}
+	return nil
+}
+
 // IssuerConfig describes the constraints on and URLs used by a single issuer.
 type IssuerConfig struct {
 	UseForRSALeaves   bool
 	UseForECDSALeaves bool
+	// UseForEd25519Leaves allows this issuer, which may have either an RSA or
+	// an ECDSA key, to issue certificates for Ed25519 public keys.
+	UseForEd25519Leaves bool
 
 	IssuerURL string
 	OCSPURL   string
 	CRLURL    string
 
+	// CRLShards is the number of shards into which this issuer's CRLs are
+	// split. Each certificate is assigned to a shard by its serial number, and
+	// the shard's URL, CRLURL followed by the shard's CRLShardPath, is
+	// included in its CRL Distribution Points extension. If zero, CRLs aren't
+	// sharded and every certificate is assigned shard zero. CRLURL must end
+	// with a slash, and each issuer must have its own CRLURL.
+	CRLShards int
+
 	Location IssuerLoc
+
+	// OCSPSigner, if set, locates a delegated OCSP responder certificate
+	// issued by this issuer, and its key. OCSP responses for this issuer's
+	// certificates are then signed by the delegated responder rather than by
+	// the issuer itself. The certificate must have the id-kp-OCSPSigning
+	// extended key usage and the id-pkix-ocsp-nocheck extension.
+	OCSPSigner *IssuerLoc
 }
 
 // IssuerLoc describes the on-disk location and parameters that an issuer
@@ -154,22 +236,40 @@ func loadSigner(location IssuerLoc, cert *Certificate) (crypto.Signer, error) {
 
 // Profile is the validated structure created by reading in ProfileConfigs and IssuerConfigs
 type Profile struct {
-	useForRSALeaves   bool
-	useForECDSALeaves bool
+	useForRSALeaves     bool
+	useForECDSALeaves   bool
+	useForEd25519Leaves bool
 
 	allowMustStaple bool
 	allowCTPoison   bool
 	allowSCTList    bool
 	allowCommonName bool
 
-	sigAlg    x509.SignatureAlgorithm
-	ocspURL   string
-	crlURL    string
-	issuerURL string
-	policies  *pkix.Extension
+	sigAlg       x509.SignatureAlgorithm
+	extKeyUsages []x509.ExtKeyUsage
+	ocspURL      string
+	omitOCSPURL  bool
+	shortLived   bool
+	crlURL       string
+	crlShards    int
+	issuerURL    string
+	policies     *pkix.Extension
 
 	maxBackdate time.Duration
 	maxValidity time.Duration
+	validity    time.Duration
+}
+
+// ValidityPeriod returns the validity period of certificates issued under this
+// profile, or zero if the profile doesn't configure one.
+func (p *Profile) ValidityPeriod() time.Duration {
+	return p.validity
+}
+
+// ShortLived returns true if certificates issued under this profile are
+// short-lived, and so get no OCSP responses.
+func (p *Profile) ShortLived() bool {
+	return p.shortLived
 }
 
 func parseOID(oidStr string) (asn1.ObjectIdentifier, error) {
@@ -191,6 +291,11 @@ var stringToQualifierType = map[string]asn1.ObjectIdentifier{
 	"id-qt-cps": policyasn1.CPSQualifierOID,
 }
 
+var stringToExtKeyUsage = map[string]x509.ExtKeyUsage{
+	"serverAuth": x509.ExtKeyUsageServerAuth,
+	"clientAuth": x509.ExtKeyUsageClientAuth,
+}
+
 // NewProfile synthesizes the profile config and issuer config into a single
 // object, and checks various aspects for correctness.
 func NewProfile(profileConfig ProfileConfig, issuerConfig IssuerConfig) (*Profile, error) {
@@ -200,18 +305,53 @@ func NewProfile(profileConfig ProfileConfig, issuerConfig IssuerConfig) (*Profil
 	if issuerConfig.OCSPURL == "" {
 		return nil, errors.New("OCSP URL is required")
 	}
+	if issuerConfig.CRLShards < 0 {
+		return nil, errors.New("CRL shards must not be negative")
+	}
+	if issuerConfig.CRLURL != "" && !strings.HasSuffix(issuerConfig.CRLURL, "/") {
+		return nil, errors.New("CRL URL must end with a slash")
+	}
 	sp := &Profile{
-		useForRSALeaves:   issuerConfig.UseForRSALeaves,
-		useForECDSALeaves: issuerConfig.UseForECDSALeaves,
-		allowMustStaple:   profileConfig.AllowMustStaple,
-		allowCTPoison:     profileConfig.AllowCTPoison,
-		allowSCTList:      profileConfig.AllowSCTList,
-		allowCommonName:   profileConfig.AllowCommonName,
-		issuerURL:         issuerConfig.IssuerURL,
-		crlURL:            issuerConfig.CRLURL,
-		ocspURL:           issuerConfig.OCSPURL,
-		maxBackdate:       profileConfig.MaxValidityBackdate.Duration,
-		maxValidity:       profileConfig.MaxValidityPeriod.Duration,
+		useForRSALeaves:     issuerConfig.UseForRSALeaves,
+		useForECDSALeaves:   issuerConfig.UseForECDSALeaves,
+		useForEd25519Leaves: issuerConfig.UseForEd25519Leaves,
+		allowMustStaple:     profileConfig.AllowMustStaple,
+		allowCTPoison:       profileConfig.AllowCTPoison,
+		allowSCTList:        profileConfig.AllowSCTList,
+		allowCommonName:     profileConfig.AllowCommonName,
+		issuerURL:           issuerConfig.IssuerURL,
+		crlURL:              issuerConfig.CRLURL,
+		crlShards:           issuerConfig.CRLShards,
+		ocspURL:             issuerConfig.OCSPURL,
+		maxBackdate:         profileConfig.MaxValidityBackdate.Duration,
+		maxValidity:         profileConfig.MaxValidityPeriod.Duration,
+		validity:            profileConfig.ValidityPeriod.Duration,
+		omitOCSPURL:         profileConfig.OmitOCSPURL || profileConfig.ShortLived,
+		shortLived:          profileConfig.ShortLived,
+	}
+	if sp.validity > sp.maxValidity {
+		return nil, fmt.Errorf("validity period is more than the maximum allowed period (%s>%s)", sp.validity, sp.maxValidity)
+	}
+	if profileConfig.OmitCRLURL {
+		sp.crlURL = ""
+		sp.crlShards = 0
+	}
+	if sp.shortLived {
+		if sp.crlURL == "" {
+			return nil, errors.New("short-lived profile requires a CRL URL")
+		}
+		if sp.allowMustStaple {
+			return nil, errors.New("short-lived profile cannot allow must-staple")
+		}
+	}
+	if len(profileConfig.ExtKeyUsages) > 0 {
+		for _, name := range profileConfig.ExtKeyUsages {
+			eku, ok := stringToExtKeyUsage[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown extended key usage: %s", name)
+			}
+			sp.extKeyUsages = append(sp.extKeyUsages, eku)
+		}
 	}
 	if len(profileConfig.Policies) > 0 {
 		var policies []policyasn1.PolicyInformation
@@ -246,6 +386,34 @@ func NewProfile(profileConfig ProfileConfig, issuerConfig IssuerConfig) (*Profil
 	return sp, nil
 }
 
+// crlShard returns the CRL shard, numbered from one, to which the certificate
+// with the given serial is assigned, or zero if CRLs aren't sharded.
+func (p *Profile) crlShard(serial *big.Int) int64 {
+	if p.crlShards == 0 {
+		return 0
+	}
+	shard := new(big.Int).Mod(serial, big.NewInt(int64(p.crlShards)))
+	return shard.Int64() + 1
+}
+
+// crlShardURL returns the URL of the given CRL shard of the issuer with the
+// given name ID, or the empty string if the profile has no CRL URL or no such
+// shard.
+func (p *Profile) crlShardURL(issuerNameID IssuerNameID, shard int64) string {
+	if p.crlURL == "" || shard < 0 || shard > int64(p.crlShards) {
+		return ""
+	}
+	return p.crlURL + CRLShardPath(issuerNameID, shard)
+}
+
+// CRLShardPath returns the slash-separated path of the CRL for the given issuer
+// and shard, relative to both the issuer's CRL URL and the location the
+// crl-updater publishes CRLs to. Shard zero is the CRL for the certificates
+// issued without a shard.
+func CRLShardPath(issuerNameID IssuerNameID, shard int64) string {
+	return fmt.Sprintf("%d/%d.crl", issuerNameID, shard)
+}
+
 // requestValid verifies the passed IssuanceRequest against the profile. If the
 // request doesn't match the signing profile an error is returned.
 func (p *Profile) requestValid(clk clock.Clock, req *IssuanceRequest) error {
@@ -258,6 +426,10 @@ func (p *Profile) requestValid(clk clock.Clock, req *IssuanceRequest) error {
 		if !p.useForECDSALeaves {
 			return errors.New("cannot sign ECDSA public keys")
 		}
+	case ed25519.PublicKey:
+		if !p.useForEd25519Leaves {
+			return errors.New("cannot sign Ed25519 public keys")
+		}
 	default:
 		return errors.New("unsupported public key type")
 	}
@@ -318,13 +490,16 @@ func (p *Profile) generateTemplate(clk clock.Clock) *x509.Certificate {
 	template := &x509.Certificate{
 		SignatureAlgorithm:    p.sigAlg,
 		ExtKeyUsage:           defaultEKU,
-		OCSPServer:            []string{p.ocspURL},
 		IssuingCertificateURL: []string{p.issuerURL},
 		BasicConstraintsValid: true,
 	}
 
-	if p.crlURL != "" {
-		template.CRLDistributionPoints = []string{p.crlURL}
+	if p.extKeyUsages != nil {
+		template.ExtKeyUsage = p.extKeyUsages
+	}
+
+	if !p.omitOCSPURL {
+		template.OCSPServer = []string{p.ocspURL}
 	}
 
 	if p.policies != nil {
@@ -434,10 +609,26 @@ func GetIssuerNameID(ee *x509.Certificate) IssuerNameID {
 // As per the OCSP spec, it is technically possible for this field to not be
 // populated: the OCSP Response can instead contain a SHA-1 hash of the Issuer
 // Public Key as the Responder ID. The Go stdlib always uses the DN, though.
+// If the response was signed by a delegated responder, the issuer is that
+// responder certificate's issuer.
 func GetOCSPIssuerNameID(resp *ocsp.Response) IssuerNameID {
+	if responder := DelegatedOCSPResponder(resp); responder != nil {
+		return truncatedHash(responder.RawIssuer)
+	}
 	return truncatedHash(resp.RawResponderName)
 }
 
+// DelegatedOCSPResponder returns the delegated responder certificate included
+// in the given OCSP response, or nil if there is none. Responses signed
+// directly by an issuer sometimes include the issuer's own certificate, which
+// is distinguished from a delegated responder by being a CA certificate.
+func DelegatedOCSPResponder(resp *ocsp.Response) *x509.Certificate {
+	if resp.Certificate == nil || resp.Certificate.IsCA {
+		return nil
+	}
+	return resp.Certificate
+}
+
 // truncatedHash computes a truncated SHA1 hash across arbitrary bytes. Uses
 // SHA1 because that is the algorithm most commonly used in OCSP requests.
 // PURPOSEFULLY NOT EXPORTED. Exists only to ensure that the implementations of
@@ -457,8 +648,26 @@ type Issuer struct {
 	Profile *Profile
 	Linter  *linter.Linter
 	Clk     clock.Clock
+
+	// OCSPSigner, if set, is a delegated OCSP responder which signs OCSP
+	// responses on behalf of this issuer.
+	OCSPSigner *OCSPSigner
+
+	// profiles holds the named profiles which may be selected in an
+	// IssuanceRequest in place of the default Profile.
+	profiles map[string]*Profile
 }
 
+// OCSPSigner is a delegated OCSP responder certificate and its key.
+type OCSPSigner struct {
+	Cert   *Certificate
+	Signer crypto.Signer
+}
+
+// oidOCSPNoCheck is the OID of the id-pkix-ocsp-nocheck extension, RFC 6960
+// Section 4.2.2.2.1.
+var oidOCSPNoCheck = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}
+
 // NewIssuer constructs an Issuer on the heap, verifying that the profile
 // is well-formed.
 func NewIssuer(cert *Certificate, signer crypto.Signer, profile *Profile, linter *linter.Linter, clk clock.Clock) (*Issuer, error) {
@@ -478,7 +687,7 @@ func NewIssuer(cert *Certificate, signer crypto.Signer, profile *Profile, linter
 		return nil, errors.New("unsupported issuer key type")
 	}
 
-	if profile.useForRSALeaves || profile.useForECDSALeaves {
+	if profile.useForRSALeaves || profile.useForECDSALeaves || profile.useForEd25519Leaves {
 		if cert.KeyUsage&x509.KeyUsageCertSign == 0 {
 			return nil, errors.New("end-entity signing cert does not have keyUsage certSign")
 		}
@@ -498,6 +707,105 @@ func NewIssuer(cert *Certificate, signer crypto.Signer, profile *Profile, linter
 	return i, nil
 }
 
+// SetOCSPSigner configures the issuer to sign OCSP responses with the given
+// delegated responder certificate and key, after checking that the certificate
+// was issued by this issuer and is suitable for signing OCSP responses.
+func (i *Issuer) SetOCSPSigner(cert *Certificate, signer crypto.Signer) error {
+	err := cert.CheckSignatureFrom(i.Cert.Certificate)
+	if err != nil {
+		return fmt.Errorf("delegated OCSP signer was not issued by %s: %w", i.Name(), err)
+	}
+	if cert.IsCA {
+		return errors.New("delegated OCSP signer must not be a CA certificate")
+	}
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != x509.ExtKeyUsageOCSPSigning {
+		return errors.New("delegated OCSP signer must have only the id-kp-OCSPSigning extended key usage")
+	}
+	if cert.KeyUsage != 0 && cert.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return errors.New("delegated OCSP signer does not have keyUsage digitalSignature")
+	}
+	noCheck := false
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidOCSPNoCheck) {
+			noCheck = true
+			break
+		}
+	}
+	if !noCheck {
+		return errors.New("delegated OCSP signer does not have the id-pkix-ocsp-nocheck extension")
+	}
+	if !core.KeyDigestEquals(signer.Public(), cert.PublicKey) {
+		return errors.New("delegated OCSP signer key does not match its certificate")
+	}
+	i.OCSPSigner = &OCSPSigner{Cert: cert, Signer: signer}
+	return nil
+}
+
+// AddProfile makes the given profile available for issuance under the given
+// name. The profile must have been synthesized from the same IssuerConfig as
+// the issuer's default profile.
+func (i *Issuer) AddProfile(name string, profile *Profile) error {
+	if name == "" {
+		return errors.New("profile name is required")
+	}
+	if _, ok := i.profiles[name]; ok {
+		return fmt.Errorf("duplicate profile name %q", name)
+	}
+	if profile.useForRSALeaves != i.Profile.useForRSALeaves ||
+		profile.useForECDSALeaves != i.Profile.useForECDSALeaves ||
+		profile.useForEd25519Leaves != i.Profile.useForEd25519Leaves {
+		return fmt.Errorf("profile %q is not usable for the same leaf key types as the issuer", name)
+	}
+	profile.sigAlg = i.Profile.sigAlg
+	if i.profiles == nil {
+		i.profiles = make(map[string]*Profile)
+	}
+	i.profiles[name] = profile
+	return nil
+}
+
+// GetProfile returns the named profile, or the issuer's default profile if the
+// name is empty.
+func (i *Issuer) GetProfile(name string) (*Profile, error) {
+	if name == "" {
+		return i.Profile, nil
+	}
+	profile, ok := i.profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown certificate profile %q", name)
+	}
+	return profile, nil
+}
+
+// ProfileNames returns the names of the issuer's named profiles.
+func (i *Issuer) ProfileNames() []string {
+	var names []string
+	for name := range i.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CRLShard returns the CRL shard to which the certificate with the given
+// serial, issued under the named profile, is assigned. It returns zero if this
+// issuer's CRLs aren't sharded or the profile omits the CRL URL.
+func (i *Issuer) CRLShard(profileName string, serial *big.Int) (int64, error) {
+	profile, err := i.GetProfile(profileName)
+	if err != nil {
+		return 0, err
+	}
+	return profile.crlShard(serial), nil
+}
+
+// CRLShardURL returns the URL of the given CRL shard, as included in the CRL
+// Distribution Points extension of the certificates assigned to it. Shard
+// zero holds the certificates issued without a shard. It returns the empty
+// string if the issuer has no CRL URL, and for shards it doesn't have.
+func (i *Issuer) CRLShardURL(shard int64) string {
+	return i.Profile.crlShardURL(i.Cert.NameID(), shard)
+}
+
 // Algs provides the list of leaf certificate public key algorithms for which
 // this issuer is willing to issue. This is not necessarily the same as the
 // public key algorithm or signature algorithm in this issuer's own cert.
@@ -509,6 +817,9 @@ func (i *Issuer) Algs() []x509.PublicKeyAlgorithm {
 	if i.Profile.useForECDSALeaves {
 		algs = append(algs, x509.ECDSA)
 	}
+	if i.Profile.useForEd25519Leaves {
+		algs = append(algs, x509.Ed25519)
+	}
 	return algs
 }
 
@@ -591,13 +902,18 @@ type IssuanceRequest struct {
 	NotBefore time.Time
 	NotAfter  time.Time
 
-	CommonName string
-	DNSNames   []string
+	CommonName  string
+	DNSNames    []string
+	IPAddresses []net.IP
 
 	IncludeMustStaple bool
 	IncludeCTPoison   bool
 	SCTList           []ct.SignedCertificateTimestamp
 	TypeIdentifier    string
+
+	// Profile is the name of the profile to issue under. If empty, the
+	// issuer's default profile is used.
+	Profile string
 }
 
 // Issue generates a certificate from the provided issuance request and
@@ -606,22 +922,31 @@ type IssuanceRequest struct {
 // zlint. If the linting fails, an error is returned and the certificate
 // is not signed using the issuer's key.
 func (i *Issuer) Issue(req *IssuanceRequest) ([]byte, error) {
+	profile, err := i.GetProfile(req.Profile)
+	if err != nil {
+		return nil, err
+	}
+
 	// check request is valid according to the issuance profile
-	err := i.Profile.requestValid(i.Clk, req)
+	err = profile.requestValid(i.Clk, req)
 	if err != nil {
 		return nil, err
 	}
 
 	// generate template from the issuance profile
-	template := i.Profile.generateTemplate(i.Clk)
+	template := profile.generateTemplate(i.Clk)
 
 	// populate template from the issuance request
 	template.NotBefore, template.NotAfter = req.NotBefore, req.NotAfter
 	template.SerialNumber = big.NewInt(0).SetBytes(req.Serial)
+	if shardURL := profile.crlShardURL(i.Cert.NameID(), profile.crlShard(template.SerialNumber)); shardURL != "" {
+		template.CRLDistributionPoints = []string{shardURL}
+	}
 	if req.CommonName != "" {
 		template.Subject.CommonName = req.CommonName
 	}
 	template.DNSNames = req.DNSNames
+	template.IPAddresses = req.IPAddresses
 	template.AuthorityKeyId = i.Cert.SubjectKeyId
 	skid, err := generateSKID(req.PublicKey)
 	if err != nil {
@@ -631,7 +956,7 @@ func (i *Issuer) Issue(req *IssuanceRequest) ([]byte, error) {
 	switch req.PublicKey.(type) {
 	case *rsa.PublicKey:
 		template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
-	case *ecdsa.PublicKey:
+	case *ecdsa.PublicKey, ed25519.PublicKey:
 		template.KeyUsage = x509.KeyUsageDigitalSignature
 	}
 
@@ -679,8 +1004,9 @@ func containsCTPoison(extensions []pkix.Extension) bool {
 
 // RequestFromPrecert constructs a final certificate IssuanceRequest matching
 // the provided precertificate. It returns an error if the precertificate doesn't
-// contain the CT poison extension.
-func RequestFromPrecert(precert *x509.Certificate, scts []ct.SignedCertificateTimestamp, typeIdenfier string) (*IssuanceRequest, error) {
+// contain the CT poison extension. The profile must be the one the
+// precertificate was issued under.
+func RequestFromPrecert(precert *x509.Certificate, scts []ct.SignedCertificateTimestamp, typeIdenfier string, profile string) (*IssuanceRequest, error) {
 	if !containsCTPoison(precert.Extensions) {
 		return nil, errors.New("provided certificate doesn't contain the CT poison extension")
 	}
@@ -691,9 +1017,11 @@ func RequestFromPrecert(precert *x509.Certificate, scts []ct.SignedCertificateTi
 		NotAfter:          precert.NotAfter,
 		CommonName:        precert.Subject.CommonName,
 		DNSNames:          precert.DNSNames,
+		IPAddresses:       precert.IPAddresses,
 		IncludeMustStaple: ContainsMustStaple(precert.Extensions),
 		SCTList:           scts,
 		TypeIdentifier:    typeIdenfier,
+		Profile:           profile,
 	}, nil
 }
 
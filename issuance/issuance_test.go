@@ -13,6 +13,7 @@ import (
 	"encoding/asn1"
 	"io/ioutil"
 	"math/big"
+	"net"
 	"os"
 	"testing"
 	"time"
@@ -159,6 +160,43 @@ func TestNewProfileUnknownQualifierType(t *testing.T) {
 	test.AssertEquals(t, err.Error(), "unknown qualifier type: asd")
 }
 
+func TestNewProfileUnknownExtKeyUsage(t *testing.T) {
+	config := defaultProfileConfig()
+	config.ExtKeyUsages = []string{"serverAuth", "codeSigning"}
+	_, err := NewProfile(config, defaultIssuerConfig())
+	test.AssertError(t, err, "NewProfile didn't fail with unknown extended key usage")
+	test.AssertEquals(t, err.Error(), "unknown extended key usage: codeSigning")
+}
+
+func TestNewProfileValidityTooLong(t *testing.T) {
+	config := defaultProfileConfig()
+	config.ValidityPeriod = cmd.ConfigDuration{Duration: 2 * time.Hour}
+	_, err := NewProfile(config, defaultIssuerConfig())
+	test.AssertError(t, err, "NewProfile didn't fail with validity period longer than the maximum")
+}
+
+func TestLoadProfilesConfig(t *testing.T) {
+	pc, err := LoadProfilesConfig("../test/certificate-profiles.json")
+	test.AssertNotError(t, err, "LoadProfilesConfig failed")
+
+	profile, ok := pc.Lookup("")
+	test.Assert(t, ok, "default profile not found")
+	test.AssertEquals(t, profile.MaxValidityPeriod.Duration, 2160*time.Hour)
+	profile, ok = pc.Lookup("shortlived")
+	test.Assert(t, ok, "shortlived profile not found")
+	test.AssertEquals(t, profile.MaxValidityPeriod.Duration, 160*time.Hour)
+	_, ok = pc.Lookup("nonexistent")
+	test.Assert(t, !ok, "nonexistent profile found")
+
+	test.AssertNotError(t, pc.CheckNames([]string{"shortlived"}), "CheckNames failed")
+	err = pc.CheckNames([]string{"shortlived", "nonexistent"})
+	test.AssertError(t, err, "CheckNames accepted an unknown profile")
+	test.AssertContains(t, err.Error(), `"nonexistent"`)
+
+	_, err = LoadProfilesConfig("../test/nonexistent.json")
+	test.AssertError(t, err, "LoadProfilesConfig didn't fail for a missing file")
+}
+
 func TestRequestValid(t *testing.T) {
 	fc := clock.NewFake()
 	fc.Add(time.Hour * 24)
@@ -180,6 +218,12 @@ func TestRequestValid(t *testing.T) {
 			request:       &IssuanceRequest{PublicKey: &rsa.PublicKey{}},
 			expectedError: "cannot sign RSA public keys",
 		},
+		{
+			name:          "cannot sign ed25519",
+			profile:       &Profile{},
+			request:       &IssuanceRequest{PublicKey: ed25519.PublicKey{}},
+			expectedError: "cannot sign Ed25519 public keys",
+		},
 		{
 			name:          "cannot sign ecdsa",
 			profile:       &Profile{},
@@ -375,21 +419,6 @@ func TestGenerateTemplate(t *testing.T) {
 		profile          *Profile
 		expectedTemplate *x509.Certificate
 	}{
-		{
-			name: "crl url",
-			profile: &Profile{
-				crlURL: "crl-url",
-				sigAlg: x509.SHA256WithRSA,
-			},
-			expectedTemplate: &x509.Certificate{
-				BasicConstraintsValid: true,
-				SignatureAlgorithm:    x509.SHA256WithRSA,
-				ExtKeyUsage:           defaultEKU,
-				IssuingCertificateURL: []string{""},
-				OCSPServer:            []string{""},
-				CRLDistributionPoints: []string{"crl-url"},
-			},
-		},
 		{
 			name: "include policies",
 			profile: &Profile{
@@ -413,6 +442,34 @@ func TestGenerateTemplate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ext key usages",
+			profile: &Profile{
+				sigAlg:       x509.SHA256WithRSA,
+				extKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			},
+			expectedTemplate: &x509.Certificate{
+				BasicConstraintsValid: true,
+				SignatureAlgorithm:    x509.SHA256WithRSA,
+				ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+				IssuingCertificateURL: []string{""},
+				OCSPServer:            []string{""},
+			},
+		},
+		{
+			name: "omit ocsp url",
+			profile: &Profile{
+				sigAlg:      x509.SHA256WithRSA,
+				ocspURL:     "ocsp-url",
+				omitOCSPURL: true,
+			},
+			expectedTemplate: &x509.Certificate{
+				BasicConstraintsValid: true,
+				SignatureAlgorithm:    x509.SHA256WithRSA,
+				ExtKeyUsage:           defaultEKU,
+				IssuingCertificateURL: []string{""},
+			},
+		},
 	}
 	fc := clock.NewFake()
 	fc.Set(time.Time{}.Add(time.Hour))
@@ -510,6 +567,67 @@ func TestNewIssuerOCSPOnly(t *testing.T) {
 	test.AssertNotError(t, err, "NewIssuer failed")
 }
 
+func TestSetOCSPSigner(t *testing.T) {
+	issuer, err := NewIssuer(issuerCert, issuerSigner, defaultProfile(), &linter.Linter{}, clock.NewFake())
+	test.AssertNotError(t, err, "NewIssuer failed")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	makeCert := func(template *x509.Certificate, parent *x509.Certificate, parentKey crypto.Signer) *Certificate {
+		t.Helper()
+		template.SerialNumber = big.NewInt(456)
+		template.Subject = pkix.Name{CommonName: "big ca ocsp"}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+		test.AssertNotError(t, err, "failed to create delegated signer cert")
+		cert, err := x509.ParseCertificate(der)
+		test.AssertNotError(t, err, "failed to parse delegated signer cert")
+		return &Certificate{Certificate: cert}
+	}
+	noCheck := []pkix.Extension{{Id: oidOCSPNoCheck, Value: []byte{5, 0}}}
+
+	err = issuer.SetOCSPSigner(makeCert(&x509.Certificate{
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+	}, issuerCert.Certificate, issuerSigner), key)
+	test.AssertError(t, err, "SetOCSPSigner accepted a cert without id-pkix-ocsp-nocheck")
+
+	err = issuer.SetOCSPSigner(makeCert(&x509.Certificate{
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtraExtensions: noCheck,
+	}, issuerCert.Certificate, issuerSigner), key)
+	test.AssertError(t, err, "SetOCSPSigner accepted a cert without id-kp-OCSPSigning")
+
+	selfSigned := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		Subject:               pkix.Name{CommonName: "big ca"},
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	otherDER, err := x509.CreateCertificate(rand.Reader, selfSigned, selfSigned, key.Public(), key)
+	test.AssertNotError(t, err, "failed to create other issuer")
+	otherIssuer, err := x509.ParseCertificate(otherDER)
+	test.AssertNotError(t, err, "failed to parse other issuer")
+	err = issuer.SetOCSPSigner(makeCert(&x509.Certificate{
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+		ExtraExtensions: noCheck,
+	}, otherIssuer, key), key)
+	test.AssertError(t, err, "SetOCSPSigner accepted a cert from a different issuer")
+
+	good := makeCert(&x509.Certificate{
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageOCSPSigning},
+		ExtraExtensions: noCheck,
+	}, issuerCert.Certificate, issuerSigner)
+	err = issuer.SetOCSPSigner(good, issuerSigner)
+	test.AssertError(t, err, "SetOCSPSigner accepted a key which doesn't match the cert")
+	test.Assert(t, issuer.OCSPSigner == nil, "OCSPSigner set despite errors")
+
+	err = issuer.SetOCSPSigner(good, key)
+	test.AssertNotError(t, err, "SetOCSPSigner failed")
+	test.AssertNotNil(t, issuer.OCSPSigner, "OCSPSigner not set")
+	test.AssertEquals(t, issuer.OCSPSigner.Cert, good)
+}
+
 func TestIssue(t *testing.T) {
 	for _, tc := range []struct {
 		name         string
@@ -567,6 +685,233 @@ func TestIssue(t *testing.T) {
 	}
 }
 
+func TestIssueIPAddresses(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	linter, err := linter.New(
+		issuerCert.Certificate,
+		issuerSigner,
+		[]string{"w_ct_sct_policy_count_unsatisfied"},
+	)
+	test.AssertNotError(t, err, "failed to create linter")
+	signer, err := NewIssuer(issuerCert, issuerSigner, defaultProfile(), linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	certBytes, err := signer.Issue(&IssuanceRequest{
+		PublicKey:   pk.Public(),
+		Serial:      []byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		IPAddresses: []net.IP{net.ParseIP("93.184.216.34").To4()},
+		NotBefore:   fc.Now(),
+		NotAfter:    fc.Now().Add(time.Hour - time.Second),
+	})
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	test.AssertEquals(t, len(cert.DNSNames), 0)
+	test.AssertEquals(t, len(cert.IPAddresses), 1)
+	test.AssertEquals(t, cert.IPAddresses[0].String(), "93.184.216.34")
+	test.AssertEquals(t, cert.Subject.CommonName, "")
+}
+
+func TestIssueNamedProfile(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	linter, err := linter.New(
+		issuerCert.Certificate,
+		issuerSigner,
+		[]string{"w_ct_sct_policy_count_unsatisfied", "e_sub_cert_aia_does_not_contain_ocsp_url"},
+	)
+	test.AssertNotError(t, err, "failed to create linter")
+	signer, err := NewIssuer(issuerCert, issuerSigner, defaultProfile(), linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+
+	config := defaultProfileConfig()
+	config.ExtKeyUsages = []string{"serverAuth"}
+	config.OmitOCSPURL = true
+	profile, err := NewProfile(config, defaultIssuerConfig())
+	test.AssertNotError(t, err, "NewProfile failed")
+	err = signer.AddProfile("", profile)
+	test.AssertError(t, err, "AddProfile didn't fail with an empty name")
+	err = signer.AddProfile("shortlived", profile)
+	test.AssertNotError(t, err, "AddProfile failed")
+	err = signer.AddProfile("shortlived", profile)
+	test.AssertError(t, err, "AddProfile didn't fail with a duplicate name")
+	test.AssertDeepEquals(t, signer.ProfileNames(), []string{"shortlived"})
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	req := &IssuanceRequest{
+		PublicKey: pk.Public(),
+		Serial:    []byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(time.Hour - time.Second),
+		Profile:   "shortlived",
+	}
+	certBytes, err := signer.Issue(req)
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	test.AssertDeepEquals(t, cert.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+	test.AssertEquals(t, len(cert.OCSPServer), 0)
+
+	req.Profile = "unknown"
+	_, err = signer.Issue(req)
+	test.AssertError(t, err, "Issue didn't fail with an unknown profile")
+	test.AssertEquals(t, err.Error(), `unknown certificate profile "unknown"`)
+}
+
+func TestNewProfileShortLived(t *testing.T) {
+	config := defaultProfileConfig()
+	config.ShortLived = true
+	_, err := NewProfile(config, defaultIssuerConfig())
+	test.AssertError(t, err, "NewProfile didn't fail for a short-lived profile without a CRL URL")
+
+	ic := defaultIssuerConfig()
+	ic.CRLURL = "http://crl-url/"
+	_, err = NewProfile(config, ic)
+	test.AssertError(t, err, "NewProfile didn't fail for a short-lived profile allowing must-staple")
+
+	config.AllowMustStaple = false
+	profile, err := NewProfile(config, ic)
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, profile.ShortLived(), "profile should be short-lived")
+	test.Assert(t, profile.omitOCSPURL, "short-lived profile should omit the OCSP URL")
+
+	config.OmitCRLURL = true
+	_, err = NewProfile(config, ic)
+	test.AssertError(t, err, "NewProfile didn't fail for a short-lived profile omitting the CRL URL")
+}
+
+func TestNewProfileCRLShards(t *testing.T) {
+	ic := defaultIssuerConfig()
+	ic.CRLShards = -1
+	_, err := NewProfile(defaultProfileConfig(), ic)
+	test.AssertError(t, err, "NewProfile didn't fail with negative CRL shards")
+
+	ic.CRLShards = 4
+	ic.CRLURL = "http://crl-url/1.crl"
+	_, err = NewProfile(defaultProfileConfig(), ic)
+	test.AssertError(t, err, "NewProfile didn't fail with a sharded CRL URL not ending in a slash")
+
+	ic.CRLShards = 0
+	_, err = NewProfile(defaultProfileConfig(), ic)
+	test.AssertError(t, err, "NewProfile didn't fail with an unsharded CRL URL not ending in a slash")
+}
+
+func TestIssueCRLShard(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	linter, err := linter.New(
+		issuerCert.Certificate,
+		issuerSigner,
+		[]string{"w_ct_sct_policy_count_unsatisfied"},
+	)
+	test.AssertNotError(t, err, "failed to create linter")
+
+	ic := defaultIssuerConfig()
+	ic.CRLURL = "http://crl-url/"
+	ic.CRLShards = 4
+	profile, err := NewProfile(defaultProfileConfig(), ic)
+	test.AssertNotError(t, err, "NewProfile failed")
+	signer, err := NewIssuer(issuerCert, issuerSigner, profile, linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+
+	omitProfileConfig := defaultProfileConfig()
+	omitProfileConfig.OmitCRLURL = true
+	omitProfile, err := NewProfile(omitProfileConfig, ic)
+	test.AssertNotError(t, err, "NewProfile failed")
+	err = signer.AddProfile("nocrl", omitProfile)
+	test.AssertNotError(t, err, "AddProfile failed")
+
+	crlShard := func(profileName string, serial int64) int64 {
+		t.Helper()
+		shard, err := signer.CRLShard(profileName, big.NewInt(serial))
+		test.AssertNotError(t, err, "CRLShard failed")
+		return shard
+	}
+	test.AssertEquals(t, crlShard("", 7), int64(4))
+	test.AssertEquals(t, crlShard("", 8), int64(1))
+	// Certificates issued under a profile without a CRL URL have no shard
+	test.AssertEquals(t, crlShard("nocrl", 7), int64(0))
+	_, err = signer.CRLShard("unknown", big.NewInt(7))
+	test.AssertError(t, err, "CRLShard didn't fail for an unknown profile")
+
+	test.AssertEquals(t, signer.CRLShardURL(0), "http://crl-url/"+CRLShardPath(issuerCert.NameID(), 0))
+	test.AssertEquals(t, signer.CRLShardURL(5), "")
+	test.AssertEquals(t, CRLShardPath(1234, 2), "1234/2.crl")
+	test.AssertEquals(t, signer.CRLShardURL(2), "http://crl-url/"+CRLShardPath(issuerCert.NameID(), 2))
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	serial := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	certBytes, err := signer.Issue(&IssuanceRequest{
+		PublicKey: pk.Public(),
+		Serial:    serial,
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(time.Hour - time.Second),
+	})
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	shard := crlShard("", new(big.Int).SetBytes(serial).Int64())
+	test.AssertDeepEquals(t, cert.CRLDistributionPoints, []string{signer.CRLShardURL(shard)})
+
+	certBytes, err = signer.Issue(&IssuanceRequest{
+		PublicKey: pk.Public(),
+		Serial:    serial,
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(time.Hour - time.Second),
+		Profile:   "nocrl",
+	})
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err = x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	test.AssertEquals(t, len(cert.CRLDistributionPoints), 0)
+}
+
+func TestIssueUnshardedCRLURL(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	linter, err := linter.New(
+		issuerCert.Certificate,
+		issuerSigner,
+		[]string{"w_ct_sct_policy_count_unsatisfied"},
+	)
+	test.AssertNotError(t, err, "failed to create linter")
+
+	ic := defaultIssuerConfig()
+	ic.CRLURL = "http://crl-url/"
+	profile, err := NewProfile(defaultProfileConfig(), ic)
+	test.AssertNotError(t, err, "NewProfile failed")
+	signer, err := NewIssuer(issuerCert, issuerSigner, profile, linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	serial := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	shard, err := signer.CRLShard("", new(big.Int).SetBytes(serial))
+	test.AssertNotError(t, err, "CRLShard failed")
+	test.AssertEquals(t, shard, int64(0))
+
+	// Certificates of an unsharded issuer point at the CRL for shard zero,
+	// which is where the crl-updater publishes it
+	certBytes, err := signer.Issue(&IssuanceRequest{
+		PublicKey: pk.Public(),
+		Serial:    serial,
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(time.Hour - time.Second),
+	})
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	test.AssertDeepEquals(t, cert.CRLDistributionPoints, []string{"http://crl-url/" + CRLShardPath(issuerCert.NameID(), 0)})
+}
+
 func TestIssueRSA(t *testing.T) {
 	fc := clock.NewFake()
 	fc.Set(time.Now())
@@ -598,6 +943,53 @@ func TestIssueRSA(t *testing.T) {
 	test.AssertEquals(t, cert.KeyUsage, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment)
 }
 
+func TestIssueEd25519(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	linter, err := linter.New(
+		issuerCert.Certificate,
+		issuerSigner,
+		[]string{"w_ct_sct_policy_count_unsatisfied"},
+	)
+	test.AssertNotError(t, err, "failed to create linter")
+	pk, _, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	req := &IssuanceRequest{
+		PublicKey: pk,
+		Serial:    []byte{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(time.Hour - time.Second),
+	}
+
+	// The default profile doesn't allow Ed25519 leaves.
+	signer, err := NewIssuer(issuerCert, issuerSigner, defaultProfile(), linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+	_, err = signer.Issue(req)
+	test.AssertError(t, err, "Issue should have failed")
+	test.AssertEquals(t, err.Error(), "cannot sign Ed25519 public keys")
+
+	issuerConfig := defaultIssuerConfig()
+	issuerConfig.UseForEd25519Leaves = true
+	profile, err := NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertNotError(t, err, "NewProfile failed")
+	signer, err = NewIssuer(issuerCert, issuerSigner, profile, linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+	test.AssertDeepEquals(t, signer.Algs(), []x509.PublicKeyAlgorithm{x509.RSA, x509.ECDSA, x509.Ed25519})
+
+	certBytes, err := signer.Issue(req)
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	err = cert.CheckSignatureFrom(issuerCert.Certificate)
+	test.AssertNotError(t, err, "signature validation failed")
+	test.AssertEquals(t, cert.PublicKeyAlgorithm, x509.Ed25519)
+	test.AssertDeepEquals(t, cert.PublicKey, pk)
+	test.AssertEquals(t, cert.SignatureAlgorithm, x509.ECDSAWithSHA256)
+	test.AssertEquals(t, len(cert.Extensions), 8) // Constraints, KU, EKU, SKID, AKID, AIA, SAN, Policies
+	test.AssertEquals(t, cert.KeyUsage, x509.KeyUsageDigitalSignature)
+}
+
 func TestIssueCTPoison(t *testing.T) {
 	fc := clock.NewFake()
 	fc.Set(time.Now())
@@ -0,0 +1,43 @@
+package subscriber
+
+import (
+	"github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v3/lint"
+	"github.com/zmap/zlint/v3/util"
+
+	"github.com/letsencrypt/boulder/linter/lints"
+)
+
+type ed25519KeyUsage struct{}
+
+func init() {
+	lint.RegisterLint(&lint.Lint{
+		Name:          "e_ed25519_key_usage",
+		Description:   "Let's Encrypt Subscriber Certificates for Ed25519 keys have a Key Usage of digitalSignature only",
+		Citation:      "RFC 8410: 5",
+		Source:        lints.LetsEncryptCPSSubscriber,
+		EffectiveDate: util.RFC5280Date,
+		Lint:          NewEd25519KeyUsage,
+	})
+}
+
+func NewEd25519KeyUsage() lint.LintInterface {
+	return &ed25519KeyUsage{}
+}
+
+func (l *ed25519KeyUsage) CheckApplies(c *x509.Certificate) bool {
+	return c.PublicKeyAlgorithm == x509.Ed25519 && !c.IsCA
+}
+
+func (l *ed25519KeyUsage) Execute(c *x509.Certificate) *lint.LintResult {
+	// RFC 8410 5: Ed25519 keys can only sign, so keyEncipherment, keyAgreement
+	// and the like make no sense. Of the usages it allows, only
+	// digitalSignature is appropriate for a TLS subscriber certificate.
+	if !util.IsExtInCert(c, util.KeyUsageOID) {
+		return &lint.LintResult{Status: lint.Error, Details: "Key Usage extension is missing"}
+	}
+	if c.KeyUsage != x509.KeyUsageDigitalSignature {
+		return &lint.LintResult{Status: lint.Error, Details: "Key Usage must be digitalSignature only"}
+	}
+	return &lint.LintResult{Status: lint.Pass}
+}
@@ -0,0 +1,57 @@
+package subscriber
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	zlintx509 "github.com/zmap/zcrypto/x509"
+	"github.com/zmap/zlint/v3/lint"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func makeLintCert(t *testing.T, pub interface{}, ku x509.KeyUsage) *zlintx509.Certificate {
+	t.Helper()
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating issuer key")
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"example.com"},
+		KeyUsage:     ku,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, issuerKey)
+	test.AssertNotError(t, err, "creating certificate")
+	cert, err := zlintx509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing certificate")
+	return cert
+}
+
+func TestEd25519KeyUsage(t *testing.T) {
+	ed25519Key, _, err := ed25519.GenerateKey(rand.Reader)
+	test.AssertNotError(t, err, "generating Ed25519 key")
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating ECDSA key")
+
+	l := NewEd25519KeyUsage()
+
+	cert := makeLintCert(t, ecdsaKey.Public(), x509.KeyUsageDigitalSignature|x509.KeyUsageKeyAgreement)
+	test.Assert(t, !l.CheckApplies(cert), "lint should not apply to ECDSA keys")
+
+	cert = makeLintCert(t, ed25519Key, x509.KeyUsageDigitalSignature)
+	test.Assert(t, l.CheckApplies(cert), "lint should apply to Ed25519 keys")
+	test.AssertEquals(t, l.Execute(cert).Status, lint.Pass)
+
+	cert = makeLintCert(t, ed25519Key, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment)
+	test.AssertEquals(t, l.Execute(cert).Status, lint.Error)
+
+	cert = makeLintCert(t, ed25519Key, 0)
+	test.AssertEquals(t, l.Execute(cert).Status, lint.Error)
+}
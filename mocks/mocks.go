@@ -337,6 +337,11 @@ func (sa *StorageAuthority) SetOrderError(_ context.Context, req *sapb.SetOrderE
 	return &emptypb.Empty{}, nil
 }
 
+// DeactivateOrder is a mock
+func (sa *StorageAuthority) DeactivateOrder(_ context.Context, req *sapb.OrderRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
 // FinalizeOrder is a mock
 func (sa *StorageAuthority) FinalizeOrder(_ context.Context, req *sapb.FinalizeOrderRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
@@ -358,6 +363,7 @@ func (sa *StorageAuthority) GetOrder(_ context.Context, req *sapb.OrderRequest,
 		Created:           created,
 		Expires:           exp,
 		Names:             []string{"example.com"},
+		TypeIdentifier:    string(identifier.DNS),
 		Status:            string(core.StatusValid),
 		V2Authorizations:  []int64{1},
 		CertificateSerial: "serial",
@@ -543,6 +549,11 @@ func (sa *StorageAuthority) KeyBlocked(ctx context.Context, req *sapb.KeyBlocked
 	return &sapb.Exists{Exists: false}, nil
 }
 
+// GetRevokedCerts is a mock
+func (sa *StorageAuthority) GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest, _ ...grpc.CallOption) (*sapb.RevokedCerts, error) {
+	return &sapb.RevokedCerts{}, nil
+}
+
 // Publisher is a mock
 type PublisherClient struct {
 	// empty
@@ -42,7 +42,7 @@ func NewDbSource(dbMap dbSelector, stats prometheus.Registerer, log blog.Logger)
 
 // Response implements the Source interface. It looks up the requested OCSP
 // response in the sql database. If the certificate status row that it finds
-// indicates that the cert is expired or this cert has never had an OCSP
+// indicates that the cert is expired, is short-lived, or has never had an OCSP
 // response generated for it, it returns an error.
 func (src *dbSource) Response(ctx context.Context, req *ocsp.Request) (*Response, error) {
 	serialString := core.SerialToString(req.SerialNumber)
@@ -63,6 +63,10 @@ func (src *dbSource) Response(ctx context.Context, req *ocsp.Request) (*Response
 		src.log.Infof("OCSP Response not sent (expired) for CA=%s, Serial=%s", hex.EncodeToString(req.IssuerKeyHash), serialString)
 		src.counter.WithLabelValues("expired").Inc()
 		return nil, ErrNotFound
+	} else if certStatus.IsShortLived {
+		src.log.Infof("OCSP Response not sent (short-lived) for CA=%s, Serial=%s", hex.EncodeToString(req.IssuerKeyHash), serialString)
+		src.counter.WithLabelValues("short_lived").Inc()
+		return nil, ErrNotFound
 	} else if certStatus.OCSPLastUpdated.IsZero() {
 		src.log.Warningf("OCSP Response not sent (ocspLastUpdated is zero) for CA=%s, Serial=%s", hex.EncodeToString(req.IssuerKeyHash), serialString)
 		src.counter.WithLabelValues("never_updated").Inc()
@@ -76,5 +80,5 @@ func (src *dbSource) Response(ctx context.Context, req *ocsp.Request) (*Response
 	}
 
 	src.counter.WithLabelValues("success").Inc()
-	return &Response{Response: resp, Raw: certStatus.OCSPResponse}, nil
+	return &Response{Response: resp, Raw: certStatus.OCSPResponse, Source: "db"}, nil
 }
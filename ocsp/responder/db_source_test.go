@@ -142,6 +142,19 @@ func TestDbSource(t *testing.T) {
 	_, err = src.Response(context.Background(), req)
 	test.AssertErrorIs(t, err, ErrNotFound)
 
+	// Test for converting short-lived results into no results, even if a
+	// response was stored for them.
+	status = core.CertificateStatus{
+		IsExpired:       false,
+		IsShortLived:    true,
+		OCSPLastUpdated: time.Now(),
+		OCSPResponse:    respBytes,
+	}
+	src, err = NewDbSource(echoSelector{status: status}, metrics.NoopRegisterer, blog.NewMock())
+	test.AssertNotError(t, err, "failed to create dbSource")
+	_, err = src.Response(context.Background(), req)
+	test.AssertErrorIs(t, err, ErrNotFound)
+
 	// Test for converting never-updated results into no results.
 	status = core.CertificateStatus{
 		IsExpired:       false,
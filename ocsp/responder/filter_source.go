@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"crypto/x509"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/jmhodges/clock"
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
@@ -19,6 +22,9 @@ import (
 type responderID struct {
 	nameHash []byte
 	keyHash  []byte
+	// cert is the issuer certificate, used to verify delegated responder
+	// certificates included in responses.
+	cert *x509.Certificate
 }
 
 type filterSource struct {
@@ -28,12 +34,13 @@ type filterSource struct {
 	serialPrefixes []string
 	counter        *prometheus.CounterVec
 	log            blog.Logger
+	clk            clock.Clock
 }
 
 // NewFilterSource returns a filterSource which performs various checks on the
 // OCSP requests sent to the wrapped Source, and the OCSP responses returned
 // by it.
-func NewFilterSource(issuerCerts []*issuance.Certificate, serialPrefixes []string, wrapped Source, stats prometheus.Registerer, log blog.Logger) (*filterSource, error) {
+func NewFilterSource(issuerCerts []*issuance.Certificate, serialPrefixes []string, wrapped Source, stats prometheus.Registerer, log blog.Logger, clk clock.Clock) (*filterSource, error) {
 	if len(issuerCerts) < 1 {
 		return nil, errors.New("Filter must include at least 1 issuer cert")
 	}
@@ -44,6 +51,7 @@ func NewFilterSource(issuerCerts []*issuance.Certificate, serialPrefixes []strin
 		rid := responderID{
 			keyHash:  keyHash[:],
 			nameHash: nameHash[:],
+			cert:     issuerCert.Certificate,
 		}
 		issuersByNameId[issuerCert.NameID()] = rid
 	}
@@ -58,6 +66,7 @@ func NewFilterSource(issuerCerts []*issuance.Certificate, serialPrefixes []strin
 		serialPrefixes: serialPrefixes,
 		counter:        counter,
 		log:            log,
+		clk:            clk,
 	}, nil
 }
 
@@ -123,14 +132,22 @@ func (src *filterSource) checkRequest(req *ocsp.Request) (issuance.IssuerNameID,
 // checkResponse returns nil if the ocsp response was generated by the same
 // issuer as was identified in the request, or an error otherwise. This filters
 // out, for example, responses which are for a serial that we issued, but from a
-// different issuer than that contained in the request.
+// different issuer than that contained in the request. Responses signed by a
+// delegated responder are accepted only if the responder's certificate was
+// issued by the requested issuer for the purpose of signing OCSP responses.
 func (src *filterSource) checkResponse(reqIssuerID issuance.IssuerNameID, resp *Response) error {
 	respIssuerID := issuance.GetOCSPIssuerNameID(resp.Response)
 	if reqIssuerID != respIssuerID {
-		// This would be allowed if we used delegated responders, but we don't.
 		return fmt.Errorf("responder name does not match requested issuer name")
 	}
 
+	if responder := issuance.DelegatedOCSPResponder(resp.Response); responder != nil {
+		err := src.checkDelegatedResponder(src.issuers[reqIssuerID].cert, responder, resp)
+		if err != nil {
+			return fmt.Errorf("invalid delegated responder: %w", err)
+		}
+	}
+
 	// In an ideal world, we'd also compare the Issuer Key Hash from the request's
 	// CertID (equivalent to looking up the key hash in src.issuers) against the
 	// Issuer Key Hash contained in the response's CertID. However, the Go OCSP
@@ -140,3 +157,34 @@ func (src *filterSource) checkResponse(reqIssuerID issuance.IssuerNameID, resp *
 
 	return nil
 }
+
+// checkDelegatedResponder returns nil if the responder certificate included in
+// the response was issued by the given issuer, is currently valid, is
+// authorized to sign OCSP responses, and is the certificate named as the
+// response's responder. The response's signature by that certificate was
+// already verified when it was parsed.
+func (src *filterSource) checkDelegatedResponder(issuer, responder *x509.Certificate, resp *Response) error {
+	if issuer == nil {
+		return errors.New("issuer certificate unavailable")
+	}
+	err := responder.CheckSignatureFrom(issuer)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(responder.RawSubject, resp.RawResponderName) {
+		return errors.New("responder name does not match responder certificate")
+	}
+	now := src.clk.Now()
+	if now.Before(responder.NotBefore) {
+		return fmt.Errorf("responder certificate is not valid until %s", responder.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(responder.NotAfter) {
+		return fmt.Errorf("responder certificate expired at %s", responder.NotAfter.Format(time.RFC3339))
+	}
+	for _, eku := range responder.ExtKeyUsage {
+		if eku == x509.ExtKeyUsageOCSPSigning {
+			return nil
+		}
+	}
+	return errors.New("responder certificate lacks id-kp-OCSPSigning")
+}
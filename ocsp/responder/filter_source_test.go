@@ -3,10 +3,18 @@ package responder
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
 	"io/ioutil"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/jmhodges/clock"
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/issuance"
 	blog "github.com/letsencrypt/boulder/log"
@@ -16,14 +24,14 @@ import (
 )
 
 func TestNewFilter(t *testing.T) {
-	_, err := NewFilterSource([]*issuance.Certificate{}, []string{}, nil, metrics.NoopRegisterer, blog.NewMock())
+	_, err := NewFilterSource([]*issuance.Certificate{}, []string{}, nil, metrics.NoopRegisterer, blog.NewMock(), clock.NewFake())
 	test.AssertError(t, err, "didn't error when creating empty filter")
 
 	issuer, err := issuance.LoadCertificate("./testdata/test-ca.der.pem")
 	test.AssertNotError(t, err, "failed to load issuer cert")
 	issuerNameId := issuer.NameID()
 
-	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, nil, metrics.NoopRegisterer, blog.NewMock())
+	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, nil, metrics.NoopRegisterer, blog.NewMock(), clock.NewFake())
 	test.AssertNotError(t, err, "errored when creating good filter")
 	test.AssertEquals(t, len(f.issuers), 1)
 	test.AssertEquals(t, len(f.serialPrefixes), 1)
@@ -34,7 +42,7 @@ func TestCheckRequest(t *testing.T) {
 	issuer, err := issuance.LoadCertificate("./testdata/test-ca.der.pem")
 	test.AssertNotError(t, err, "failed to load issuer cert")
 
-	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, nil, metrics.NoopRegisterer, blog.NewMock())
+	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, nil, metrics.NoopRegisterer, blog.NewMock(), clock.NewFake())
 	test.AssertNotError(t, err, "errored when creating good filter")
 
 	reqBytes, err := ioutil.ReadFile("./testdata/ocsp.req")
@@ -86,8 +94,8 @@ func TestCheckResponse(t *testing.T) {
 	resp, err := ocsp.ParseResponse(respBytes, nil)
 	test.AssertNotError(t, err, "failed to parse OCSP response")
 
-	source := &echoSource{&Response{resp, respBytes}}
-	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, source, metrics.NoopRegisterer, blog.NewMock())
+	source := &echoSource{&Response{Response: resp, Raw: respBytes}}
+	f, err := NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, source, metrics.NoopRegisterer, blog.NewMock(), clock.NewFake())
 	test.AssertNotError(t, err, "errored when creating good filter")
 
 	actual, err := f.Response(context.Background(), req)
@@ -96,10 +104,103 @@ func TestCheckResponse(t *testing.T) {
 
 	// Overwrite the Responder Name in the stored response to cause a diagreement.
 	resp.RawResponderName = []byte("C = US, O = Foo, DN = Bar")
-	source = &echoSource{&Response{resp, respBytes}}
-	f, err = NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, source, metrics.NoopRegisterer, blog.NewMock())
+	source = &echoSource{&Response{Response: resp, Raw: respBytes}}
+	f, err = NewFilterSource([]*issuance.Certificate{issuer}, []string{"00"}, source, metrics.NoopRegisterer, blog.NewMock(), clock.NewFake())
 	test.AssertNotError(t, err, "errored when creating good filter")
 
 	_, err = f.Response(context.Background(), req)
 	test.AssertError(t, err, "expected error")
 }
+
+func TestCheckResponseDelegated(t *testing.T) {
+	makeCert := func(template, parent *x509.Certificate, pub crypto.PublicKey, parentKey crypto.Signer) *x509.Certificate {
+		t.Helper()
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, parentKey)
+		test.AssertNotError(t, err, "failed to create certificate")
+		cert, err := x509.ParseCertificate(der)
+		test.AssertNotError(t, err, "failed to parse certificate")
+		return cert
+	}
+	newKey := func() *ecdsa.PrivateKey {
+		t.Helper()
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		test.AssertNotError(t, err, "failed to generate key")
+		return key
+	}
+	caTemplate := func(cn string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		}
+	}
+	clk := clock.NewFake()
+	responderTemplate := func(eku x509.ExtKeyUsage) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "delegated responder"},
+			ExtKeyUsage:  []x509.ExtKeyUsage{eku},
+			NotBefore:    clk.Now().Add(-time.Hour),
+			NotAfter:     clk.Now().Add(time.Hour),
+		}
+	}
+
+	issuerKey := newKey()
+	issuerCert := makeCert(caTemplate("issuer"), caTemplate("issuer"), issuerKey.Public(), issuerKey)
+	issuer, err := issuance.NewCertificate(issuerCert)
+	test.AssertNotError(t, err, "failed to load issuer cert")
+	// An unrelated CA whose subject is identical to the real issuer's.
+	impostorKey := newKey()
+	impostorCert := makeCert(caTemplate("issuer"), caTemplate("issuer"), impostorKey.Public(), impostorKey)
+
+	nameHash, keyHash := issuer.NameHash(), issuer.KeyHash()
+	req := &ocsp.Request{
+		HashAlgorithm:  crypto.SHA1,
+		IssuerNameHash: nameHash[:],
+		IssuerKeyHash:  keyHash[:],
+		SerialNumber:   big.NewInt(1234),
+	}
+
+	respond := func(responderCert *x509.Certificate, responderKey crypto.Signer) error {
+		t.Helper()
+		respBytes, err := ocsp.CreateResponse(issuerCert, responderCert, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now(),
+			Certificate:  responderCert,
+		}, responderKey)
+		test.AssertNotError(t, err, "failed to create OCSP response")
+		resp, err := ocsp.ParseResponse(respBytes, nil)
+		test.AssertNotError(t, err, "failed to parse OCSP response")
+		f, err := NewFilterSource([]*issuance.Certificate{issuer}, nil, &echoSource{&Response{Response: resp, Raw: respBytes}}, metrics.NoopRegisterer, blog.NewMock(), clk)
+		test.AssertNotError(t, err, "errored when creating good filter")
+		_, err = f.Response(context.Background(), req)
+		return err
+	}
+
+	responderKey := newKey()
+	err = respond(makeCert(responderTemplate(x509.ExtKeyUsageOCSPSigning), issuerCert, responderKey.Public(), issuerKey), responderKey)
+	test.AssertNotError(t, err, "rejected response from a valid delegated responder")
+
+	err = respond(makeCert(responderTemplate(x509.ExtKeyUsageServerAuth), issuerCert, responderKey.Public(), issuerKey), responderKey)
+	test.AssertError(t, err, "accepted response from a responder without id-kp-OCSPSigning")
+
+	err = respond(makeCert(responderTemplate(x509.ExtKeyUsageOCSPSigning), impostorCert, responderKey.Public(), impostorKey), responderKey)
+	test.AssertError(t, err, "accepted response from a responder issued by a different CA")
+
+	expired := responderTemplate(x509.ExtKeyUsageOCSPSigning)
+	expired.NotBefore = clk.Now().Add(-2 * time.Hour)
+	expired.NotAfter = clk.Now().Add(-time.Hour)
+	err = respond(makeCert(expired, issuerCert, responderKey.Public(), issuerKey), responderKey)
+	test.AssertError(t, err, "accepted response from an expired responder")
+	test.AssertContains(t, err.Error(), "responder certificate expired")
+
+	notYetValid := responderTemplate(x509.ExtKeyUsageOCSPSigning)
+	notYetValid.NotBefore = clk.Now().Add(time.Hour)
+	notYetValid.NotAfter = clk.Now().Add(2 * time.Hour)
+	err = respond(makeCert(notYetValid, issuerCert, responderKey.Public(), issuerKey), responderKey)
+	test.AssertError(t, err, "accepted response from a not yet valid responder")
+	test.AssertContains(t, err.Error(), "responder certificate is not valid until")
+}
@@ -59,6 +59,7 @@ func NewMemorySourceFromFile(responseFile string, logger blog.Logger) (*inMemory
 		responses[response.SerialNumber.String()] = &Response{
 			Response: response,
 			Raw:      der,
+			Source:   "memory",
 		}
 	}
 
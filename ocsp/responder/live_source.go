@@ -0,0 +1,209 @@
+package responder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/crypto/ocsp"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/rocsp"
+	rocsp_config "github.com/letsencrypt/boulder/rocsp/config"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// errLiveSigningRateLimited is returned when a response would have to be
+// signed live but doing so would exceed the configured signing rate.
+var errLiveSigningRateLimited = errors.New("live signing rate limit exceeded")
+
+// certStatusGetter is the subset of the SA's gRPC client which the
+// liveSigningSource relies on.
+type certStatusGetter interface {
+	GetCertificateStatus(ctx context.Context, req *sapb.Serial, opts ...grpc.CallOption) (*corepb.CertificateStatus, error)
+}
+
+// ocspGenerator is the subset of the CA's OCSPGenerator gRPC client which the
+// liveSigningSource relies on.
+type ocspGenerator interface {
+	GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequest, opts ...grpc.CallOption) (*capb.OCSPResponse, error)
+}
+
+// responseStorer is the subset of rocsp.WritingClient which the
+// liveSigningSource relies on.
+type responseStorer interface {
+	StoreResponse(ctx context.Context, respBytes []byte, shortIssuerID byte, ttl time.Duration) error
+}
+
+type liveSigningSource struct {
+	primary Source
+	sa      certStatusGetter
+	signer  ocspGenerator
+	storer  responseStorer
+	issuers []rocsp_config.ShortIDIssuer
+	timeout time.Duration
+	limiter *rate.Limiter
+	flight  singleflight.Group
+	counter *prometheus.CounterVec
+	log     blog.Logger
+	clk     clock.Clock
+}
+
+// NewLiveSigningSource returns a Source which looks up OCSP responses in the
+// primary Source, which is expected to be backed by Redis. When the primary
+// has no response for a serial, it reads the certificate's status from the SA,
+// asks the CA to sign a fresh response, writes that response back to Redis and
+// serves it. At most one response is signed at a time for any given serial,
+// and no more than maxSigningRate responses are signed per second, with bursts
+// of up to maxSigningBurst. Signing a response is not tied to the request which
+// caused it, since other requests may be waiting for the same response, and
+// instead may take up to timeout.
+func NewLiveSigningSource(
+	primary Source,
+	sa certStatusGetter,
+	signer ocspGenerator,
+	storer responseStorer,
+	issuers []rocsp_config.ShortIDIssuer,
+	timeout time.Duration,
+	maxSigningRate float64,
+	maxSigningBurst int,
+	stats prometheus.Registerer,
+	log blog.Logger,
+	clk clock.Clock,
+) (*liveSigningSource, error) {
+	if primary == nil || sa == nil || signer == nil || storer == nil {
+		return nil, errors.New("must provide a primary source, SA, CA and Redis client")
+	}
+	if timeout <= 0 {
+		return nil, errors.New("signing timeout must be positive")
+	}
+	if maxSigningRate <= 0 {
+		return nil, errors.New("maximum signing rate must be positive")
+	}
+	if maxSigningBurst <= 0 {
+		maxSigningBurst = 1
+	}
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ocsp_live_signing_responses",
+		Help: "Count of OCSP requests/responses by action taken by the liveSigningSource",
+	}, []string{"result"})
+	stats.MustRegister(counter)
+	return &liveSigningSource{
+		primary: primary,
+		sa:      sa,
+		signer:  signer,
+		storer:  storer,
+		issuers: issuers,
+		timeout: timeout,
+		limiter: rate.NewLimiter(rate.Limit(maxSigningRate), maxSigningBurst),
+		counter: counter,
+		log:     log,
+		clk:     clk,
+	}, nil
+}
+
+// Response implements the Source interface. It returns the primary Source's
+// response if there is one, and otherwise signs a new response live.
+func (src *liveSigningSource) Response(ctx context.Context, req *ocsp.Request) (*Response, error) {
+	resp, err := src.primary.Response(ctx, req)
+	if err == nil {
+		src.counter.WithLabelValues("primary_result").Inc()
+		return resp, nil
+	}
+	if !errors.Is(err, rocsp.ErrRedisNotFound) {
+		src.counter.WithLabelValues("primary_error").Inc()
+		return nil, err
+	}
+
+	serialString := core.SerialToString(req.SerialNumber)
+	result := src.flight.DoChan(serialString, func() (interface{}, error) {
+		signCtx, cancel := context.WithTimeout(context.Background(), src.timeout)
+		defer cancel()
+		return src.signAndStore(signCtx, serialString)
+	})
+	select {
+	case <-ctx.Done():
+		src.counter.WithLabelValues("timed_out").Inc()
+		return nil, fmt.Errorf("waiting for live signed OCSP response for serial %s: %w", serialString, ctx.Err())
+	case r := <-result:
+		resp, err = r.Val.(*Response), r.Err
+	}
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			src.counter.WithLabelValues("not_found").Inc()
+		} else if errors.Is(err, errLiveSigningRateLimited) {
+			src.counter.WithLabelValues("rate_limited").Inc()
+		} else {
+			src.counter.WithLabelValues("signing_error").Inc()
+		}
+		return nil, err
+	}
+	src.counter.WithLabelValues("live_signed").Inc()
+	return resp, nil
+}
+
+// signAndStore obtains a new OCSP response for the given serial from the CA,
+// and stores it in Redis for subsequent requests. A failure to store the
+// response is logged, but the response is still returned.
+func (src *liveSigningSource) signAndStore(ctx context.Context, serial string) (*Response, error) {
+	if !src.limiter.AllowN(src.clk.Now(), 1) {
+		return nil, errLiveSigningRateLimited
+	}
+
+	status, err := src.sa.GetCertificateStatus(ctx, &sapb.Serial{Serial: serial})
+	if err != nil {
+		if errors.Is(err, berrors.NotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("looking up certificate status for serial %s: %w", serial, err)
+	}
+
+	notAfter := time.Unix(0, status.NotAfter)
+	if status.IsExpired || !src.clk.Now().Before(notAfter) {
+		return nil, ErrNotFound
+	}
+	// Short-lived certificates are revoked only by CRL, and never get OCSP
+	// responses.
+	if status.IsShortLived {
+		return nil, ErrNotFound
+	}
+
+	issuer, err := rocsp_config.FindIssuerByID(status.IssuerID, src.issuers)
+	if err != nil {
+		return nil, fmt.Errorf("finding issuer for serial %s: %w", serial, err)
+	}
+
+	signed, err := src.signer.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
+		Serial:    status.Serial,
+		IssuerID:  status.IssuerID,
+		Status:    status.Status,
+		Reason:    int32(status.RevokedReason),
+		RevokedAt: status.RevokedDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signing OCSP response for serial %s: %w", serial, err)
+	}
+
+	resp, err := ocsp.ParseResponse(signed.Response, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing live signed OCSP response for serial %s: %w", serial, err)
+	}
+
+	err = src.storer.StoreResponse(ctx, signed.Response, issuer.ShortID(), notAfter.Sub(src.clk.Now()))
+	if err != nil {
+		src.log.Warningf("Storing live signed OCSP response for serial %s: %s", serial, err)
+		src.counter.WithLabelValues("store_error").Inc()
+	}
+
+	return &Response{Response: resp, Raw: signed.Response, Source: "live"}, nil
+}
@@ -0,0 +1,257 @@
+package responder
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/rocsp"
+	rocsp_config "github.com/letsencrypt/boulder/rocsp/config"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type missSource struct{}
+
+func (src *missSource) Response(context.Context, *ocsp.Request) (*Response, error) {
+	return nil, rocsp.ErrRedisNotFound
+}
+
+type fakeStatusSA struct {
+	status *corepb.CertificateStatus
+	err    error
+}
+
+func (sa *fakeStatusSA) GetCertificateStatus(_ context.Context, req *sapb.Serial, _ ...grpc.CallOption) (*corepb.CertificateStatus, error) {
+	if sa.err != nil {
+		return nil, sa.err
+	}
+	return sa.status, nil
+}
+
+// fakeSigner signs OCSP responses with a throwaway key, optionally blocking
+// until released so that concurrent requests pile up.
+type fakeSigner struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	calls   int32
+	release chan struct{}
+}
+
+func newFakeSigner(t *testing.T) *fakeSigner {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "responder"}}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating certificate")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing certificate")
+	return &fakeSigner{cert: cert, key: key}
+}
+
+func (s *fakeSigner) GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequest, _ ...grpc.CallOption) (*capb.OCSPResponse, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if s.release != nil {
+		<-s.release
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	serial, err := core.StringToSerial(req.Serial)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ocsp.CreateResponse(s.cert, s.cert, ocsp.Response{
+		Status:       ocsp.Good,
+		SerialNumber: serial,
+		ThisUpdate:   time.Now(),
+	}, s.key)
+	return &capb.OCSPResponse{Response: resp}, err
+}
+
+type fakeStorer struct {
+	sync.Mutex
+	stored  int
+	shortID byte
+	ttl     time.Duration
+}
+
+func (s *fakeStorer) StoreResponse(_ context.Context, _ []byte, shortIssuerID byte, ttl time.Duration) error {
+	s.Lock()
+	defer s.Unlock()
+	s.stored++
+	s.shortID = shortIssuerID
+	s.ttl = ttl
+	return nil
+}
+
+func setupLiveSigning(t *testing.T, rate float64, burst int) (*liveSigningSource, *fakeStatusSA, *fakeSigner, *fakeStorer, clock.FakeClock) {
+	t.Helper()
+	issuers, err := rocsp_config.LoadIssuers(map[string]int{
+		"../../test/hierarchy/int-e1.cert.pem": 23,
+	})
+	test.AssertNotError(t, err, "loading issuers")
+
+	fc := clock.NewFake()
+	fc.Set(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))
+	sa := &fakeStatusSA{status: &corepb.CertificateStatus{
+		Serial:   "0000000000000000000000000000000000ff",
+		Status:   string(core.OCSPStatusGood),
+		NotAfter: fc.Now().Add(time.Hour).UnixNano(),
+		IssuerID: int64(issuers[0].NameID()),
+	}}
+	signer := newFakeSigner(t)
+	storer := &fakeStorer{}
+	src, err := NewLiveSigningSource(&missSource{}, sa, signer, storer, issuers, time.Second, rate, burst, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertNotError(t, err, "creating liveSigningSource")
+	return src, sa, signer, storer, fc
+}
+
+func TestLiveSigningPrimaryResult(t *testing.T) {
+	_, sa, signer, storer, fc := setupLiveSigning(t, 1, 1)
+	src, err := NewLiveSigningSource(&succeedSource{}, sa, signer, storer, nil, time.Second, 1, 1, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertNotError(t, err, "creating liveSigningSource")
+
+	_, err = src.Response(context.Background(), &ocsp.Request{SerialNumber: big.NewInt(0xff)})
+	test.AssertNotError(t, err, "unexpected error")
+	test.AssertEquals(t, signer.calls, int32(0))
+
+	// Errors other than a miss are not handled by signing.
+	src.primary = &failSource{}
+	_, err = src.Response(context.Background(), &ocsp.Request{SerialNumber: big.NewInt(0xff)})
+	test.AssertError(t, err, "expected error")
+	test.AssertEquals(t, signer.calls, int32(0))
+}
+
+func TestLiveSigningMiss(t *testing.T) {
+	src, _, signer, storer, _ := setupLiveSigning(t, 1, 1)
+
+	resp, err := src.Response(context.Background(), &ocsp.Request{SerialNumber: big.NewInt(0xff)})
+	test.AssertNotError(t, err, "unexpected error")
+	test.AssertEquals(t, resp.SerialNumber.Int64(), int64(0xff))
+	test.AssertEquals(t, signer.calls, int32(1))
+	test.AssertEquals(t, storer.stored, 1)
+	test.AssertEquals(t, storer.shortID, byte(23))
+	test.AssertEquals(t, storer.ttl, time.Hour)
+}
+
+func TestLiveSigningNotFound(t *testing.T) {
+	src, sa, signer, _, fc := setupLiveSigning(t, 100, 100)
+
+	sa.err = berrors.NotFoundError("no such serial")
+	_, err := src.Response(context.Background(), &ocsp.Request{SerialNumber: big.NewInt(0xff)})
+	test.AssertErrorIs(t, err, ErrNotFound)
+
+	sa.err = errors.New("oops")
+	_, err = src.Response(context.Background(), &ocsp.Request{SerialNumber: big.NewInt(0xff)})
+	test.AssertError(t, err, "expected error")
+	test.Assert(t, !errors.Is(err, ErrNotFound), "SA failure shouldn't be reported as not found")
+
+	// Responses aren't signed for short-lived certificates.
+	sa.err = nil
+	sa.status.IsShortLived = true
+	_, err = src.Response(context.Background(), &ocsp.Request{SerialNumber: big.NewInt(0xff)})
+	test.AssertErrorIs(t, err, ErrNotFound)
+
+	// Nor for expired certificates.
+	sa.status.IsShortLived = false
+	fc.Add(2 * time.Hour)
+	_, err = src.Response(context.Background(), &ocsp.Request{SerialNumber: big.NewInt(0xff)})
+	test.AssertErrorIs(t, err, ErrNotFound)
+	test.AssertEquals(t, signer.calls, int32(0))
+}
+
+func TestLiveSigningRateLimit(t *testing.T) {
+	src, _, signer, _, fc := setupLiveSigning(t, 1, 2)
+	req := &ocsp.Request{SerialNumber: big.NewInt(0xff)}
+
+	for i := 0; i < 2; i++ {
+		_, err := src.Response(context.Background(), req)
+		test.AssertNotError(t, err, "unexpected error within burst")
+	}
+	_, err := src.Response(context.Background(), req)
+	test.AssertErrorIs(t, err, errLiveSigningRateLimited)
+	test.AssertEquals(t, signer.calls, int32(2))
+
+	fc.Add(time.Second)
+	_, err = src.Response(context.Background(), req)
+	test.AssertNotError(t, err, "unexpected error after refill")
+}
+
+func TestLiveSigningSingleflight(t *testing.T) {
+	src, _, signer, storer, _ := setupLiveSigning(t, 100, 100)
+	signer.release = make(chan struct{})
+	req := &ocsp.Request{SerialNumber: big.NewInt(0xff)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := src.Response(context.Background(), req)
+			test.AssertNotError(t, err, "unexpected error")
+		}()
+	}
+	// Wait for the first request to reach the signer, and give the others
+	// a moment to join it, before letting it finish.
+	for atomic.LoadInt32(&signer.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(signer.release)
+	wg.Wait()
+
+	test.AssertEquals(t, atomic.LoadInt32(&signer.calls), int32(1))
+	test.AssertEquals(t, storer.stored, 1)
+}
+
+func TestLiveSigningCanceledRequest(t *testing.T) {
+	src, _, signer, storer, _ := setupLiveSigning(t, 100, 100)
+	signer.release = make(chan struct{})
+	req := &ocsp.Request{SerialNumber: big.NewInt(0xff)}
+
+	// The request which starts the signing operation gives up on it...
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := src.Response(ctx, req)
+		canceled <- err
+	}()
+	for atomic.LoadInt32(&signer.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	test.AssertErrorIs(t, <-canceled, context.Canceled)
+
+	// ...but another request waiting for the same response still gets it.
+	waiting := make(chan error)
+	go func() {
+		_, err := src.Response(context.Background(), req)
+		waiting <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(signer.release)
+	test.AssertNotError(t, <-waiting, "unexpected error")
+	test.AssertEquals(t, atomic.LoadInt32(&signer.calls), int32(1))
+	test.AssertEquals(t, storer.stored, 1)
+}
@@ -21,7 +21,7 @@ func (src *succeedSource) Response(context.Context, *ocsp.Request) (*Response, e
 	}
 	// We can't just return nil, as the multiSource checks the Statuses from each
 	// Source to ensure they agree.
-	return &Response{&ocsp.Response{Status: ocsp.Good}, []byte{}}, nil
+	return &Response{Response: &ocsp.Response{Status: ocsp.Good}, Raw: []byte{}}, nil
 }
 
 type failSource struct{}
@@ -63,7 +63,7 @@ func TestBothErr(t *testing.T) {
 }
 
 func TestBothSucceedButDisagree(t *testing.T) {
-	otherResp := &Response{&ocsp.Response{Status: ocsp.Revoked}, []byte{}}
+	otherResp := &Response{Response: &ocsp.Response{Status: ocsp.Revoked}, Raw: []byte{}}
 	src, err := NewMultiSource(&succeedSource{otherResp}, &succeedSource{}, metrics.NoopRegisterer, blog.NewMock())
 	test.AssertNotError(t, err, "failed to create multiSource")
 
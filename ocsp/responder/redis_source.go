@@ -50,5 +50,5 @@ func (src *redisSource) Response(ctx context.Context, req *ocsp.Request) (*Respo
 	}
 
 	src.counter.WithLabelValues("success").Inc()
-	return &Response{Response: resp, Raw: respBytes}, nil
+	return &Response{Response: resp, Raw: respBytes, Source: "redis"}, nil
 }
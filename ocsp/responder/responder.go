@@ -42,6 +42,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/honeycombio/beeline-go"
@@ -68,9 +69,13 @@ var responseTypeToString = map[ocsp.ResponseStatus]string{
 
 // A Responder object provides an HTTP wrapper around a Source.
 type Responder struct {
-	Source        Source
-	timeout       time.Duration
+	Source  Source
+	timeout time.Duration
+	// maxAge, if non-zero, is the longest max-age sent in the Cache-Control
+	// header. The max-age never extends past the response's nextUpdate.
+	maxAge        time.Duration
 	responseTypes *prometheus.CounterVec
+	cacheResults  *prometheus.CounterVec
 	responseAges  prometheus.Histogram
 	requestSizes  prometheus.Histogram
 	clk           clock.Clock
@@ -78,7 +83,7 @@ type Responder struct {
 }
 
 // NewResponder instantiates a Responder with the give Source.
-func NewResponder(source Source, timeout time.Duration, stats prometheus.Registerer, logger blog.Logger) *Responder {
+func NewResponder(source Source, timeout time.Duration, maxAge time.Duration, stats prometheus.Registerer, logger blog.Logger) *Responder {
 	requestSizes := prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:    "ocsp_request_sizes",
@@ -109,10 +114,21 @@ func NewResponder(source Source, timeout time.Duration, stats prometheus.Registe
 	)
 	stats.MustRegister(responseTypes)
 
+	cacheResults := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ocsp_cache_results",
+			Help: "Number of successful OCSP responses by the Source they came from and whether the client's cached copy was still valid",
+		},
+		[]string{"source", "result"},
+	)
+	stats.MustRegister(cacheResults)
+
 	return &Responder{
 		Source:        source,
 		timeout:       timeout,
+		maxAge:        maxAge,
 		responseTypes: responseTypes,
+		cacheResults:  cacheResults,
 		responseAges:  responseAges,
 		requestSizes:  requestSizes,
 		clk:           clock.New(),
@@ -298,26 +314,18 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	}
 
 	// Write OCSP response
-	response.Header().Add("Last-Modified", ocspResponse.ThisUpdate.Format(time.RFC1123))
-	response.Header().Add("Expires", ocspResponse.NextUpdate.Format(time.RFC1123))
-	now := rs.clk.Now()
-	maxAge := 0
-	if now.Before(ocspResponse.NextUpdate) {
-		maxAge = int(ocspResponse.NextUpdate.Sub(now) / time.Second)
-	} else {
-		// TODO(#530): we want max-age=0 but this is technically an authorized OCSP response
-		//             (despite being stale) and 5019 forbids attaching no-cache
-		maxAge = 0
-	}
+	response.Header().Add("Last-Modified", ocspResponse.ProducedAt.UTC().Format(http.TimeFormat))
+	response.Header().Add("Expires", ocspResponse.NextUpdate.UTC().Format(http.TimeFormat))
 	response.Header().Set(
 		"Cache-Control",
 		fmt.Sprintf(
 			"max-age=%d, public, no-transform, must-revalidate",
-			maxAge,
+			rs.computeMaxAge(ocspResponse),
 		),
 	)
 	responseHash := sha256.Sum256(ocspResponse.Raw)
-	response.Header().Add("ETag", fmt.Sprintf("\"%X\"", responseHash))
+	etag := fmt.Sprintf("\"%X\"", responseHash)
+	response.Header().Add("ETag", etag)
 
 	serialString := core.SerialToString(ocspResponse.SerialNumber)
 	if len(serialString) > 2 {
@@ -327,17 +335,62 @@ func (rs Responder) ServeHTTP(response http.ResponseWriter, request *http.Reques
 		response.Header().Add("Edge-Cache-Tag", serialString[len(serialString)-2:])
 	}
 
+	source := ocspResponse.Source
+	if source == "" {
+		source = "unknown"
+	}
+
 	// RFC 7232 says that a 304 response must contain the above
 	// headers if they would also be sent for a 200 for the same
 	// request, so we have to wait until here to do this
-	if etag := request.Header.Get("If-None-Match"); etag != "" {
-		if etag == fmt.Sprintf("\"%X\"", responseHash) {
-			response.WriteHeader(http.StatusNotModified)
-			return
-		}
+	if result := notModified(request, etag, ocspResponse.ProducedAt); result != "" {
+		rs.cacheResults.With(prometheus.Labels{"source": source, "result": result}).Inc()
+		response.WriteHeader(http.StatusNotModified)
+		return
 	}
+	rs.cacheResults.With(prometheus.Labels{"source": source, "result": "full"}).Inc()
 	response.WriteHeader(http.StatusOK)
 	response.Write(ocspResponse.Raw)
 	rs.responseAges.Observe(rs.clk.Now().Sub(ocspResponse.ThisUpdate).Seconds())
 	rs.responseTypes.With(prometheus.Labels{"type": responseTypeToString[ocsp.Success]}).Inc()
 }
+
+// computeMaxAge returns the number of seconds for which the response may be
+// cached: until its nextUpdate, but no longer than the configured maxAge.
+func (rs Responder) computeMaxAge(resp *Response) int {
+	now := rs.clk.Now()
+	if !now.Before(resp.NextUpdate) {
+		// TODO(#530): we want max-age=0 but this is technically an authorized OCSP response
+		//             (despite being stale) and 5019 forbids attaching no-cache
+		return 0
+	}
+	maxAge := resp.NextUpdate.Sub(now)
+	if rs.maxAge > 0 && rs.maxAge < maxAge {
+		maxAge = rs.maxAge
+	}
+	return int(maxAge / time.Second)
+}
+
+// notModified evaluates the request's conditional headers, per RFC 7232
+// Section 6, against the response's ETag and producedAt. It returns the name
+// of the validator which matched if the client's cached copy is still
+// current, or the empty string if the full response should be sent.
+func notModified(request *http.Request, etag string, producedAt time.Time) string {
+	if inm := request.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return "not_modified_etag"
+			}
+		}
+		// If-Modified-Since is ignored when If-None-Match is present.
+		return ""
+	}
+	if ims := request.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		if err == nil && !producedAt.Truncate(time.Second).After(since) {
+			return "not_modified_date"
+		}
+	}
+	return ""
+}
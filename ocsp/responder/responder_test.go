@@ -67,7 +67,7 @@ func (ts testSource) Response(_ context.Context, r *ocsp.Request) (*Response, er
 	if err != nil {
 		return nil, err
 	}
-	return &Response{resp, respBytes}, nil
+	return &Response{Response: resp, Raw: respBytes}, nil
 }
 
 type testCase struct {
@@ -101,6 +101,12 @@ func TestOCSP(t *testing.T) {
 			},
 			[]string{"type"},
 		),
+		cacheResults: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspCacheResults-test",
+			},
+			[]string{"source", "result"},
+		),
 		responseAges: prometheus.NewHistogram(
 			prometheus.HistogramOpts{
 				Name:    "ocspAges-test",
@@ -147,6 +153,12 @@ func TestRequestTooBig(t *testing.T) {
 			},
 			[]string{"type"},
 		),
+		cacheResults: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspCacheResults-test",
+			},
+			[]string{"source", "result"},
+		),
 		responseAges: prometheus.NewHistogram(
 			prometheus.HistogramOpts{
 				Name:    "ocspAges-test",
@@ -183,6 +195,12 @@ func TestCacheHeaders(t *testing.T) {
 			},
 			[]string{"type"},
 		),
+		cacheResults: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspCacheResults-test",
+			},
+			[]string{"source", "result"},
+		),
 		responseAges: prometheus.NewHistogram(
 			prometheus.HistogramOpts{
 				Name:    "ocspAges-test",
@@ -207,8 +225,8 @@ func TestCacheHeaders(t *testing.T) {
 		header string
 		value  string
 	}{
-		{"Last-Modified", "Tue, 20 Oct 2015 00:00:00 UTC"},
-		{"Expires", "Sun, 20 Oct 2030 00:00:00 UTC"},
+		{"Last-Modified", "Wed, 21 Oct 2015 20:55:00 GMT"},
+		{"Expires", "Sun, 20 Oct 2030 00:00:00 GMT"},
 		{"Cache-Control", "max-age=471398400, public, no-transform, must-revalidate"},
 		{"Etag", "\"8169FB0843B081A76E9F6F13FD70C8411597BEACF8B182136FFDD19FBD26140A\""},
 	}
@@ -241,6 +259,99 @@ func TestCacheHeaders(t *testing.T) {
 	if rw.Code != http.StatusNotModified {
 		t.Fatalf("Got wrong status code: expected %d, got %d", http.StatusNotModified, rw.Code)
 	}
+	test.AssertMetricWithLabelsEquals(
+		t, responder.cacheResults, prometheus.Labels{"source": "memory", "result": "not_modified_etag"}, 1)
+	test.AssertMetricWithLabelsEquals(
+		t, responder.cacheResults, prometheus.Labels{"source": "memory", "result": "full"}, 1)
+}
+
+func TestConditionalRequests(t *testing.T) {
+	source, err := NewMemorySourceFromFile(responseFile, blog.NewMock())
+	test.AssertNotError(t, err, "constructing source")
+
+	fc := clock.NewFake()
+	fc.Set(time.Date(2015, 11, 12, 0, 0, 0, 0, time.UTC))
+	responder := Responder{
+		Source: source,
+		maxAge: time.Hour,
+		responseTypes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspResponses-test",
+			},
+			[]string{"type"},
+		),
+		cacheResults: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ocspCacheResults-test",
+			},
+			[]string{"source", "result"},
+		),
+		responseAges: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:    "ocspAges-test",
+				Buckets: []float64{43200},
+			},
+		),
+		clk: fc,
+		log: blog.NewMock(),
+	}
+
+	etag := "\"8169FB0843B081A76E9F6F13FD70C8411597BEACF8B182136FFDD19FBD26140A\""
+	testCases := []struct {
+		name     string
+		header   string
+		value    string
+		expected int
+	}{
+		{"no validators", "", "", http.StatusOK},
+		{"weak etag", "If-None-Match", "W/" + etag, http.StatusNotModified},
+		{"etag in list", "If-None-Match", "\"abcd\", " + etag, http.StatusNotModified},
+		{"wildcard etag", "If-None-Match", "*", http.StatusNotModified},
+		{"wrong etag", "If-None-Match", "\"abcd\"", http.StatusOK},
+		{"modified since", "If-Modified-Since", "Wed, 21 Oct 2015 20:54:59 GMT", http.StatusOK},
+		{"not modified since", "If-Modified-Since", "Wed, 21 Oct 2015 20:55:00 GMT", http.StatusNotModified},
+		{"unparseable date", "If-Modified-Since", "yesterday", http.StatusOK},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := http.Header{}
+			if tc.header != "" {
+				headers.Add(tc.header, tc.value)
+			}
+			rw := httptest.NewRecorder()
+			responder.ServeHTTP(rw, &http.Request{
+				Method: "GET",
+				URL: &url.URL{
+					Path: "MEMwQTA/MD0wOzAJBgUrDgMCGgUABBSwLsMRhyg1dJUwnXWk++D57lvgagQU6aQ/7p6l5vLV13lgPJOmLiSOl6oCAhJN",
+				},
+				Header: headers,
+			})
+			test.AssertEquals(t, rw.Code, tc.expected)
+			// The configured maxAge is shorter than the time until nextUpdate.
+			test.AssertEquals(t, rw.Header().Get("Cache-Control"), "max-age=3600, public, no-transform, must-revalidate")
+		})
+	}
+
+	// If-Modified-Since is ignored when If-None-Match is present.
+	headers := http.Header{}
+	headers.Add("If-None-Match", "\"abcd\"")
+	headers.Add("If-Modified-Since", "Wed, 21 Oct 2015 20:55:00 GMT")
+	rw := httptest.NewRecorder()
+	responder.ServeHTTP(rw, &http.Request{
+		Method: "GET",
+		URL: &url.URL{
+			Path: "MEMwQTA/MD0wOzAJBgUrDgMCGgUABBSwLsMRhyg1dJUwnXWk++D57lvgagQU6aQ/7p6l5vLV13lgPJOmLiSOl6oCAhJN",
+		},
+		Header: headers,
+	})
+	test.AssertEquals(t, rw.Code, http.StatusOK)
+
+	test.AssertMetricWithLabelsEquals(
+		t, responder.cacheResults, prometheus.Labels{"source": "memory", "result": "not_modified_etag"}, 3)
+	test.AssertMetricWithLabelsEquals(
+		t, responder.cacheResults, prometheus.Labels{"source": "memory", "result": "not_modified_date"}, 1)
+	test.AssertMetricWithLabelsEquals(
+		t, responder.cacheResults, prometheus.Labels{"source": "memory", "result": "full"}, 5)
 }
 
 func TestNewSourceFromFile(t *testing.T) {
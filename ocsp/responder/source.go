@@ -7,10 +7,12 @@ import (
 )
 
 // Response is a wrapper around the standard library's *ocsp.Response, but it
-// also carries with it the raw bytes of the encoded response.
+// also carries with it the raw bytes of the encoded response, and the name of
+// the Source it was found in, for metrics.
 type Response struct {
 	*ocsp.Response
-	Raw []byte
+	Raw    []byte
+	Source string
 }
 
 // Source represents the logical source of OCSP responses, i.e.,
@@ -142,7 +142,7 @@ func New(
 	}
 
 	var queryBody strings.Builder
-	queryBody.WriteString("WHERE ocspLastUpdated < ? AND NOT isExpired ")
+	queryBody.WriteString("WHERE ocspLastUpdated < ? AND NOT isExpired AND NOT isShortLived ")
 	if len(serialSuffixes) > 0 {
 		fmt.Fprintf(&queryBody, "AND RIGHT(serial, 1) IN ( %s ) ",
 			getQuestionsForShardList(len(serialSuffixes)),
@@ -313,29 +313,19 @@ func (updater *OCSPUpdater) findStaleOCSPResponses(ctx context.Context, oldestLa
 	return staleStatusesOut
 }
 
-// generateResponse signs an new OCSP response for a given certStatus row.
-// Takes its argument by value to force a copy, then returns a reference to that copy.
-func (updater *OCSPUpdater) generateResponse(ctx context.Context, status sa.CertStatusMetadata) (*sa.CertStatusMetadata, error) {
+// ocspRequest returns the request to sign a new OCSP response for a given
+// certStatus row.
+func ocspRequest(status sa.CertStatusMetadata) (*capb.GenerateOCSPRequest, error) {
 	if status.IssuerID == 0 {
 		return nil, errors.New("cert status has 0 IssuerID")
 	}
-	ocspReq := capb.GenerateOCSPRequest{
+	return &capb.GenerateOCSPRequest{
 		Serial:    status.Serial,
 		IssuerID:  status.IssuerID,
 		Status:    string(status.Status),
 		Reason:    int32(status.RevokedReason),
 		RevokedAt: status.RevokedDate.UnixNano(),
-	}
-
-	ocspResponse, err := updater.ogc.GenerateOCSP(ctx, &ocspReq)
-	if err != nil {
-		return nil, err
-	}
-
-	status.OCSPLastUpdated = updater.clk.Now()
-	status.OCSPResponse = ocspResponse.Response
-
-	return &status, nil
+	}, nil
 }
 
 // storeResponse stores a given CertificateStatus in the database.
@@ -436,13 +426,144 @@ func (updater *OCSPUpdater) processExpired(ctx context.Context, staleStatusesIn
 	return staleStatusesOut
 }
 
+// maxOCSPStreamAttempts is the number of GenerateOCSPStream calls made for a
+// single batch of stale statuses. When a stream breaks partway through a batch,
+// the statuses which weren't answered are sent again on a new stream, up to
+// this many times. Statuses still unanswered after that keep their stale
+// responses, and are picked up again on the next tick.
+const maxOCSPStreamAttempts = 3
+
+// pendingStatus is a certStatus row whose OCSP request has been sent to the
+// CA, along with the time at which it was sent.
+type pendingStatus struct {
+	status sa.CertStatusMetadata
+	start  time.Time
+}
+
+// generateOCSPStream obtains a new OCSP response over a single
+// GenerateOCSPStream call for each of the statuses in retry, followed by each
+// status received from staleStatusesIn, and passes each status with its new
+// response to handle. Up to updater.parallelGenerateOCSPRequests requests are
+// outstanding at the CA at a time. If the stream breaks, it stops reading from
+// staleStatusesIn and returns the error along with the statuses which weren't
+// answered, in order, so that they can be sent again.
+func (updater *OCSPUpdater) generateOCSPStream(ctx context.Context, retry []sa.CertStatusMetadata, staleStatusesIn <-chan sa.CertStatusMetadata, handle func(pendingStatus)) ([]sa.CertStatusMetadata, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := updater.ogc.GenerateOCSPStream(ctx)
+	if err != nil {
+		return retry, err
+	}
+
+	// The sender passes each status it has sent on to pending, so that it can
+	// be matched up with the CA's response. Because pending is buffered, its
+	// capacity bounds the number of requests outstanding at the CA. The
+	// receiver closes stop if the stream breaks. Once pending is closed,
+	// unsent holds the statuses the sender took but didn't send.
+	pending := make(chan pendingStatus, updater.parallelGenerateOCSPRequests)
+	stop := make(chan struct{})
+	var unsent []sa.CertStatusMetadata
+	var sendErr error
+	go func() {
+		defer close(pending)
+		send := func(status sa.CertStatusMetadata) bool {
+			start := updater.clk.Now()
+			req, err := ocspRequest(status)
+			if err != nil {
+				updater.log.AuditErrf("Failed to generate OCSP response: %s", err)
+				updater.generatedCounter.WithLabelValues("failed").Inc()
+				return true
+			}
+			err = stream.Send(req)
+			if err != nil {
+				sendErr = err
+				unsent = append(unsent, status)
+				return false
+			}
+			select {
+			case pending <- pendingStatus{status, start}:
+				return true
+			case <-stop:
+				unsent = append(unsent, status)
+				return false
+			}
+		}
+		for i, status := range retry {
+			if !send(status) {
+				unsent = append(unsent, retry[i+1:]...)
+				return
+			}
+		}
+		for {
+			select {
+			case <-stop:
+				return
+			case status, ok := <-staleStatusesIn:
+				if !ok {
+					_ = stream.CloseSend()
+					return
+				}
+				if !send(status) {
+					return
+				}
+			}
+		}
+	}()
+
+	// The CA sends responses in the same order as the requests, so each
+	// response belongs to the oldest status still pending.
+	var unanswered []sa.CertStatusMetadata
+	var recvErr error
+	for p := range pending {
+		if recvErr != nil {
+			unanswered = append(unanswered, p.status)
+			continue
+		}
+		res, err := stream.Recv()
+		if err != nil {
+			// The stream is broken, so none of the outstanding requests
+			// will be answered on it.
+			recvErr = err
+			close(stop)
+			cancel()
+			unanswered = append(unanswered, p.status)
+			continue
+		}
+		if res.Error != "" {
+			err = errors.New(res.Error)
+		} else if res.Serial != p.status.Serial {
+			err = fmt.Errorf("received response for serial %s, expected %s", res.Serial, p.status.Serial)
+		}
+		if err != nil {
+			updater.log.AuditErrf("Failed to generate OCSP response: %s", err)
+			updater.generatedCounter.WithLabelValues("failed").Inc()
+			continue
+		}
+		updater.generatedCounter.WithLabelValues("success").Inc()
+
+		p.status.OCSPLastUpdated = updater.clk.Now()
+		p.status.OCSPResponse = res.Response
+		handle(p)
+	}
+	unanswered = append(unanswered, unsent...)
+	if recvErr != nil {
+		return unanswered, recvErr
+	}
+	if sendErr != nil {
+		return unanswered, sendErr
+	}
+	return nil, nil
+}
+
 // generateOCSPResponses is the final stage of a pipeline. It takes a
-// channel of `core.CertificateStatus` and sends a goroutine for each to
-// obtain a new OCSP response and update the status in the database.
+// channel of `core.CertificateStatus`, obtains a new OCSP response for each
+// over a GenerateOCSPStream call, and sends a goroutine for each response to
+// update the status in the database. If the stream breaks, the statuses it
+// didn't answer are sent again on a new one.
 func (updater *OCSPUpdater) generateOCSPResponses(ctx context.Context, staleStatusesIn <-chan sa.CertStatusMetadata) {
 	// Use the semaphore pattern from
 	// https://github.com/golang/go/wiki/BoundingResourceUse to send a number of
-	// GenerateOCSP / storeResponse requests in parallel, while limiting the total number of
+	// storeResponse requests in parallel, while limiting the total number of
 	// outstanding requests. The number of outstanding requests equals the
 	// capacity of the channel.
 	sem := make(chan int, updater.parallelGenerateOCSPRequests)
@@ -454,20 +575,11 @@ func (updater *OCSPUpdater) generateOCSPResponses(ctx context.Context, staleStat
 		updater.genStoreHistogram.Observe(time.Since(start).Seconds())
 	}
 
-	// Work runs as a goroutine per ocsp response to obtain a new ocsp
-	// response and store it in the database.
-	work := func(status sa.CertStatusMetadata) {
-		defer done(updater.clk.Now())
-
-		meta, err := updater.generateResponse(ctx, status)
-		if err != nil {
-			updater.log.AuditErrf("Failed to generate OCSP response: %s", err)
-			updater.generatedCounter.WithLabelValues("failed").Inc()
-			return
-		}
-		updater.generatedCounter.WithLabelValues("success").Inc()
+	// Work runs as a goroutine per ocsp response to store it in the database.
+	work := func(p pendingStatus) {
+		defer done(p.start)
 
-		err = updater.storeResponse(ctx, meta)
+		err := updater.storeResponse(ctx, &p.status)
 		if err != nil {
 			updater.log.AuditErrf("Failed to store OCSP response: %s", err)
 			updater.storedCounter.WithLabelValues("failed").Inc()
@@ -475,12 +587,30 @@ func (updater *OCSPUpdater) generateOCSPResponses(ctx context.Context, staleStat
 		}
 		updater.storedCounter.WithLabelValues("success").Inc()
 	}
-
-	// Consume the stale statuses channel and send off a sign/store request
-	// for each stale response.
-	for status := range staleStatusesIn {
+	handle := func(p pendingStatus) {
 		wait()
-		go work(status)
+		go work(p)
+	}
+
+	var retry []sa.CertStatusMetadata
+	for attempt := 1; ; attempt++ {
+		unanswered, err := updater.generateOCSPStream(ctx, retry, staleStatusesIn, handle)
+		if err == nil {
+			break
+		}
+		updater.log.AuditErrf("OCSP generation stream failed with %d requests unanswered: %s", len(unanswered), err)
+		if attempt >= maxOCSPStreamAttempts || ctx.Err() != nil {
+			// Drain the input so that earlier stages of the pipeline can
+			// finish. These statuses are picked up again on the next tick.
+			for range unanswered {
+				updater.generatedCounter.WithLabelValues("failed").Inc()
+			}
+			for range staleStatusesIn {
+				updater.generatedCounter.WithLabelValues("failed").Inc()
+			}
+			break
+		}
+		retry = unanswered
 	}
 
 	// Block until the sem channel reaches its full capacity again,
@@ -7,7 +7,10 @@ import (
 	"crypto/x509"
 	"database/sql"
 	"errors"
+	"fmt"
+	"io"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
 	"testing"
@@ -42,6 +45,64 @@ func (ca *mockOCSP) GenerateOCSP(_ context.Context, req *capb.GenerateOCSPReques
 	return &capb.OCSPResponse{Response: []byte{1, 2, 3}}, nil
 }
 
+func (ca *mockOCSP) GenerateOCSPStream(_ context.Context, _ ...grpc.CallOption) (capb.OCSPGenerator_GenerateOCSPStreamClient, error) {
+	return newMockOCSPStream(ca.GenerateOCSP), nil
+}
+
+// mockOCSPStream is the client side of a GenerateOCSPStream call which passes
+// each request to a GenerateOCSP implementation in its own goroutine, and
+// returns the results in request order, like the CA does.
+type mockOCSPStream struct {
+	grpc.ClientStream
+	generate func(context.Context, *capb.GenerateOCSPRequest, ...grpc.CallOption) (*capb.OCSPResponse, error)
+	results  chan chan *capb.OCSPStreamResponse
+}
+
+func newMockOCSPStream(generate func(context.Context, *capb.GenerateOCSPRequest, ...grpc.CallOption) (*capb.OCSPResponse, error)) *mockOCSPStream {
+	return &mockOCSPStream{generate: generate, results: make(chan chan *capb.OCSPStreamResponse, 100)}
+}
+
+func (m *mockOCSPStream) Send(req *capb.GenerateOCSPRequest) error {
+	result := make(chan *capb.OCSPStreamResponse, 1)
+	m.results <- result
+	go func() {
+		res := &capb.OCSPStreamResponse{Serial: req.Serial}
+		resp, err := m.generate(context.Background(), req)
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Response = resp.Response
+		}
+		result <- res
+	}()
+	return nil
+}
+
+func (m *mockOCSPStream) CloseSend() error {
+	close(m.results)
+	return nil
+}
+
+func (m *mockOCSPStream) Recv() (*capb.OCSPStreamResponse, error) {
+	result, ok := <-m.results
+	if !ok {
+		return nil, io.EOF
+	}
+	return <-result, nil
+}
+
+// generateAndStore obtains and stores a new OCSP response for a single status
+// and asserts that it succeeded.
+func generateAndStore(t *testing.T, updater *OCSPUpdater, status sa.CertStatusMetadata) {
+	t.Helper()
+	statuses := make(chan sa.CertStatusMetadata, 1)
+	statuses <- status
+	close(statuses)
+	updater.generateOCSPResponses(ctx, statuses)
+	test.AssertMetricWithLabelsEquals(t, updater.generatedCounter, prometheus.Labels{"result": "failed"}, 0)
+	test.AssertMetricWithLabelsEquals(t, updater.storedCounter, prometheus.Labels{"result": "failed"}, 0)
+}
+
 type noopROCSP struct {
 }
 
@@ -158,10 +219,7 @@ func TestGenerateAndStoreOCSPResponse(t *testing.T) {
 	test.AssertEquals(t, len(statuses), 1)
 	status := <-statuses
 
-	meta, err := updater.generateResponse(ctx, status)
-	test.AssertNotError(t, err, "Couldn't generate OCSP response")
-	err = updater.storeResponse(context.Background(), meta)
-	test.AssertNotError(t, err, "Couldn't store certificate status")
+	generateAndStore(t, updater, status)
 }
 
 type rocspStorage struct {
@@ -356,10 +414,7 @@ func TestFindStaleOCSPResponses(t *testing.T) {
 
 	// Generate and store an updated response, which will update the
 	// ocspLastUpdate field for this cert.
-	meta, err := updater.generateResponse(ctx, status)
-	test.AssertNotError(t, err, "Couldn't generate OCSP response")
-	err = updater.storeResponse(context.Background(), meta)
-	test.AssertNotError(t, err, "Couldn't store OCSP response")
+	generateAndStore(t, updater, status)
 
 	// We should have 0 stale responses now.
 	statuses = findStaleOCSPResponsesBuffered(ctx, updater, earliest, 10)
@@ -580,11 +635,10 @@ func TestGenerateOCSPResponsePrecert(t *testing.T) {
 	cert := <-certs
 	test.AssertEquals(t, cert.Serial, serial)
 
-	// Directly call generateResponse again with the same result. It should not
-	// error and should instead update the precertificate's OCSP status even
-	// though no certificate row exists.
-	_, err = updater.generateResponse(ctx, cert)
-	test.AssertNotError(t, err, "generateResponse for precert errored")
+	// Generate a response for it again. It should not error and should
+	// instead update the precertificate's OCSP status even though no
+	// certificate row exists.
+	generateAndStore(t, updater, cert)
 }
 
 type mockOCSPRecordIssuer struct {
@@ -596,6 +650,10 @@ func (ca *mockOCSPRecordIssuer) GenerateOCSP(_ context.Context, req *capb.Genera
 	return &capb.OCSPResponse{Response: []byte{1, 2, 3}}, nil
 }
 
+func (ca *mockOCSPRecordIssuer) GenerateOCSPStream(_ context.Context, _ ...grpc.CallOption) (capb.OCSPGenerator_GenerateOCSPStreamClient, error) {
+	return newMockOCSPStream(ca.GenerateOCSP), nil
+}
+
 func TestIssuerInfo(t *testing.T) {
 	updater, sa, _, fc, cleanUp := setup(t)
 	defer cleanUp()
@@ -631,9 +689,8 @@ func TestIssuerInfo(t *testing.T) {
 	status := <-statuses
 	test.AssertEquals(t, status.IssuerID, id)
 
-	_, err = updater.generateResponse(context.Background(), status)
-	test.AssertNotError(t, err, "generateResponse failed")
-	test.Assert(t, m.gotIssuer, "generateResponse didn't send issuer information and serial")
+	generateAndStore(t, updater, status)
+	test.Assert(t, m.gotIssuer, "generateOCSPResponses didn't send issuer information and serial")
 }
 
 type brokenDB struct{}
@@ -751,3 +808,98 @@ func TestGetQuestionsForShardList(t *testing.T) {
 	test.AssertEquals(t, getQuestionsForShardList(1), "?")
 	test.AssertEquals(t, getQuestionsForShardList(16), "?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?")
 }
+
+// mockOCSPBreaksStream is a mock OCSP generator whose first stream breaks
+// after answering a number of requests.
+type mockOCSPBreaksStream struct {
+	mockOCSP
+	answer  int
+	streams int
+}
+
+func (ca *mockOCSPBreaksStream) GenerateOCSPStream(_ context.Context, _ ...grpc.CallOption) (capb.OCSPGenerator_GenerateOCSPStreamClient, error) {
+	ca.streams++
+	stream := newMockOCSPStream(ca.GenerateOCSP)
+	if ca.streams == 1 {
+		return &brokenOCSPStream{mockOCSPStream: stream, answer: ca.answer}, nil
+	}
+	return stream, nil
+}
+
+// brokenOCSPStream is a mockOCSPStream which fails after answering a number
+// of requests, as when the connection to the CA is lost.
+type brokenOCSPStream struct {
+	*mockOCSPStream
+	answer int
+}
+
+func (s *brokenOCSPStream) Recv() (*capb.OCSPStreamResponse, error) {
+	if s.answer == 0 {
+		return nil, errors.New("transport is closing")
+	}
+	s.answer--
+	return s.mockOCSPStream.Recv()
+}
+
+// recordingDB is a mock ocspDb which records the IDs of the certificate
+// statuses it is asked to update.
+type recordingDB struct {
+	sync.Mutex
+	ids []int64
+}
+
+func (rdb *recordingDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (rdb *recordingDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	rdb.Lock()
+	defer rdb.Unlock()
+	rdb.ids = append(rdb.ids, args[2].(int64))
+	return nil, nil
+}
+
+func TestGenerateOCSPResponsesBrokenStream(t *testing.T) {
+	rdb := &recordingDB{}
+	ca := &mockOCSPBreaksStream{answer: 2}
+	updater, err := New(
+		metrics.NoopRegisterer,
+		clock.NewFake(),
+		rdb,
+		rdb,
+		nil,
+		nil,
+		nil,
+		ca,
+		1,
+		time.Second,
+		time.Minute,
+		1.5,
+		0,
+		2,
+		0,
+		blog.NewMock(),
+	)
+	test.AssertNotError(t, err, "Failed to create updater")
+
+	statuses := make(chan sa.CertStatusMetadata, 5)
+	for i := int64(1); i <= 5; i++ {
+		statuses <- sa.CertStatusMetadata{
+			CertificateStatus: core.CertificateStatus{
+				ID:       i,
+				Serial:   fmt.Sprintf("%036d", i),
+				IssuerID: 1,
+			},
+		}
+	}
+	close(statuses)
+	updater.generateOCSPResponses(ctx, statuses)
+
+	// The statuses the first stream didn't answer are sent again on a second
+	// one, so every status is updated.
+	test.AssertEquals(t, ca.streams, 2)
+	test.AssertMetricWithLabelsEquals(t, updater.generatedCounter, prometheus.Labels{"result": "success"}, 5)
+	test.AssertMetricWithLabelsEquals(t, updater.generatedCounter, prometheus.Labels{"result": "failed"}, 0)
+	sort.Slice(rdb.ids, func(i, j int) bool { return rdb.ids[i] < rdb.ids[j] })
+	test.AssertDeepEquals(t, rdb.ids, []int64{1, 2, 3, 4, 5})
+}
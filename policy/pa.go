@@ -15,6 +15,7 @@ import (
 	"golang.org/x/net/idna"
 	"golang.org/x/text/unicode/norm"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/iana"
@@ -34,8 +35,15 @@ type AuthorityImpl struct {
 	blocklistMu            sync.RWMutex
 
 	enabledChallenges map[core.AcmeChallenge]bool
-	pseudoRNG         *rand.Rand
-	rngMu             sync.Mutex
+
+	// ipIssuanceEnabled, allowedIPRanges and allowReservedIPs hold the policy
+	// for IP address identifiers set by SetIPAddressPolicy.
+	ipIssuanceEnabled bool
+	allowedIPRanges   []*net.IPNet
+	allowReservedIPs  bool
+
+	pseudoRNG *rand.Rand
+	rngMu     sync.Mutex
 }
 
 // New constructs a Policy Authority.
@@ -51,6 +59,28 @@ func New(challengeTypes map[core.AcmeChallenge]bool) (*AuthorityImpl, error) {
 	return &pa, nil
 }
 
+// SetIPAddressPolicy enables issuance for IP address identifiers (RFC 8738).
+// Only addresses contained in one of allowedRanges, given in CIDR notation,
+// are accepted; an empty list accepts any address. Addresses in private or
+// otherwise reserved ranges are refused unless allowReserved is true. Until
+// this is called the PA refuses all IP address identifiers.
+func (pa *AuthorityImpl) SetIPAddressPolicy(allowedRanges []string, allowReserved bool) error {
+	var ranges []*net.IPNet
+	for _, r := range allowedRanges {
+		_, ipNet, err := net.ParseCIDR(r)
+		if err != nil {
+			return fmt.Errorf("parsing allowed IP range %q: %w", r, err)
+		}
+		ranges = append(ranges, ipNet)
+	}
+	pa.blocklistMu.Lock()
+	pa.ipIssuanceEnabled = true
+	pa.allowedIPRanges = ranges
+	pa.allowReservedIPs = allowReserved
+	pa.blocklistMu.Unlock()
+	return nil
+}
+
 // blockedNamesPolicy is a struct holding lists of blocked domain names. One for
 // exact blocks and one for blocks including all subdomains.
 type blockedNamesPolicy struct {
@@ -185,6 +215,9 @@ var (
 	errInvalidDNSCharacter  = berrors.MalformedError("Domain name contains an invalid character")
 	errNameTooLong          = berrors.MalformedError("Domain name is longer than 253 bytes")
 	errIPAddress            = berrors.MalformedError("The ACME server can not issue a certificate for an IP address")
+	errMalformedIP          = berrors.MalformedError("IP address is not in canonical textual form")
+	errIPForbidden          = berrors.RejectedIdentifierError("The ACME server refuses to issue a certificate for this IP address, because it is forbidden by policy")
+	errReservedIP           = berrors.RejectedIdentifierError("IP address is in a reserved address range")
 	errTooManyLabels        = berrors.MalformedError("Domain name has more than 10 labels (parts)")
 	errEmptyName            = berrors.MalformedError("Domain name is empty")
 	errNameEndsInDot        = berrors.MalformedError("Domain name ends in a dot")
@@ -346,7 +379,8 @@ func ValidEmail(address string) error {
 //
 // We place several criteria on identifiers we are willing to issue for:
 //
-//  * MUST self-identify as DNS identifiers
+//  * MUST self-identify as DNS identifiers (IP identifiers are checked
+//    separately by willingToIssueIP)
 //  * MUST contain only bytes in the DNS hostname character set
 //  * MUST NOT have more than maxLabels labels
 //  * MUST follow the DNS hostname syntax rules in RFC 1035 and RFC 2181
@@ -367,6 +401,9 @@ func (pa *AuthorityImpl) WillingToIssue(id identifier.ACMEIdentifier) error {
 	if id.Type == identifier.JWT {
 		return nil
 	}
+	if id.Type == identifier.IP {
+		return pa.willingToIssueIP(id.Value)
+	}
 	if id.Type != identifier.DNS {
 		return errInvalidIdentifier
 	}
@@ -386,6 +423,35 @@ func (pa *AuthorityImpl) WillingToIssue(id identifier.ACMEIdentifier) error {
 	return nil
 }
 
+// willingToIssueIP checks that an IP identifier value is an IPv4 or IPv6
+// address in its canonical textual form, that IP address issuance is enabled,
+// and that the address is permitted by the configured IP address policy.
+func (pa *AuthorityImpl) willingToIssueIP(value string) error {
+	ip := net.ParseIP(value)
+	if ip == nil || ip.String() != value {
+		return errMalformedIP
+	}
+
+	pa.blocklistMu.RLock()
+	defer pa.blocklistMu.RUnlock()
+
+	if !pa.ipIssuanceEnabled {
+		return errIPAddress
+	}
+	if !pa.allowReservedIPs && bdns.IsReservedIP(ip) {
+		return errReservedIP
+	}
+	if len(pa.allowedIPRanges) == 0 {
+		return nil
+	}
+	for _, ipNet := range pa.allowedIPRanges {
+		if ipNet.Contains(ip) {
+			return nil
+		}
+	}
+	return errIPForbidden
+}
+
 // WillingToIssueWildcards is an extension of WillingToIssue that accepts DNS
 // identifiers for well formed wildcard domains in addition to regular
 // identifiers.
@@ -394,7 +460,8 @@ func (pa *AuthorityImpl) WillingToIssue(id identifier.ACMEIdentifier) error {
 // returned. In addition to the regular WillingToIssue checks this function
 // also checks each wildcard identifier to enforce that:
 //
-// * The identifier is a DNS type identifier
+// * The identifier is a DNS type identifier (IP type identifiers are passed
+//   directly to WillingToIssue)
 // * There is at most one `*` wildcard character
 // * That the wildcard character is the leftmost label
 // * That the wildcard label is not immediately adjacent to a top level ICANN
@@ -453,6 +520,10 @@ func (pa *AuthorityImpl) WillingToIssueWildcards(idents []identifier.ACMEIdentif
 // willingToIssueWildcard vets a single identifier. It is used by
 // the plural WillingToIssueWildcards when evaluating a list of identifiers.
 func (pa *AuthorityImpl) willingToIssueWildcard(ident identifier.ACMEIdentifier) error {
+	// IP identifiers can never be wildcards
+	if ident.Type == identifier.IP {
+		return pa.WillingToIssue(ident)
+	}
 	// We're only willing to process DNS identifiers
 	if ident.Type != identifier.DNS {
 		return errInvalidIdentifier
@@ -550,26 +621,42 @@ func (pa *AuthorityImpl) checkHostLists(domain string) error {
 
 // ChallengesFor makes a decision of what challenges are acceptable for
 // the given identifier.
-func (pa *AuthorityImpl) ChallengesFor(identifier identifier.ACMEIdentifier) ([]core.Challenge, error) {
+func (pa *AuthorityImpl) ChallengesFor(ident identifier.ACMEIdentifier) ([]core.Challenge, error) {
 	challenges := []core.Challenge{}
 
 	token := core.NewToken()
-	if string(identifier.Type) == "jwt" {
+	if ident.Type == identifier.JWT {
 		if pa.ChallengeTypeEnabled(core.ChallengeTypeTrustedJWT) {
 			challenges = append(challenges, core.TrustedJWTChallenge01(token))
 		}
+	} else if ident.Type == identifier.IP {
+		// RFC 8738 only defines the HTTP-01 and TLS-ALPN-01 challenges for IP
+		// identifiers.
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeHTTP01) {
+			challenges = append(challenges, core.HTTPChallenge01(token))
+		}
+
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeTLSALPN01) {
+			challenges = append(challenges, core.TLSALPNChallenge01(token))
+		}
 		// If the identifier is for a DNS wildcard name we only
-		// provide a DNS-01 challenge as a matter of CA policy.
-	} else if strings.HasPrefix(identifier.Value, "*.") {
-		// We must have the DNS-01 challenge type enabled to create challenges for
-		// a wildcard identifier per LE policy.
-		if !pa.ChallengeTypeEnabled(core.ChallengeTypeDNS01) {
+		// provide DNS-based challenges as a matter of CA policy.
+	} else if strings.HasPrefix(ident.Value, "*.") {
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNS01) {
+			challenges = append(challenges, core.DNSChallenge01(token))
+		}
+
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNSAccount01) {
+			challenges = append(challenges, core.DNSAccountChallenge01(token))
+		}
+
+		// We must have a DNS-based challenge type enabled to create challenges
+		// for a wildcard identifier per LE policy.
+		if len(challenges) == 0 {
 			return nil, fmt.Errorf(
-				"Challenges requested for wildcard identifier but DNS-01 " +
-					"challenge type is not enabled")
+				"Challenges requested for wildcard identifier but neither DNS-01 " +
+					"nor DNS-ACCOUNT-01 challenge type is enabled")
 		}
-		// Only provide a DNS-01-Wildcard challenge
-		challenges = []core.Challenge{core.DNSChallenge01(token)}
 	} else {
 		// Otherwise we collect up challenges based on what is enabled.
 		if pa.ChallengeTypeEnabled(core.ChallengeTypeHTTP01) {
@@ -583,6 +670,10 @@ func (pa *AuthorityImpl) ChallengesFor(identifier identifier.ACMEIdentifier) ([]
 		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNS01) {
 			challenges = append(challenges, core.DNSChallenge01(token))
 		}
+
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNSAccount01) {
+			challenges = append(challenges, core.DNSAccountChallenge01(token))
+		}
 	}
 
 	// We shuffle the challenges to prevent ACME clients from relying on the
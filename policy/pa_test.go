@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -14,8 +15,9 @@ import (
 )
 
 var enabledChallenges = map[core.AcmeChallenge]bool{
-	core.ChallengeTypeHTTP01: true,
-	core.ChallengeTypeDNS01:  true,
+	core.ChallengeTypeHTTP01:       true,
+	core.ChallengeTypeDNS01:        true,
+	core.ChallengeTypeDNSAccount01: true,
 }
 
 func paImpl(t *testing.T) *AuthorityImpl {
@@ -162,7 +164,7 @@ func TestWillingToIssue(t *testing.T) {
 	test.AssertNotError(t, err, "Couldn't load rules")
 
 	// Test for invalid identifier type
-	ident := identifier.ACMEIdentifier{Type: "ip", Value: "example.com"}
+	ident := identifier.ACMEIdentifier{Type: "email", Value: "example.com"}
 	err = pa.WillingToIssue(ident)
 	if err != errInvalidIdentifier {
 		t.Error("Identifier was not correctly forbidden: ", ident)
@@ -401,17 +403,19 @@ func TestChallengesForWildcard(t *testing.T) {
 	}
 
 	// First try to get a challenge for the wildcard ident without the
-	// DNS-01 challenge type enabled. This should produce an error
+	// DNS-01 or DNS-ACCOUNT-01 challenge types enabled. This should produce an
+	// error
 	var enabledChallenges = map[core.AcmeChallenge]bool{
-		core.ChallengeTypeHTTP01: true,
-		core.ChallengeTypeDNS01:  false,
+		core.ChallengeTypeHTTP01:       true,
+		core.ChallengeTypeDNS01:        false,
+		core.ChallengeTypeDNSAccount01: false,
 	}
 	pa := mustConstructPA(t, enabledChallenges)
 	_, err := pa.ChallengesFor(wildcardIdent)
 	test.AssertError(t, err, "ChallengesFor did not error for a wildcard ident "+
-		"when DNS-01 was disabled")
+		"when DNS-01 and DNS-ACCOUNT-01 were disabled")
 	test.AssertEquals(t, err.Error(), "Challenges requested for wildcard "+
-		"identifier but DNS-01 challenge type is not enabled")
+		"identifier but neither DNS-01 nor DNS-ACCOUNT-01 challenge type is enabled")
 
 	// Try again with DNS-01 enabled. It should not error and
 	// should return only one DNS-01 type challenge
@@ -422,6 +426,91 @@ func TestChallengesForWildcard(t *testing.T) {
 		"unexpectedly")
 	test.AssertEquals(t, len(challenges), 1)
 	test.AssertEquals(t, challenges[0].Type, core.ChallengeTypeDNS01)
+
+	// With only DNS-ACCOUNT-01 enabled it should return only one DNS-ACCOUNT-01
+	// type challenge
+	enabledChallenges[core.ChallengeTypeDNS01] = false
+	enabledChallenges[core.ChallengeTypeDNSAccount01] = true
+	pa = mustConstructPA(t, enabledChallenges)
+	challenges, err = pa.ChallengesFor(wildcardIdent)
+	test.AssertNotError(t, err, "ChallengesFor errored for a wildcard ident "+
+		"unexpectedly")
+	test.AssertEquals(t, len(challenges), 1)
+	test.AssertEquals(t, challenges[0].Type, core.ChallengeTypeDNSAccount01)
+
+	// With both enabled it should return both DNS-based challenges and nothing
+	// else
+	enabledChallenges[core.ChallengeTypeDNS01] = true
+	pa = mustConstructPA(t, enabledChallenges)
+	challenges, err = pa.ChallengesFor(wildcardIdent)
+	test.AssertNotError(t, err, "ChallengesFor errored for a wildcard ident "+
+		"unexpectedly")
+	test.AssertEquals(t, len(challenges), 2)
+	for _, chall := range challenges {
+		test.Assert(t, chall.Type == core.ChallengeTypeDNS01 || chall.Type == core.ChallengeTypeDNSAccount01,
+			fmt.Sprintf("unexpected %s challenge for a wildcard ident", chall.Type))
+	}
+}
+
+func TestWillingToIssueIP(t *testing.T) {
+	pa := paImpl(t)
+
+	// Without an IP address policy every IP identifier is refused.
+	err := pa.WillingToIssue(identifier.ACMEIdentifier{Type: identifier.IP, Value: "93.184.216.34"})
+	test.AssertErrorIs(t, err, errIPAddress)
+
+	err = pa.SetIPAddressPolicy([]string{"93.184.216.0/24", "2606:2800:220::/48"}, false)
+	test.AssertNotError(t, err, "SetIPAddressPolicy failed")
+
+	testCases := []struct {
+		value string
+		err   error
+	}{
+		{"93.184.216.34", nil},
+		{"2606:2800:220:1:248:1893:25c8:1946", nil},
+		{"1.1.1.1", errIPForbidden},
+		{"10.0.0.1", errReservedIP},
+		{"::1", errReservedIP},
+		{"093.184.216.34", errMalformedIP},
+		{"2606:2800:0220:1:248:1893:25c8:1946", errMalformedIP},
+		{"example.com", errMalformedIP},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			err := pa.WillingToIssueWildcards([]identifier.ACMEIdentifier{{Type: identifier.IP, Value: tc.value}})
+			if tc.err == nil {
+				test.AssertNotError(t, err, "WillingToIssueWildcards failed")
+				return
+			}
+			test.AssertError(t, err, "WillingToIssueWildcards should have failed")
+			test.AssertContains(t, err.Error(), tc.err.Error())
+		})
+	}
+
+	// Reserved addresses are accepted when explicitly allowed.
+	err = pa.SetIPAddressPolicy(nil, true)
+	test.AssertNotError(t, err, "SetIPAddressPolicy failed")
+	err = pa.WillingToIssue(identifier.ACMEIdentifier{Type: identifier.IP, Value: "10.0.0.1"})
+	test.AssertNotError(t, err, "WillingToIssue failed for reserved IP when allowed")
+
+	err = pa.SetIPAddressPolicy([]string{"not a cidr"}, false)
+	test.AssertError(t, err, "SetIPAddressPolicy accepted a malformed range")
+}
+
+func TestChallengesForIP(t *testing.T) {
+	pa, err := New(map[core.AcmeChallenge]bool{
+		core.ChallengeTypeHTTP01:    true,
+		core.ChallengeTypeDNS01:     true,
+		core.ChallengeTypeTLSALPN01: true,
+	})
+	test.AssertNotError(t, err, "Couldn't create policy implementation")
+
+	challenges, err := pa.ChallengesFor(identifier.ACMEIdentifier{Type: identifier.IP, Value: "93.184.216.34"})
+	test.AssertNotError(t, err, "ChallengesFor failed")
+	test.AssertEquals(t, len(challenges), 2)
+	for _, chall := range challenges {
+		test.Assert(t, chall.Type != core.ChallengeTypeDNS01, "DNS-01 offered for an IP identifier")
+	}
 }
 
 // TestMalformedExactBlocklist tests that loading a YAML policy file with an
@@ -87,6 +87,14 @@ type RegistrationAuthorityImpl struct {
 	maxNames                     int
 	reuseValidAuthz              bool
 	orderLifetime                time.Duration
+	// The CA's certificate profiles, which bound the certificate validity
+	// period a client may request with the notBefore and notAfter fields of a
+	// new order. Nil means clients can't request a validity period at all.
+	profiles *issuance.ProfilesConfig
+	// The certificate profiles clients may request for a new order, keyed by
+	// name, each with the set of account IDs permitted to request it. A nil set
+	// permits all accounts.
+	certProfiles map[string]map[int64]bool
 
 	issuersByNameID map[issuance.IssuerNameID]*issuance.Certificate
 	issuersByID     map[issuance.IssuerID]*issuance.Certificate
@@ -119,6 +127,8 @@ func NewRegistrationAuthorityImpl(
 	pubc pubpb.PublisherClient,
 	caaClient caaChecker,
 	orderLifetime time.Duration,
+	profiles *issuance.ProfilesConfig,
+	certProfiles map[string][]int64,
 	ctp *ctpolicy.CTPolicy,
 	purger akamaipb.AkamaiPurgerClient,
 	issuers []*issuance.Certificate,
@@ -195,6 +205,18 @@ func NewRegistrationAuthorityImpl(
 		issuersByID[issuer.ID()] = issuer
 	}
 
+	certProfileAllowLists := make(map[string]map[int64]bool, len(certProfiles))
+	for name, regIDs := range certProfiles {
+		var allowList map[int64]bool
+		if len(regIDs) > 0 {
+			allowList = make(map[int64]bool, len(regIDs))
+			for _, regID := range regIDs {
+				allowList[regID] = true
+			}
+		}
+		certProfileAllowLists[name] = allowList
+	}
+
 	ra := &RegistrationAuthorityImpl{
 		clk:                          clk,
 		log:                          logger,
@@ -208,6 +230,8 @@ func NewRegistrationAuthorityImpl(
 		publisher:                    pubc,
 		caa:                          caaClient,
 		orderLifetime:                orderLifetime,
+		profiles:                     profiles,
+		certProfiles:                 certProfileAllowLists,
 		ctpolicy:                     ctp,
 		ctpolicyResults:              ctpolicyResults,
 		purger:                       purger,
@@ -662,6 +686,18 @@ func (ra *RegistrationAuthorityImpl) MatchesCSR(parsedCertificate *x509.Certific
 	return nil
 }
 
+// csrIdentifierValues returns the dNSNames and the textual form of the
+// iPAddresses requested by the CSR, deduplicated, lowercased and sorted, so
+// that they can be compared against the names of an order.
+func csrIdentifierValues(csr *x509.CertificateRequest) []string {
+	names := make([]string, 0, len(csr.DNSNames)+len(csr.IPAddresses))
+	names = append(names, csr.DNSNames...)
+	for _, ip := range csr.IPAddresses {
+		names = append(names, ip.String())
+	}
+	return core.UniqueLowerNames(names)
+}
+
 // checkOrderAuthorizations verifies that a provided set of names associated
 // with a specific order and account has all of the required valid, unexpired
 // authorizations to proceed with issuance. It returns the authorizations that
@@ -730,7 +766,8 @@ func (ra *RegistrationAuthorityImpl) checkAuthorizationsCAA(
 	// that was more than 7 hours ago, to be on the safe side. We can
 	// check to see if the authorized challenge `AttemptedAt`
 	// (`Validated`) value from the database is before our caaRecheckTime.
-	// Set the recheck time to 7 hours ago.
+	// Set the recheck time to 7 hours ago. The VA's CAA cache relies on
+	// this window to bound how old a cached lookup may be.
 	caaRecheckAfter := now.Add(-7 * time.Hour)
 
 	// Set a CAA recheck time based on the assumption of a 30 day authz
@@ -748,6 +785,9 @@ func (ra *RegistrationAuthorityImpl) checkAuthorizationsCAA(
 			return berrors.InternalServerError("found an authorization with a nil Expires field: id %s", authz.ID)
 		} else if authz.Expires.Before(now) {
 			badNames = append(badNames, name)
+		} else if authz.Identifier.Type == identifier.IP {
+			// CAA does not apply to IP address identifiers (RFC 8738 Section 7)
+			continue
 		} else if staleCAA, err := validatedBefore(authz, caaRecheckAfter); err != nil {
 			return berrors.InternalServerError(err.Error())
 		} else if staleCAA {
@@ -950,7 +990,7 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 
 	// Dedupe, lowercase and sort both the names from the CSR and the names in the
 	// order.
-	csrNames := core.UniqueLowerNames(csrOb.DNSNames)
+	csrNames := csrIdentifierValues(csrOb)
 	orderNames := core.UniqueLowerNames(order.Names)
 
 	// Immediately reject the request if the number of names differ
@@ -992,7 +1032,7 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 	// field. This v2 flow allows the CA to select the issuer based on the CSR's
 	// PublicKeyAlgorithm.
 	cert, err := ra.issueCertificate(ctx, issueReq, accountID(order.RegistrationID),
-		orderID(order.Id), issuance.IssuerNameID(0), order.TypeIdentifier)
+		orderID(order.Id), issuance.IssuerNameID(0), order.TypeIdentifier, order.NotBefore, order.NotAfter, order.CertProfile)
 	if err != nil {
 		// Fail the order. The problem is computed using
 		// `web.ProblemDetailsForError`, the same function the WFE uses to convert
@@ -1051,7 +1091,10 @@ func (ra *RegistrationAuthorityImpl) issueCertificate(
 	acctID accountID,
 	oID orderID,
 	issuerNameID issuance.IssuerNameID,
-	typeIdentifier string) (core.Certificate, error) {
+	typeIdentifier string,
+	notBefore int64,
+	notAfter int64,
+	certProfile string) (core.Certificate, error) {
 	// Construct the log event
 	logEvent := certificateRequestEvent{
 		ID:          core.NewToken(),
@@ -1063,7 +1106,7 @@ func (ra *RegistrationAuthorityImpl) issueCertificate(
 	beeline.AddFieldToTrace(ctx, "order.id", oID)
 	beeline.AddFieldToTrace(ctx, "acct.id", acctID)
 	var result string
-	cert, err := ra.issueCertificateInner(ctx, req, acctID, oID, issuerNameID, &logEvent, typeIdentifier)
+	cert, err := ra.issueCertificateInner(ctx, req, acctID, oID, issuerNameID, &logEvent, typeIdentifier, notBefore, notAfter, certProfile)
 	if err != nil {
 		logEvent.Error = err.Error()
 		beeline.AddFieldToTrace(ctx, "issuance.error", err)
@@ -1096,7 +1139,10 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 	oID orderID,
 	issuerNameID issuance.IssuerNameID,
 	logEvent *certificateRequestEvent,
-	typeIdentifier string) (core.Certificate, error) {
+	typeIdentifier string,
+	notBefore int64,
+	notAfter int64,
+	certProfile string) (core.Certificate, error) {
 	emptyCert := core.Certificate{}
 	if acctID <= 0 {
 		return emptyCert, berrors.MalformedError("invalid account ID: %d", acctID)
@@ -1121,9 +1167,9 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 	logEvent.Names = csr.DNSNames
 	beeline.AddFieldToTrace(ctx, "csr.dnsnames", csr.DNSNames)
 
-	// Validate that authorization key is authorized for all domains in the CSR
-	names := make([]string, len(csr.DNSNames))
-	copy(names, csr.DNSNames)
+	// Validate that authorization key is authorized for all domains and IP
+	// addresses in the CSR
+	names := csrIdentifierValues(csr)
 
 	if core.KeyDigestEquals(csr.PublicKey, account.Key) {
 		return emptyCert, berrors.MalformedError("certificate public key must be different than account key")
@@ -1174,6 +1220,9 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		OrderID:        int64(oID),
 		IssuerNameID:   int64(issuerNameID),
 		TypeIdentifier: typeIdentifier,
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+		CertProfile:    certProfile,
 	}
 
 	// wrapError adds a prefix to an error. If the error is a boulder error then
@@ -1205,6 +1254,7 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		RegistrationID: int64(acctID),
 		OrderID:        int64(oID),
 		TypeIdentifier: typeIdentifier,
+		CertProfile:    certProfile,
 	})
 	if err != nil {
 		return emptyCert, wrapError(err, "issuing certificate for precertificate")
@@ -1267,10 +1317,15 @@ func (ra *RegistrationAuthorityImpl) getSCTs(ctx context.Context, cert []byte, e
 
 // domainsForRateLimiting transforms a list of FQDNs into a list of eTLD+1's
 // for the purpose of rate limiting. It also de-duplicates the output
-// domains. Exact public suffix matches are included.
+// domains. Exact public suffix matches are included. IP addresses have no
+// registered domain, so each is counted by the full address.
 func domainsForRateLimiting(names []string) ([]string, error) {
 	var domains []string
 	for _, name := range names {
+		if net.ParseIP(name) != nil {
+			domains = append(domains, name)
+			continue
+		}
 		domain, err := publicsuffix.Domain(name)
 		if err != nil {
 			// The only possible errors are:
@@ -1672,6 +1727,7 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 				Id:    authz.ID,
 				RegID: authz.RegistrationID,
 			},
+			TypeIdentifier: string(authz.Identifier.Type),
 		}
 		res, err := ra.VA.PerformValidation(vaCtx, &req)
 		challenge := &authz.Challenges[challIndex]
@@ -1741,20 +1797,20 @@ func (ra *RegistrationAuthorityImpl) deprecatedRevokeCertificate(ctx context.Con
 	var issuerID int64
 	var issuer *issuance.Certificate
 	var ok bool
+	if cert.Raw == nil && reason == ocsp.KeyCompromise {
+		return fmt.Errorf("cannot revoke for KeyCompromise without full cert")
+	}
+
+	status, err := ra.SA.GetCertificateStatus(ctx, &sapb.Serial{Serial: serial})
+	if err != nil {
+		return fmt.Errorf("unable to confirm that serial %q was ever issued: %w", serial, err)
+	}
+
 	if cert.Raw == nil {
 		// We've been given a synthetic cert containing just a serial number,
 		// presumably because the cert we're revoking is so badly malformed that
 		// it is unparsable. We need to gather the relevant info using only the
 		// serial number.
-		if reason == ocsp.KeyCompromise {
-			return fmt.Errorf("cannot revoke for KeyCompromise without full cert")
-		}
-
-		status, err := ra.SA.GetCertificateStatus(ctx, &sapb.Serial{Serial: serial})
-		if err != nil {
-			return fmt.Errorf("unable to confirm that serial %q was ever issued: %w", serial, err)
-		}
-
 		issuerID = status.IssuerID
 		issuer, ok = ra.issuersByNameID[issuance.IssuerNameID(issuerID)]
 		if !ok {
@@ -1773,13 +1829,7 @@ func (ra *RegistrationAuthorityImpl) deprecatedRevokeCertificate(ctx context.Con
 	}
 
 	revokedAt := ra.clk.Now().UnixNano()
-	ocspResponse, err := ra.CA.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
-		Serial:    serial,
-		IssuerID:  issuerID,
-		Status:    string(core.OCSPStatusRevoked),
-		Reason:    int32(reason),
-		RevokedAt: revokedAt,
-	})
+	ocspResponse, err := ra.generateRevokedOCSP(ctx, status, serial, issuerID, reason, revokedAt)
 	if err != nil {
 		return err
 	}
@@ -1788,7 +1838,7 @@ func (ra *RegistrationAuthorityImpl) deprecatedRevokeCertificate(ctx context.Con
 		Serial:   serial,
 		Reason:   int64(reason),
 		Date:     revokedAt,
-		Response: ocspResponse.Response,
+		Response: ocspResponse,
 	})
 	if err != nil {
 		return err
@@ -1815,6 +1865,10 @@ func (ra *RegistrationAuthorityImpl) deprecatedRevokeCertificate(ctx context.Con
 		}
 	}
 
+	if status.IsShortLived {
+		// There are no OCSP responses to purge.
+		return nil
+	}
 	purgeURLs, err := akamai.GeneratePurgeURLs(cert, issuer.Certificate)
 	if err != nil {
 		return err
@@ -1827,20 +1881,42 @@ func (ra *RegistrationAuthorityImpl) deprecatedRevokeCertificate(ctx context.Con
 	return nil
 }
 
+// generateRevokedOCSP asks the CA for a revoked OCSP response for the
+// certificate with the given status, serial and issuer. Short-lived
+// certificates never get OCSP responses, so for them it returns nil without
+// asking the CA.
+func (ra *RegistrationAuthorityImpl) generateRevokedOCSP(ctx context.Context, status *corepb.CertificateStatus, serial string, issuerID int64, reason revocation.Reason, revokedAt int64) ([]byte, error) {
+	if status.IsShortLived {
+		return nil, nil
+	}
+	ocspResponse, err := ra.CA.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
+		Serial:    serial,
+		IssuerID:  issuerID,
+		Status:    string(core.OCSPStatusRevoked),
+		Reason:    int32(reason),
+		RevokedAt: revokedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ocspResponse.Response, nil
+}
+
 // revokeCertificate generates a revoked OCSP response for the certificate with
-// the given serial and issuer and stores that response in the database.
+// the given serial and issuer and stores that response in the database. No
+// OCSP response is generated for short-lived certificates, whose revocation is
+// only recorded.
 // TODO(#5152) make the issuerID argument an issuance.IssuerNameID
 func (ra *RegistrationAuthorityImpl) revokeCertificate(ctx context.Context, serial *big.Int, issuerID int64, reason revocation.Reason) error {
 	serialString := core.SerialToString(serial)
 	revokedAt := ra.clk.Now().UnixNano()
 
-	ocspResponse, err := ra.CA.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
-		Serial:    serialString,
-		IssuerID:  int64(issuerID),
-		Status:    string(core.OCSPStatusRevoked),
-		Reason:    int32(reason),
-		RevokedAt: revokedAt,
-	})
+	status, err := ra.SA.GetCertificateStatus(ctx, &sapb.Serial{Serial: serialString})
+	if err != nil {
+		return berrors.NotFoundError("unable to confirm that serial %q was ever issued: %s", serialString, err)
+	}
+
+	ocspResponse, err := ra.generateRevokedOCSP(ctx, status, serialString, issuerID, reason, revokedAt)
 	if err != nil {
 		return err
 	}
@@ -1849,7 +1925,7 @@ func (ra *RegistrationAuthorityImpl) revokeCertificate(ctx context.Context, seri
 		Serial:   serialString,
 		Reason:   int64(reason),
 		Date:     revokedAt,
-		Response: ocspResponse.Response,
+		Response: ocspResponse,
 	})
 	if err != nil {
 		return err
@@ -1884,13 +1960,7 @@ func (ra *RegistrationAuthorityImpl) updateRevocationForKeyCompromise(ctx contex
 	}
 
 	// The new OCSP response has to be back-dated to the original date.
-	ocspResponse, err := ra.CA.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
-		Serial:    serialString,
-		IssuerID:  int64(issuerID),
-		Status:    string(core.OCSPStatusRevoked),
-		Reason:    int32(ocsp.KeyCompromise),
-		RevokedAt: status.RevokedDate,
-	})
+	ocspResponse, err := ra.generateRevokedOCSP(ctx, status, serialString, issuerID, ocsp.KeyCompromise, status.RevokedDate)
 	if err != nil {
 		return err
 	}
@@ -1900,7 +1970,7 @@ func (ra *RegistrationAuthorityImpl) updateRevocationForKeyCompromise(ctx contex
 		Reason:   int64(ocsp.KeyCompromise),
 		Date:     thisUpdate,
 		Backdate: status.RevokedDate,
-		Response: ocspResponse.Response,
+		Response: ocspResponse,
 	})
 	if err != nil {
 		return err
@@ -2339,15 +2409,53 @@ func (ra *RegistrationAuthorityImpl) DeactivateAuthorization(ctx context.Context
 	return &emptypb.Empty{}, nil
 }
 
-// checkOrderNames validates that the RA's policy authority allows issuing for
-// each of the names in an order. If any of the names are unacceptable a
-// malformed or rejectedIdentifier error with suberrors for each rejected
-// identifier is returned.
-func (ra *RegistrationAuthorityImpl) checkOrderNames(names []string) error {
-	idents := make([]identifier.ACMEIdentifier, len(names))
-	for i, name := range names {
-		idents[i] = identifier.DNSIdentifier(name)
+// DeactivateOrder deactivates a pending or ready order, along with any of its
+// authorizations that are still pending and not shared with another of the
+// account's pending or ready orders. This lets a client abandon an order and
+// release the pending authorizations it holds.
+func (ra *RegistrationAuthorityImpl) DeactivateOrder(ctx context.Context, req *corepb.Order) (*emptypb.Empty, error) {
+	if req == nil || req.Id == 0 || req.Status == "" {
+		return nil, errIncompleteGRPCRequest
+	}
+	if req.Status != string(core.StatusPending) && req.Status != string(core.StatusReady) {
+		return nil, berrors.MalformedError("Order's status (%q) is not acceptable for deactivation", req.Status)
+	}
+	if _, err := ra.SA.DeactivateOrder(ctx, &sapb.OrderRequest{Id: req.Id}); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// newOrderIdentifiers returns the identifiers requested by a new order
+// request, keyed by their lowercased names. Each is of the type the client
+// gave it, so a DNS identifier holding an IP address stays a DNS identifier
+// and is refused by policy. A request without a type for each name has all of
+// its names of the order's type. An error is returned if a name is given more
+// than one type.
+func newOrderIdentifiers(req *rapb.NewOrderRequest, orderType identifier.IdentifierType) (map[string]identifier.ACMEIdentifier, error) {
+	if len(req.TypeIdentifiers) != 0 && len(req.TypeIdentifiers) != len(req.Names) {
+		return nil, errIncompleteGRPCRequest
 	}
+	idents := make(map[string]identifier.ACMEIdentifier, len(req.Names))
+	for i, name := range req.Names {
+		name = strings.ToLower(name)
+		identType := orderType
+		if len(req.TypeIdentifiers) != 0 {
+			identType = identifier.IdentifierType(req.TypeIdentifiers[i])
+		}
+		if existing, ok := idents[name]; ok && existing.Type != identType {
+			return nil, berrors.MalformedError("Order includes %q as identifiers of types %q and %q", name, existing.Type, identType)
+		}
+		idents[name] = identifier.ACMEIdentifier{Type: identType, Value: name}
+	}
+	return idents, nil
+}
+
+// checkOrderNames validates that the RA's policy authority allows issuing for
+// each of the identifiers in an order. If any of the identifiers are
+// unacceptable a malformed or rejectedIdentifier error with suberrors for each
+// rejected identifier is returned.
+func (ra *RegistrationAuthorityImpl) checkOrderNames(idents []identifier.ACMEIdentifier) error {
 	err := ra.PA.WillingToIssueWildcards(idents)
 	if err != nil {
 		return err
@@ -2355,6 +2463,76 @@ func (ra *RegistrationAuthorityImpl) checkOrderNames(names []string) error {
 	return nil
 }
 
+// truncateToSeconds truncates a Unix timestamp in nanoseconds to whole seconds,
+// the precision with which the SA stores the validity period requested for an
+// order. This keeps the stored and requested validity periods of an order
+// comparable when deciding whether to reuse it.
+func truncateToSeconds(ts int64) int64 {
+	return ts - ts%int64(time.Second)
+}
+
+// checkRequestedValidity checks the certificate validity period a client
+// requested for a new order using the given certificate profile. The notBefore
+// and notAfter are Unix timestamps in nanoseconds and are zero when not
+// requested. A requested notBefore may not be in the future or backdated by
+// more than the profile's MaxValidityBackdate, and the requested period may not
+// be longer than the profile's MaxValidityPeriod. The CA enforces the same
+// bounds when the certificate is issued.
+func (ra *RegistrationAuthorityImpl) checkRequestedValidity(profileName string, notBefore, notAfter int64) error {
+	if notBefore == 0 && notAfter == 0 {
+		return nil
+	}
+	if ra.profiles == nil {
+		return berrors.MalformedError("NotBefore and NotAfter are not supported")
+	}
+	profile, ok := ra.profiles.Lookup(profileName)
+	if !ok {
+		return berrors.MalformedError("Unrecognized certificate profile %q", profileName)
+	}
+	now := ra.clk.Now()
+	start := now
+	if notBefore != 0 {
+		start = time.Unix(0, notBefore)
+		if start.After(now) {
+			return berrors.MalformedError("NotBefore must not be in the future")
+		}
+		if now.Sub(start) > profile.MaxValidityBackdate.Duration {
+			return berrors.MalformedError(
+				"NotBefore must not be more than %s in the past", profile.MaxValidityBackdate.Duration)
+		}
+	}
+	if notAfter == 0 {
+		return nil
+	}
+	end := time.Unix(0, notAfter)
+	if !end.After(start) {
+		return berrors.MalformedError("NotAfter must be after NotBefore")
+	}
+	if end.Sub(start) > profile.MaxValidityPeriod.Duration {
+		return berrors.MalformedError(
+			"Requested validity period (%s) is longer than the maximum allowed by the certificate profile (%s)",
+			end.Sub(start), profile.MaxValidityPeriod.Duration)
+	}
+	return nil
+}
+
+// checkCertProfile checks that the certificate profile a client requested for
+// a new order exists and that the account is permitted to request it. The
+// empty name refers to the CA's default profile, which every account may use.
+func (ra *RegistrationAuthorityImpl) checkCertProfile(name string, regID int64) error {
+	if name == "" {
+		return nil
+	}
+	allowList, ok := ra.certProfiles[name]
+	if !ok {
+		return berrors.MalformedError("Unrecognized certificate profile %q", name)
+	}
+	if allowList != nil && !allowList[regID] {
+		return berrors.UnauthorizedError("Account is not permitted to request certificate profile %q", name)
+	}
+	return nil
+}
+
 // NewOrder creates a new order object
 func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.NewOrderRequest) (*corepb.Order, error) {
 	if req == nil || req.RegistrationID == 0 {
@@ -2362,26 +2540,40 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	}
 
 	var typeIdentifier identifier.IdentifierType
-	if req.TypeIdentifier == "dns" {
+	switch req.TypeIdentifier {
+	case string(identifier.DNS):
 		typeIdentifier = identifier.DNS
-	} else {
+	case string(identifier.IP):
+		typeIdentifier = identifier.IP
+	default:
 		typeIdentifier = identifier.JWT
 	}
 
+	identsByName, err := newOrderIdentifiers(req, typeIdentifier)
+	if err != nil {
+		return nil, err
+	}
+
 	newOrder := &sapb.NewOrderRequest{
 		RegistrationID: req.RegistrationID,
 		Names:          core.UniqueLowerNames(req.Names),
 		TypeIdentifier: req.TypeIdentifier,
+		NotBefore:      truncateToSeconds(req.NotBefore),
+		NotAfter:       truncateToSeconds(req.NotAfter),
+		CertProfile:    req.CertProfile,
 	}
 
 	if len(newOrder.Names) > ra.maxNames {
 		return nil, berrors.MalformedError(
 			"Order cannot contain more than %d DNS names", ra.maxNames)
 	}
-	var err error
-	if req.TypeIdentifier == "dns" {
+	if typeIdentifier != identifier.JWT {
 		// Validate that our policy allows issuing for each of the names in the order
-		err = ra.checkOrderNames(newOrder.Names)
+		idents := make([]identifier.ACMEIdentifier, len(newOrder.Names))
+		for i, name := range newOrder.Names {
+			idents[i] = identsByName[name]
+		}
+		err = ra.checkOrderNames(idents)
 		if err != nil {
 			return nil, err
 		}
@@ -2392,6 +2584,16 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 		return nil, err
 	}
 
+	err = ra.checkCertProfile(newOrder.CertProfile, newOrder.RegistrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ra.checkRequestedValidity(newOrder.CertProfile, req.NotBefore, req.NotAfter)
+	if err != nil {
+		return nil, err
+	}
+
 	// See if there is an existing unexpired pending (or ready) order that can be reused
 	// for this account
 	existingOrder, err := ra.SA.GetOrderForNames(ctx, &sapb.GetOrderForNamesRequest{
@@ -2404,6 +2606,13 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 		return nil, err
 	}
 
+	// An existing order is only reused if it was created with the same
+	// requested validity period and certificate profile.
+	if existingOrder != nil && (existingOrder.NotBefore != newOrder.NotBefore || existingOrder.NotAfter != newOrder.NotAfter ||
+		existingOrder.CertProfile != newOrder.CertProfile) {
+		existingOrder = nil
+	}
+
 	// If there was an order, make sure it has expected fields and return it
 	// Error if an incomplete order is returned.
 	if existingOrder != nil {
@@ -2475,13 +2684,12 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 			continue
 		}
 		authz := nameToExistingAuthz[name]
-		// If the identifier is a wildcard and the existing authz only has one
-		// DNS-01 type challenge we can reuse it. In theory we will
-		// never get back an authorization for a domain with a wildcard prefix
-		// that doesn't meet this criteria from SA.GetAuthorizations but we verify
-		// again to be safe.
-		if strings.HasPrefix(name, "*.") &&
-			len(authz.Challenges) == 1 && core.AcmeChallenge(authz.Challenges[0].Type) == core.ChallengeTypeDNS01 {
+		// If the identifier is a wildcard and the existing authz only has
+		// DNS-01 or DNS-ACCOUNT-01 type challenges we can reuse it. In theory we
+		// will never get back an authorization for a domain with a wildcard
+		// prefix that doesn't meet this criteria from SA.GetAuthorizations but we
+		// verify again to be safe.
+		if strings.HasPrefix(name, "*.") && onlyDNSChallenges(authz.Challenges) {
 			authzID, err := strconv.ParseInt(authz.Id, 10, 64)
 			if err != nil {
 				return nil, err
@@ -2524,10 +2732,7 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	// authorization for each.
 	var newAuthzs []*corepb.Authorization
 	for _, name := range missingAuthzNames {
-		pb, err := ra.createPendingAuthz(ctx, newOrder.RegistrationID, identifier.ACMEIdentifier{
-			Type:  typeIdentifier,
-			Value: name,
-		})
+		pb, err := ra.createPendingAuthz(ctx, newOrder.RegistrationID, identsByName[name])
 		if err != nil {
 			return nil, err
 		}
@@ -2584,12 +2789,109 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	return storedOrder, nil
 }
 
+// NewAuthorization creates a standalone pending authorization for a single
+// identifier, as described by the newAuthz resource in RFC 8555 Section 7.4.1.
+// If the account already holds a usable valid or pending authorization for the
+// identifier that authorization is returned instead of creating a new one, and
+// the response is marked as reused. Authorizations created here are picked up by later calls to NewOrder in the
+// same way as authorizations left over from earlier orders.
+func (ra *RegistrationAuthorityImpl) NewAuthorization(ctx context.Context, req *rapb.NewAuthorizationRequest) (*rapb.NewAuthorizationResponse, error) {
+	if req == nil || req.RegistrationID == 0 || req.Identifier == "" {
+		return nil, errIncompleteGRPCRequest
+	}
+
+	var typeIdentifier identifier.IdentifierType
+	switch req.TypeIdentifier {
+	case "", string(identifier.DNS):
+		typeIdentifier = identifier.DNS
+	case string(identifier.IP):
+		typeIdentifier = identifier.IP
+	default:
+		return nil, berrors.MalformedError(
+			"Pre-authorization is not supported for identifier type %q", req.TypeIdentifier)
+	}
+
+	name := strings.ToLower(req.Identifier)
+	// RFC 8555 Section 7.4.1: wildcard domain names must not be used in
+	// newAuthz requests.
+	if strings.HasPrefix(name, "*") {
+		return nil, berrors.MalformedError("Wildcard domain names are not supported for pre-authorization")
+	}
+	ident := identifier.ACMEIdentifier{Type: typeIdentifier, Value: name}
+	err := ra.PA.WillingToIssueWildcards([]identifier.ACMEIdentifier{ident})
+	if err != nil {
+		return nil, err
+	}
+
+	// As in NewOrder, only reuse authorizations that are at least 1 day away
+	// from expiring.
+	authzExpiryCutoff := ra.clk.Now().AddDate(0, 0, 1).UnixNano()
+
+	if ra.reuseValidAuthz {
+		validAuthzs, err := ra.SA.GetValidAuthorizations2(ctx, &sapb.GetValidAuthorizationsRequest{
+			RegistrationID: req.RegistrationID,
+			Domains:        []string{name},
+			Now:            authzExpiryCutoff,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range validAuthzs.Authz {
+			if v.Domain == name && v.Authz != nil && v.Authz.TypeIdentifier == string(typeIdentifier) {
+				return &rapb.NewAuthorizationResponse{Authz: v.Authz, Reused: true}, nil
+			}
+		}
+	}
+
+	pendingAuthz, err := ra.SA.GetPendingAuthorization2(ctx, &sapb.GetPendingAuthorizationRequest{
+		RegistrationID:  req.RegistrationID,
+		IdentifierType:  string(typeIdentifier),
+		IdentifierValue: name,
+		ValidUntil:      authzExpiryCutoff,
+	})
+	if err == nil {
+		return &rapb.NewAuthorizationResponse{Authz: pendingAuthz, Reused: true}, nil
+	} else if !errors.Is(err, berrors.NotFound) {
+		return nil, err
+	}
+
+	err = ra.checkPendingAuthorizationLimit(ctx, req.RegistrationID)
+	if err != nil {
+		return nil, err
+	}
+	err = ra.checkInvalidAuthorizationLimit(ctx, req.RegistrationID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	authz, err := ra.createPendingAuthz(ctx, req.RegistrationID, ident)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := ra.SA.NewAuthorizations2(ctx, &sapb.AddPendingAuthorizationsRequest{
+		Authz: []*corepb.Authorization{authz},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ids == nil || len(ids.Ids) != 1 {
+		return nil, errIncompleteGRPCResponse
+	}
+
+	authz, err = ra.SA.GetAuthorization2(ctx, &sapb.AuthorizationID2{Id: ids.Ids[0]})
+	if err != nil {
+		return nil, err
+	}
+	return &rapb.NewAuthorizationResponse{Authz: authz}, nil
+}
+
 // createPendingAuthz checks that a name is allowed for issuance and creates the
 // necessary challenges for it and puts this and all of the relevant information
 // into a corepb.Authorization for transmission to the SA to be stored
 func (ra *RegistrationAuthorityImpl) createPendingAuthz(ctx context.Context, reg int64, identifier identifier.ACMEIdentifier) (*corepb.Authorization, error) {
 	authz := &corepb.Authorization{
 		Identifier:     identifier.Value,
+		TypeIdentifier: string(identifier.Type),
 		RegistrationID: reg,
 		Status:         string(core.StatusPending),
 		Expires:        ra.clk.Now().Add(ra.pendingAuthorizationLifetime).Truncate(time.Second).UnixNano(),
@@ -2621,6 +2923,20 @@ func (ra *RegistrationAuthorityImpl) createPendingAuthz(ctx context.Context, reg
 	return authz, nil
 }
 
+// onlyDNSChallenges returns true if there is at least one challenge and every
+// challenge is of a DNS-based type, which are the only types offered for
+// wildcard identifiers.
+func onlyDNSChallenges(challenges []*corepb.Challenge) bool {
+	for _, chall := range challenges {
+		switch core.AcmeChallenge(chall.Type) {
+		case core.ChallengeTypeDNS01, core.ChallengeTypeDNSAccount01:
+		default:
+			return false
+		}
+	}
+	return len(challenges) > 0
+}
+
 // wildcardOverlap takes a slice of domain names and returns an error if any of
 // them is a non-wildcard FQDN that overlaps with a wildcard domain in the map.
 func wildcardOverlap(dnsNames []string) error {
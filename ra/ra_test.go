@@ -352,7 +352,7 @@ func initAuthorities(t *testing.T) (*DummyValidationAuthority, sapb.StorageAutho
 	ra := NewRegistrationAuthorityImpl(fc,
 		log,
 		stats,
-		1, testKeyPolicy, 100, true, 300*24*time.Hour, 7*24*time.Hour, nil, noopCAA{}, 0, ctp, nil, nil)
+		1, testKeyPolicy, 100, true, 300*24*time.Hour, 7*24*time.Hour, nil, noopCAA{}, 0, nil, nil, ctp, nil, nil)
 	ra.SA = sa
 	ra.VA = va
 	ra.CA = ca
@@ -1055,6 +1055,10 @@ func TestDomainsForRateLimiting(t *testing.T) {
 	domains, err = domainsForRateLimiting([]string{"github.io", "foo.github.io", "bar.github.io"})
 	test.AssertNotError(t, err, "failed on public suffix private domain")
 	test.AssertDeepEquals(t, domains, []string{"bar.github.io", "foo.github.io", "github.io"})
+
+	domains, err = domainsForRateLimiting([]string{"10.0.0.1", "192.168.0.1", "2001:db8::1", "example.com"})
+	test.AssertNotError(t, err, "failed on IP addresses")
+	test.AssertDeepEquals(t, domains, []string{"10.0.0.1", "192.168.0.1", "2001:db8::1", "example.com"})
 }
 
 func TestRateLimitLiveReload(t *testing.T) {
@@ -1525,6 +1529,38 @@ func TestDeactivateAuthorization(t *testing.T) {
 	test.AssertEquals(t, deact.Status, string(core.StatusDeactivated))
 }
 
+func TestDeactivateOrder(t *testing.T) {
+	_, sa, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	// Deactivate failure because incomplete order provided
+	_, err := ra.DeactivateOrder(ctx, &corepb.Order{})
+	test.AssertDeepEquals(t, err, fmt.Errorf("incomplete gRPC request message"))
+
+	order, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.Id,
+		Names:          []string{"not-example.com"},
+		TypeIdentifier: "dns",
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, order.Status, string(core.StatusPending))
+
+	// Deactivate failure because the order has already begun processing
+	_, err = ra.DeactivateOrder(ctx, &corepb.Order{Id: order.Id, Status: string(core.StatusProcessing)})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	_, err = ra.DeactivateOrder(ctx, order)
+	test.AssertNotError(t, err, "DeactivateOrder failed")
+
+	deact, err := sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertEquals(t, deact.Status, string(core.StatusDeactivated))
+
+	authz, err := sa.GetAuthorization2(ctx, &sapb.AuthorizationID2{Id: order.V2Authorizations[0]})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, authz.Status, string(core.StatusDeactivated))
+}
+
 func TestDeactivateRegistration(t *testing.T) {
 	_, _, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -2029,6 +2065,223 @@ func TestNewOrderReuse(t *testing.T) {
 	}
 }
 
+func TestNewAuthorization(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ctx := context.Background()
+
+	// Incomplete requests should be rejected
+	_, err := ra.NewAuthorization(ctx, &rapb.NewAuthorizationRequest{RegistrationID: Registration.Id})
+	test.AssertError(t, err, "NewAuthorization with no identifier didn't fail")
+
+	// Wildcards can't be pre-authorized
+	_, err = ra.NewAuthorization(ctx, &rapb.NewAuthorizationRequest{
+		RegistrationID: Registration.Id,
+		Identifier:     "*.zombo.com",
+		TypeIdentifier: "dns",
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	// Names that policy forbids can't be pre-authorized
+	_, err = ra.NewAuthorization(ctx, &rapb.NewAuthorizationRequest{
+		RegistrationID: Registration.Id,
+		Identifier:     "zombo.invalid",
+		TypeIdentifier: "dns",
+	})
+	test.AssertError(t, err, "NewAuthorization for a forbidden name didn't fail")
+
+	req := &rapb.NewAuthorizationRequest{
+		RegistrationID: Registration.Id,
+		Identifier:     "zombo.com",
+		TypeIdentifier: "dns",
+	}
+	resp, err := ra.NewAuthorization(ctx, req)
+	test.AssertNotError(t, err, "NewAuthorization failed")
+	test.Assert(t, !resp.Reused, "new authz was marked as reused")
+	authz := resp.Authz
+	test.AssertEquals(t, authz.Identifier, "zombo.com")
+	test.AssertEquals(t, authz.Status, string(core.StatusPending))
+	test.Assert(t, len(authz.Challenges) > 0, "NewAuthorization returned an authz without challenges")
+
+	// A second request for the same identifier should reuse the pending authz
+	resp, err = ra.NewAuthorization(ctx, req)
+	test.AssertNotError(t, err, "second NewAuthorization failed")
+	test.Assert(t, resp.Reused, "existing authz wasn't marked as reused")
+	test.AssertEquals(t, resp.Authz.Id, authz.Id)
+
+	// An order for the identifier should reuse the pre-authorization
+	order, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.Id,
+		Names:          []string{"zombo.com"},
+		TypeIdentifier: "dns",
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, numAuthorizations(order), 1)
+	test.AssertEquals(t, fmt.Sprintf("%d", order.V2Authorizations[0]), authz.Id)
+}
+
+func TestNewAuthorizationRateLimiting(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.rlPolicies = &dummyRateLimitConfig{
+		PendingAuthorizationsPerAccountPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 1,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+	}
+
+	ctx := context.Background()
+	_, err := ra.NewAuthorization(ctx, &rapb.NewAuthorizationRequest{
+		RegistrationID: Registration.Id,
+		Identifier:     "zombo.com",
+		TypeIdentifier: "dns",
+	})
+	test.AssertNotError(t, err, "NewAuthorization failed")
+
+	_, err = ra.NewAuthorization(ctx, &rapb.NewAuthorizationRequest{
+		RegistrationID: Registration.Id,
+		Identifier:     "welcome.to.zombo.com",
+		TypeIdentifier: "dns",
+	})
+	test.AssertErrorIs(t, err, berrors.RateLimit)
+}
+
+func TestNewOrderRequestedValidity(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	now := fc.Now()
+	orderReq := func(profile string, notBefore, notAfter time.Time) *rapb.NewOrderRequest {
+		req := &rapb.NewOrderRequest{
+			RegistrationID: Registration.Id,
+			Names:          []string{"zombo.com"},
+			TypeIdentifier: "dns",
+			CertProfile:    profile,
+		}
+		if !notBefore.IsZero() {
+			req.NotBefore = notBefore.UnixNano()
+		}
+		if !notAfter.IsZero() {
+			req.NotAfter = notAfter.UnixNano()
+		}
+		return req
+	}
+
+	// Without configured certificate profiles requests are rejected
+	_, err := ra.NewOrder(ctx, orderReq("", now, now.Add(time.Hour)))
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	ra.profiles = &issuance.ProfilesConfig{
+		Profile: issuance.ProfileConfig{
+			MaxValidityPeriod:   cmd.ConfigDuration{Duration: 90 * 24 * time.Hour},
+			MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+		},
+		Profiles: map[string]issuance.ProfileConfig{
+			"shortlived": {
+				MaxValidityPeriod:   cmd.ConfigDuration{Duration: 160 * time.Hour},
+				MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+			},
+		},
+	}
+	ra.certProfiles = map[string]map[int64]bool{"shortlived": nil}
+
+	testCases := []struct {
+		name      string
+		profile   string
+		notBefore time.Time
+		notAfter  time.Time
+		expectErr bool
+	}{
+		{"notBefore in the future", "", now.Add(time.Hour), time.Time{}, true},
+		{"notBefore too far in the past", "", now.Add(-2 * time.Hour), time.Time{}, true},
+		{"notAfter before notBefore", "", now, now.Add(-time.Hour), true},
+		{"validity period too long", "", now, now.Add(91 * 24 * time.Hour), true},
+		{"only notAfter, too long", "", time.Time{}, now.Add(91 * 24 * time.Hour), true},
+		{"too long for the shortlived profile", "shortlived", now, now.Add(30 * 24 * time.Hour), true},
+		{"acceptable validity period", "", now, now.Add(30 * 24 * time.Hour), false},
+		{"acceptable backdated notBefore", "", now.Add(-time.Hour), now.Add(24 * time.Hour), false},
+		{"acceptable notAfter only", "", time.Time{}, now.Add(48 * time.Hour), false},
+		{"acceptable for the shortlived profile", "shortlived", now, now.Add(160 * time.Hour), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			order, err := ra.NewOrder(ctx, orderReq(tc.profile, tc.notBefore, tc.notAfter))
+			if tc.expectErr {
+				test.AssertErrorIs(t, err, berrors.Malformed)
+				return
+			}
+			test.AssertNotError(t, err, "NewOrder failed")
+			if !tc.notBefore.IsZero() {
+				test.AssertEquals(t, order.NotBefore, tc.notBefore.Truncate(time.Second).UnixNano())
+			}
+			test.AssertEquals(t, order.NotAfter, tc.notAfter.Truncate(time.Second).UnixNano())
+		})
+	}
+
+	// The requested validity period is stored with a precision of seconds, so
+	// repeating a request with sub-second timestamps reuses the order
+	notBefore := now.Add(-time.Minute).Add(500 * time.Millisecond)
+	notAfter := now.Add(72 * time.Hour).Add(500 * time.Millisecond)
+	first, err := ra.NewOrder(ctx, orderReq("", notBefore, notAfter))
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, first.NotBefore, notBefore.Truncate(time.Second).UnixNano())
+	test.AssertEquals(t, first.NotAfter, notAfter.Truncate(time.Second).UnixNano())
+	second, err := ra.NewOrder(ctx, orderReq("", notBefore, notAfter))
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, second.Id, first.Id)
+}
+
+func TestTruncateToSeconds(t *testing.T) {
+	test.AssertEquals(t, truncateToSeconds(0), int64(0))
+	ts := time.Date(2021, 1, 1, 1, 1, 1, 999999999, time.UTC)
+	test.AssertEquals(t, truncateToSeconds(ts.UnixNano()), ts.Truncate(time.Second).UnixNano())
+}
+
+func TestNewOrderCertProfile(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.certProfiles = map[string]map[int64]bool{
+		"classic":    nil,
+		"shortlived": {Registration.Id + 1: true},
+	}
+	orderReq := func(profile string) *rapb.NewOrderRequest {
+		return &rapb.NewOrderRequest{
+			RegistrationID: Registration.Id,
+			Names:          []string{"zombo.com"},
+			TypeIdentifier: "dns",
+			CertProfile:    profile,
+		}
+	}
+
+	// An unknown profile is rejected
+	_, err := ra.NewOrder(ctx, orderReq("unknown"))
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	// A profile the account isn't allowed to use is rejected
+	_, err = ra.NewOrder(ctx, orderReq("shortlived"))
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+
+	// A profile available to all accounts is stored on the order
+	order, err := ra.NewOrder(ctx, orderReq("classic"))
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, order.CertProfile, "classic")
+
+	// An order for the same names with a different profile isn't reused
+	defaultOrder, err := ra.NewOrder(ctx, orderReq(""))
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, defaultOrder.CertProfile, "")
+	test.AssertNotEquals(t, defaultOrder.Id, order.Id)
+
+	// The account may use the restricted profile once it's on the allowlist
+	ra.certProfiles["shortlived"][Registration.Id] = true
+	order, err = ra.NewOrder(ctx, orderReq("shortlived"))
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, order.CertProfile, "shortlived")
+}
+
 func TestNewOrderReuseInvalidAuthz(t *testing.T) {
 	_, _, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -3222,6 +3475,69 @@ func TestWildcardOverlap(t *testing.T) {
 	}
 }
 
+func TestOnlyDNSChallenges(t *testing.T) {
+	dns01 := &corepb.Challenge{Type: string(core.ChallengeTypeDNS01)}
+	dnsAccount01 := &corepb.Challenge{Type: string(core.ChallengeTypeDNSAccount01)}
+	http01 := &corepb.Challenge{Type: string(core.ChallengeTypeHTTP01)}
+
+	test.Assert(t, onlyDNSChallenges([]*corepb.Challenge{dns01}), "dns-01 alone should be accepted")
+	test.Assert(t, onlyDNSChallenges([]*corepb.Challenge{dnsAccount01}), "dns-account-01 alone should be accepted")
+	test.Assert(t, onlyDNSChallenges([]*corepb.Challenge{dns01, dnsAccount01}), "dns-01 and dns-account-01 should be accepted")
+	test.Assert(t, !onlyDNSChallenges([]*corepb.Challenge{dns01, http01}), "http-01 should be rejected")
+	test.Assert(t, !onlyDNSChallenges(nil), "no challenges should be rejected")
+}
+
+func TestNewOrderIdentifiers(t *testing.T) {
+	// Without a type for each name, every name is of the order's type.
+	idents, err := newOrderIdentifiers(&rapb.NewOrderRequest{
+		Names: []string{"Example.com", "1.2.3.4"},
+	}, identifier.DNS)
+	test.AssertNotError(t, err, "newOrderIdentifiers failed")
+	test.AssertDeepEquals(t, idents, map[string]identifier.ACMEIdentifier{
+		"example.com": identifier.DNSIdentifier("example.com"),
+		"1.2.3.4":     identifier.DNSIdentifier("1.2.3.4"),
+	})
+
+	// Each name keeps the type the client gave it, even in an IP order.
+	idents, err = newOrderIdentifiers(&rapb.NewOrderRequest{
+		Names:           []string{"example.com", "1.2.3.4", "5.6.7.8"},
+		TypeIdentifiers: []string{"dns", "dns", "ip"},
+	}, identifier.IP)
+	test.AssertNotError(t, err, "newOrderIdentifiers failed")
+	test.AssertDeepEquals(t, idents, map[string]identifier.ACMEIdentifier{
+		"example.com": identifier.DNSIdentifier("example.com"),
+		"1.2.3.4":     identifier.DNSIdentifier("1.2.3.4"),
+		"5.6.7.8":     identifier.IPIdentifier(net.ParseIP("5.6.7.8")),
+	})
+
+	_, err = newOrderIdentifiers(&rapb.NewOrderRequest{
+		Names:           []string{"example.com", "1.2.3.4"},
+		TypeIdentifiers: []string{"dns"},
+	}, identifier.IP)
+	test.AssertErrorIs(t, err, errIncompleteGRPCRequest)
+
+	_, err = newOrderIdentifiers(&rapb.NewOrderRequest{
+		Names:           []string{"1.2.3.4", "1.2.3.4"},
+		TypeIdentifiers: []string{"dns", "ip"},
+	}, identifier.IP)
+	test.AssertErrorIs(t, err, berrors.Malformed)
+}
+
+func TestCheckOrderNamesDNSTypedIP(t *testing.T) {
+	pa, err := policy.New(map[core.AcmeChallenge]bool{})
+	test.AssertNotError(t, err, "Couldn't create PA")
+	err = pa.SetIPAddressPolicy(nil, false)
+	test.AssertNotError(t, err, "Couldn't set IP address policy")
+	ra := &RegistrationAuthorityImpl{PA: pa}
+
+	err = ra.checkOrderNames([]identifier.ACMEIdentifier{identifier.IPIdentifier(net.ParseIP("1.2.3.4"))})
+	test.AssertNotError(t, err, "IP identifier should be accepted")
+
+	err = ra.checkOrderNames([]identifier.ACMEIdentifier{identifier.DNSIdentifier("1.2.3.4")})
+	test.AssertErrorIs(t, err, berrors.RejectedIdentifier)
+	test.AssertContains(t, err.Error(), "can not issue a certificate for an IP address")
+}
+
 // mockCAFailPrecert is a mock CA that always returns an error from `IssuePrecertificate`
 type mockCAFailPrecert struct {
 	mocks.MockCA
@@ -3405,7 +3721,7 @@ func TestIssueCertificateInnerErrs(t *testing.T) {
 			// Mock the CA
 			ra.CA = tc.Mock
 			// Attempt issuance
-			_, err = ra.issueCertificateInner(ctx, req, accountID(Registration.Id), orderID(order.Id), issuance.IssuerNameID(0), logEvent)
+			_, err = ra.issueCertificateInner(ctx, req, accountID(Registration.Id), orderID(order.Id), issuance.IssuerNameID(0), logEvent, "dns", 0, 0, "")
 			// We expect all of the testcases to fail because all use mocked CAs that deliberately error
 			test.AssertError(t, err, "issueCertificateInner with failing mock CA did not fail")
 			// If there is an expected `error` then match the error message
@@ -3499,9 +3815,10 @@ rA==
 type mockSARevocation struct {
 	mocks.StorageAuthority
 
-	known   *corepb.CertificateStatus
-	blocked []*sapb.AddBlockedKeyRequest
-	revoked map[string]int64
+	known     *corepb.CertificateStatus
+	blocked   []*sapb.AddBlockedKeyRequest
+	revoked   map[string]int64
+	responses map[string][]byte
 }
 
 func newMockSARevocation(known *x509.Certificate, clk clock.Clock) *mockSARevocation {
@@ -3511,8 +3828,9 @@ func newMockSARevocation(known *x509.Certificate, clk clock.Clock) *mockSARevoca
 			Serial:   core.SerialToString(known.SerialNumber),
 			IssuerID: int64(issuance.GetIssuerNameID(known)),
 		},
-		blocked: make([]*sapb.AddBlockedKeyRequest, 0),
-		revoked: make(map[string]int64),
+		blocked:   make([]*sapb.AddBlockedKeyRequest, 0),
+		revoked:   make(map[string]int64),
+		responses: make(map[string][]byte),
 	}
 }
 
@@ -3533,6 +3851,7 @@ func (msar *mockSARevocation) RevokeCertificate(_ context.Context, req *sapb.Rev
 		return nil, berrors.AlreadyRevokedError("already revoked")
 	}
 	msar.revoked[req.Serial] = req.Reason
+	msar.responses[req.Serial] = req.Response
 	msar.known.Status = string(core.OCSPStatusRevoked)
 	return &emptypb.Empty{}, nil
 }
@@ -3557,6 +3876,17 @@ func (mcao *mockCAOCSP) GenerateOCSP(context.Context, *capb.GenerateOCSPRequest,
 	return &capb.OCSPResponse{Response: []byte{1, 2, 3}}, nil
 }
 
+// mockCANoOCSP fails the test if it is asked to generate an OCSP response.
+type mockCANoOCSP struct {
+	mocks.MockCA
+	t *testing.T
+}
+
+func (mcno *mockCANoOCSP) GenerateOCSP(context.Context, *capb.GenerateOCSPRequest, ...grpc.CallOption) (*capb.OCSPResponse, error) {
+	mcno.t.Error("OCSP response generated for a short-lived certificate")
+	return nil, errors.New("unexpected OCSP generation")
+}
+
 type mockPurger struct{}
 
 func (mp *mockPurger) Purge(context.Context, *akamaipb.PurgeRequest, ...grpc.CallOption) (*emptypb.Empty, error) {
@@ -4084,3 +4414,69 @@ func TestAdministrativelyRevokeCertificate(t *testing.T) {
 	})
 	test.AssertError(t, err, "AdministrativelyRevokeCertificate should have failed with just serial for keyCompromise")
 }
+
+func TestRevokeShortLivedCertificate(t *testing.T) {
+	_, _, ra, clk, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.CA = &mockCANoOCSP{t: t}
+	ra.purger = &mockPurger{}
+
+	_, cert := test.ThrowAwayCert(t, 1)
+	ic, err := issuance.NewCertificate(cert)
+	test.AssertNotError(t, err, "failed to create issuer cert")
+	ra.issuersByNameID = map[issuance.IssuerNameID]*issuance.Certificate{
+		ic.NameID(): ic,
+	}
+	ra.issuersByID = map[issuance.IssuerID]*issuance.Certificate{
+		ic.ID(): ic,
+	}
+	serial := core.SerialToString(cert.SerialNumber)
+
+	// Revocation by the subscriber is recorded without an OCSP response.
+	mockSA := newMockSARevocation(cert, clk)
+	mockSA.known.IsShortLived = true
+	ra.SA = mockSA
+	_, err = ra.RevokeCertByApplicant(context.Background(), &rapb.RevokeCertByApplicantRequest{
+		Cert:  cert.Raw,
+		Code:  ocsp.Unspecified,
+		RegID: 1,
+	})
+	test.AssertNotError(t, err, "RevokeCertByApplicant failed")
+	test.AssertEquals(t, mockSA.revoked[serial], int64(ocsp.Unspecified))
+	test.AssertEquals(t, len(mockSA.responses[serial]), 0)
+
+	// So is administrative revocation, by certificate or by serial.
+	mockSA = newMockSARevocation(cert, clk)
+	mockSA.known.IsShortLived = true
+	ra.SA = mockSA
+	_, err = ra.AdministrativelyRevokeCertificate(context.Background(), &rapb.AdministrativelyRevokeCertificateRequest{
+		Cert:      cert.Raw,
+		Code:      ocsp.Superseded,
+		AdminName: "root",
+	})
+	test.AssertNotError(t, err, "AdministrativelyRevokeCertificate failed")
+	test.AssertEquals(t, mockSA.revoked[serial], int64(ocsp.Superseded))
+	test.AssertEquals(t, len(mockSA.responses[serial]), 0)
+
+	mockSA.revoked = make(map[string]int64)
+	_, err = ra.AdministrativelyRevokeCertificate(context.Background(), &rapb.AdministrativelyRevokeCertificateRequest{
+		Serial:    serial,
+		Code:      ocsp.Unspecified,
+		AdminName: "root",
+	})
+	test.AssertNotError(t, err, "AdministrativelyRevokeCertificate failed")
+	test.AssertEquals(t, len(mockSA.responses[serial]), 0)
+
+	// And by the deprecated revocation path.
+	mockSA = newMockSARevocation(cert, clk)
+	mockSA.known.IsShortLived = true
+	ra.SA = mockSA
+	_, err = ra.RevokeCertificateWithReg(context.Background(), &rapb.RevokeCertificateWithRegRequest{
+		Cert:  cert.Raw,
+		Code:  ocsp.Unspecified,
+		RegID: 0,
+	})
+	test.AssertNotError(t, err, "RevokeCertificateWithReg failed")
+	test.AssertEquals(t, len(mockSA.responses[serial]), 0)
+}
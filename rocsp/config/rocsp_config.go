@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -16,19 +17,44 @@ import (
 	"golang.org/x/crypto/ocsp"
 )
 
+// Redis topologies which may be selected by RedisConfig.Mode.
+const (
+	RedisModeCluster    = "cluster"
+	RedisModeSentinel   = "sentinel"
+	RedisModeStandalone = "standalone"
+)
+
 // RedisConfig contains the configuration needed to act as a Redis client.
 type RedisConfig struct {
 	// PasswordFile is a file containing the password for the Redis user.
 	cmd.PasswordConfig
-	// TLS contains the configuration to speak TLS with Redis.
+	// TLS contains the configuration to speak TLS with Redis. In sentinel
+	// mode it is used for connections to both the Sentinels and Redis.
 	TLS cmd.TLSConfig
 	// Username is a Redis username.
 	Username string
-	// Addrs is a list of IP address:port pairs.
+	// Addrs is a list of IP address:port pairs. In cluster mode these are
+	// seed nodes of the cluster, in sentinel mode they are the Sentinels, and
+	// in standalone mode there must be exactly one.
 	Addrs []string
 	// Timeout is a per-request timeout applied to all Redis requests.
 	Timeout cmd.ConfigDuration
 
+	// Mode is the topology of the Redis deployment: "cluster", "sentinel" or
+	// "standalone". Default is "cluster".
+	Mode string
+	// MasterName is the name of the primary monitored by the Sentinels.
+	// Required in sentinel mode, and not allowed otherwise.
+	MasterName string
+	// SentinelUsername is a username for the Sentinels, if they require one.
+	SentinelUsername string
+	// SentinelPasswordFile is a file containing the password for the
+	// Sentinels, if they require one.
+	SentinelPasswordFile string
+	// DB is the database to select after connecting. Only allowed in sentinel
+	// and standalone modes, since Redis Cluster supports only database 0.
+	DB int
+
 	// Maximum number of retries before giving up.
 	// Default is to not retry failed commands.
 	MaxRetries int
@@ -77,43 +103,25 @@ type RedisConfig struct {
 
 // MakeClient produces a *rocsp.WritingClient from a config.
 func MakeClient(c *RedisConfig, clk clock.Clock, stats prometheus.Registerer) (*rocsp.WritingClient, error) {
-	password, err := c.PasswordConfig.Pass()
+	rdb, err := MakeRedisClient(c)
 	if err != nil {
-		return nil, fmt.Errorf("loading password: %w", err)
+		return nil, err
 	}
+	return rocsp.NewWritingClient(rdb, c.Timeout.Duration, clk, stats), nil
+}
 
-	tlsConfig, err := c.TLS.Load()
+// MakeReadClient produces a *rocsp.Client from a config.
+func MakeReadClient(c *RedisConfig, clk clock.Clock, stats prometheus.Registerer) (*rocsp.Client, error) {
+	rdb, err := MakeRedisClient(c)
 	if err != nil {
-		return nil, fmt.Errorf("loading TLS config: %w", err)
+		return nil, err
 	}
-
-	timeout := c.Timeout.Duration
-
-	rdb := redis.NewClusterClient(&redis.ClusterOptions{
-		Addrs:     c.Addrs,
-		Username:  c.Username,
-		Password:  password,
-		TLSConfig: tlsConfig,
-
-		MaxRetries:      c.MaxRetries,
-		MinRetryBackoff: c.MinRetryBackoff.Duration,
-		MaxRetryBackoff: c.MaxRetryBackoff.Duration,
-		DialTimeout:     c.DialTimeout.Duration,
-		ReadTimeout:     c.ReadTimeout.Duration,
-		WriteTimeout:    c.WriteTimeout.Duration,
-
-		PoolSize:           c.PoolSize,
-		MinIdleConns:       c.MinIdleConns,
-		MaxConnAge:         c.MaxConnAge.Duration,
-		PoolTimeout:        c.PoolTimeout.Duration,
-		IdleTimeout:        c.IdleTimeout.Duration,
-		IdleCheckFrequency: c.IdleCheckFrequency.Duration,
-	})
-	return rocsp.NewWritingClient(rdb, timeout, clk, stats), nil
+	return rocsp.NewClient(rdb, c.Timeout.Duration, clk, stats), nil
 }
 
-// MakeReadClient produces a *rocsp.Client from a config.
-func MakeReadClient(c *RedisConfig, clk clock.Clock, stats prometheus.Registerer) (*rocsp.Client, error) {
+// MakeRedisClient produces a redis.UniversalClient for the topology selected
+// by the config's Mode.
+func MakeRedisClient(c *RedisConfig) (redis.UniversalClient, error) {
 	password, err := c.PasswordConfig.Pass()
 	if err != nil {
 		return nil, fmt.Errorf("loading password: %w", err)
@@ -124,28 +132,105 @@ func MakeReadClient(c *RedisConfig, clk clock.Clock, stats prometheus.Registerer
 		return nil, fmt.Errorf("loading TLS config: %w", err)
 	}
 
-	timeout := c.Timeout.Duration
-
-	rdb := redis.NewClusterClient(&redis.ClusterOptions{
-		Addrs:     c.Addrs,
-		Username:  c.Username,
-		Password:  password,
-		TLSConfig: tlsConfig,
-
-		MaxRetries:      c.MaxRetries,
-		MinRetryBackoff: c.MinRetryBackoff.Duration,
-		MaxRetryBackoff: c.MaxRetryBackoff.Duration,
-		DialTimeout:     c.DialTimeout.Duration,
-		ReadTimeout:     c.ReadTimeout.Duration,
-
-		PoolSize:           c.PoolSize,
-		MinIdleConns:       c.MinIdleConns,
-		MaxConnAge:         c.MaxConnAge.Duration,
-		PoolTimeout:        c.PoolTimeout.Duration,
-		IdleTimeout:        c.IdleTimeout.Duration,
-		IdleCheckFrequency: c.IdleCheckFrequency.Duration,
-	})
-	return rocsp.NewClient(rdb, timeout, clk, stats), nil
+	if len(c.Addrs) == 0 {
+		return nil, errors.New("at least one Redis address is required")
+	}
+
+	mode := c.Mode
+	if mode == "" {
+		mode = RedisModeCluster
+	}
+	if mode != RedisModeSentinel && (c.MasterName != "" || c.SentinelUsername != "" || c.SentinelPasswordFile != "") {
+		return nil, fmt.Errorf("masterName and sentinel credentials are only allowed in %q mode", RedisModeSentinel)
+	}
+
+	switch mode {
+	case RedisModeCluster:
+		if c.DB != 0 {
+			return nil, fmt.Errorf("db is not allowed in %q mode", RedisModeCluster)
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     c.Addrs,
+			Username:  c.Username,
+			Password:  password,
+			TLSConfig: tlsConfig,
+
+			MaxRetries:      c.MaxRetries,
+			MinRetryBackoff: c.MinRetryBackoff.Duration,
+			MaxRetryBackoff: c.MaxRetryBackoff.Duration,
+			DialTimeout:     c.DialTimeout.Duration,
+			ReadTimeout:     c.ReadTimeout.Duration,
+			WriteTimeout:    c.WriteTimeout.Duration,
+
+			PoolSize:           c.PoolSize,
+			MinIdleConns:       c.MinIdleConns,
+			MaxConnAge:         c.MaxConnAge.Duration,
+			PoolTimeout:        c.PoolTimeout.Duration,
+			IdleTimeout:        c.IdleTimeout.Duration,
+			IdleCheckFrequency: c.IdleCheckFrequency.Duration,
+		}), nil
+
+	case RedisModeSentinel:
+		if c.MasterName == "" {
+			return nil, fmt.Errorf("masterName is required in %q mode", RedisModeSentinel)
+		}
+		sentinelPasswordConfig := cmd.PasswordConfig{PasswordFile: c.SentinelPasswordFile}
+		sentinelPassword, err := sentinelPasswordConfig.Pass()
+		if err != nil {
+			return nil, fmt.Errorf("loading sentinel password: %w", err)
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       c.MasterName,
+			SentinelAddrs:    c.Addrs,
+			SentinelUsername: c.SentinelUsername,
+			SentinelPassword: sentinelPassword,
+			Username:         c.Username,
+			Password:         password,
+			DB:               c.DB,
+			TLSConfig:        tlsConfig,
+
+			MaxRetries:      c.MaxRetries,
+			MinRetryBackoff: c.MinRetryBackoff.Duration,
+			MaxRetryBackoff: c.MaxRetryBackoff.Duration,
+			DialTimeout:     c.DialTimeout.Duration,
+			ReadTimeout:     c.ReadTimeout.Duration,
+			WriteTimeout:    c.WriteTimeout.Duration,
+
+			PoolSize:           c.PoolSize,
+			MinIdleConns:       c.MinIdleConns,
+			MaxConnAge:         c.MaxConnAge.Duration,
+			PoolTimeout:        c.PoolTimeout.Duration,
+			IdleTimeout:        c.IdleTimeout.Duration,
+			IdleCheckFrequency: c.IdleCheckFrequency.Duration,
+		}), nil
+
+	case RedisModeStandalone:
+		if len(c.Addrs) != 1 {
+			return nil, fmt.Errorf("exactly one address is required in %q mode, got %d", RedisModeStandalone, len(c.Addrs))
+		}
+		return redis.NewClient(&redis.Options{
+			Addr:      c.Addrs[0],
+			Username:  c.Username,
+			Password:  password,
+			DB:        c.DB,
+			TLSConfig: tlsConfig,
+
+			MaxRetries:      c.MaxRetries,
+			MinRetryBackoff: c.MinRetryBackoff.Duration,
+			MaxRetryBackoff: c.MaxRetryBackoff.Duration,
+			DialTimeout:     c.DialTimeout.Duration,
+			ReadTimeout:     c.ReadTimeout.Duration,
+			WriteTimeout:    c.WriteTimeout.Duration,
+
+			PoolSize:           c.PoolSize,
+			MinIdleConns:       c.MinIdleConns,
+			MaxConnAge:         c.MaxConnAge.Duration,
+			PoolTimeout:        c.PoolTimeout.Duration,
+			IdleTimeout:        c.IdleTimeout.Duration,
+			IdleCheckFrequency: c.IdleCheckFrequency.Duration,
+		}), nil
+	}
+	return nil, fmt.Errorf("unknown Redis mode %q", c.Mode)
 }
 
 // A ShortIDIssuer combines an issuance.Certificate with some fields necessary
@@ -209,16 +294,22 @@ func FindIssuerByID(longID int64, issuers []ShortIDIssuer) (*ShortIDIssuer, erro
 }
 
 // FindIssuerByName returns the issuer with a Subject matching the *ocsp.Response.
+// If the response was signed by a delegated responder, the issuer is that
+// responder certificate's issuer.
 func FindIssuerByName(resp *ocsp.Response, issuers []ShortIDIssuer) (*ShortIDIssuer, error) {
+	issuerName := resp.RawResponderName
+	if responder := issuance.DelegatedOCSPResponder(resp); responder != nil {
+		issuerName = responder.RawIssuer
+	}
 	var responder pkix.RDNSequence
-	_, err := asn1.Unmarshal(resp.RawResponderName, &responder)
+	_, err := asn1.Unmarshal(issuerName, &responder)
 	if err != nil {
 		return nil, fmt.Errorf("parsing resp.RawResponderName: %w", err)
 	}
 	var responders strings.Builder
 	for _, issuer := range issuers {
 		fmt.Fprintf(&responders, "%s\n", issuer.subject)
-		if bytes.Equal(issuer.RawSubject, resp.RawResponderName) {
+		if bytes.Equal(issuer.RawSubject, issuerName) {
 			return &issuer, nil
 		}
 	}
@@ -0,0 +1,75 @@
+package rocsp_config
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func redisConfig(mode string, addrs ...string) *RedisConfig {
+	caCertFile := "../../test/redis-tls/minica.pem"
+	certFile := "../../test/redis-tls/boulder/cert.pem"
+	keyFile := "../../test/redis-tls/boulder/key.pem"
+	return &RedisConfig{
+		TLS: cmd.TLSConfig{
+			CACertFile: &caCertFile,
+			CertFile:   &certFile,
+			KeyFile:    &keyFile,
+		},
+		Username: "boulder",
+		Addrs:    addrs,
+		Mode:     mode,
+	}
+}
+
+func TestMakeRedisClient(t *testing.T) {
+	rdb, err := MakeRedisClient(redisConfig("", "10.33.33.2:4218", "10.33.33.3:4218"))
+	test.AssertNotError(t, err, "making default client")
+	cluster, ok := rdb.(*redis.ClusterClient)
+	test.Assert(t, ok, "default mode should produce a cluster client")
+	test.AssertDeepEquals(t, cluster.Options().Addrs, []string{"10.33.33.2:4218", "10.33.33.3:4218"})
+	test.AssertNotNil(t, cluster.Options().TLSConfig, "TLS config should be set")
+
+	conf := redisConfig(RedisModeSentinel, "10.33.33.2:26379", "10.33.33.3:26379")
+	conf.MasterName = "primary"
+	conf.DB = 2
+	rdb, err = MakeRedisClient(conf)
+	test.AssertNotError(t, err, "making sentinel client")
+	failover, ok := rdb.(*redis.Client)
+	test.Assert(t, ok, "sentinel mode should produce a failover client")
+	test.AssertEquals(t, failover.Options().DB, 2)
+	test.AssertNotNil(t, failover.Options().TLSConfig, "TLS config should be set")
+
+	rdb, err = MakeRedisClient(redisConfig(RedisModeStandalone, "10.33.33.2:4218"))
+	test.AssertNotError(t, err, "making standalone client")
+	single, ok := rdb.(*redis.Client)
+	test.Assert(t, ok, "standalone mode should produce a single-node client")
+	test.AssertEquals(t, single.Options().Addr, "10.33.33.2:4218")
+	test.AssertNotNil(t, single.Options().TLSConfig, "TLS config should be set")
+}
+
+func TestMakeRedisClientInvalid(t *testing.T) {
+	_, err := MakeRedisClient(redisConfig("ring", "10.33.33.2:4218"))
+	test.AssertError(t, err, "unknown mode should be rejected")
+
+	_, err = MakeRedisClient(redisConfig(RedisModeCluster))
+	test.AssertError(t, err, "missing addresses should be rejected")
+
+	_, err = MakeRedisClient(redisConfig(RedisModeSentinel, "10.33.33.2:26379"))
+	test.AssertError(t, err, "sentinel mode without masterName should be rejected")
+
+	conf := redisConfig(RedisModeCluster, "10.33.33.2:4218")
+	conf.MasterName = "primary"
+	_, err = MakeRedisClient(conf)
+	test.AssertError(t, err, "masterName outside sentinel mode should be rejected")
+
+	conf = redisConfig(RedisModeCluster, "10.33.33.2:4218")
+	conf.DB = 1
+	_, err = MakeRedisClient(conf)
+	test.AssertError(t, err, "db in cluster mode should be rejected")
+
+	_, err = MakeRedisClient(redisConfig(RedisModeStandalone, "10.33.33.2:4218", "10.33.33.3:4218"))
+	test.AssertError(t, err, "multiple addresses in standalone mode should be rejected")
+}
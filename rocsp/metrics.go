@@ -6,7 +6,7 @@ import (
 )
 
 type metricsCollector struct {
-	rdb *redis.ClusterClient
+	rdb redis.UniversalClient
 
 	// Stats accessible from the go-redis connector:
 	// https://pkg.go.dev/github.com/go-redis/redis@v6.15.9+incompatible/internal/pool#Stats
@@ -24,7 +24,7 @@ func (dbc metricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	prometheus.DescribeByCollect(dbc, ch)
 }
 
-// Collect first triggers the Redis ClusterClient's PoolStats function.
+// Collect first triggers the Redis client's PoolStats function.
 // Then it creates constant metrics for each Stats value on the fly based
 // on the returned data.
 //
@@ -46,3 +46,17 @@ func (dbc metricsCollector) Collect(ch chan<- prometheus.Metric) {
 	writeGauge(dbc.idleConns, float64(stats.IdleConns))
 	writeGauge(dbc.staleConns, float64(stats.StaleConns))
 }
+
+// poolLabels returns the constant labels which identify the connection pool
+// of rdb in metrics.
+func poolLabels(rdb redis.UniversalClient) prometheus.Labels {
+	switch rdb := rdb.(type) {
+	case *redis.ClusterClient:
+		return prometheus.Labels{"address": rdb.Options().Addrs[0], "user": rdb.Options().Username}
+	case *redis.Client:
+		// For a client created by redis.NewFailoverClient the address is the
+		// placeholder "FailoverClient", since the primary may change.
+		return prometheus.Labels{"address": rdb.Options().Addr, "user": rdb.Options().Username}
+	}
+	return prometheus.Labels{"address": "", "user": ""}
+}
@@ -82,7 +82,7 @@ func SerialFromMetadataKey(key string) (string, error) {
 
 // Client represents a read-only Redis client.
 type Client struct {
-	rdb        *redis.ClusterClient
+	rdb        redis.UniversalClient
 	timeout    time.Duration
 	clk        clock.Clock
 	rdc        metricsCollector
@@ -90,16 +90,18 @@ type Client struct {
 }
 
 // NewClient creates a Client. The timeout applies to all requests, though a shorter timeout can be
-// applied on a per-request basis using context.Context.
+// applied on a per-request basis using context.Context. The rdb may be a
+// *redis.ClusterClient, or a *redis.Client connected either to a single node
+// or, via redis.NewFailoverClient, to the primary monitored by Sentinel.
 func NewClient(
-	rdb *redis.ClusterClient,
+	rdb redis.UniversalClient,
 	timeout time.Duration,
 	clk clock.Clock,
 	stats prometheus.Registerer,
 ) *Client {
 	dbc := metricsCollector{rdb: rdb}
 
-	labels := prometheus.Labels{"address": rdb.Options().Addrs[0], "user": rdb.Options().Username}
+	labels := poolLabels(rdb)
 	dbc.hits = prometheus.NewDesc(
 		"redis_hits",
 		"Number of times free connection was found in the pool.",
@@ -150,7 +152,7 @@ type WritingClient struct {
 }
 
 // NewWritingClient creates a WritingClient.
-func NewWritingClient(rdb *redis.ClusterClient, timeout time.Duration, clk clock.Clock, stats prometheus.Registerer) *WritingClient {
+func NewWritingClient(rdb redis.UniversalClient, timeout time.Duration, clk clock.Clock, stats prometheus.Registerer) *WritingClient {
 	storeResponseLatency := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name: "rocsp_store_response_latency",
@@ -303,7 +305,7 @@ func (c *Client) ScanResponses(ctx context.Context, serialPattern string) <-chan
 	results := make(chan ScanResponsesResult)
 	go func() {
 		defer close(results)
-		err := c.rdb.ForEachMaster(ctx, func(ctx context.Context, rdb *redis.Client) error {
+		err := c.forEachPrimary(ctx, func(ctx context.Context, rdb *redis.Client) error {
 			iter := rdb.Scan(ctx, 0, pattern, 0).Iterator()
 			for iter.Next(ctx) {
 				key := iter.Val()
@@ -329,6 +331,18 @@ func (c *Client) ScanResponses(ctx context.Context, serialPattern string) <-chan
 	return results
 }
 
+// forEachPrimary calls fn for each primary node: every master of a Redis
+// Cluster, or the one node a *redis.Client is connected to.
+func (c *Client) forEachPrimary(ctx context.Context, fn func(context.Context, *redis.Client) error) error {
+	switch rdb := c.rdb.(type) {
+	case *redis.ClusterClient:
+		return rdb.ForEachMaster(ctx, fn)
+	case *redis.Client:
+		return fn(ctx, rdb)
+	}
+	return fmt.Errorf("unsupported Redis client type %T", c.rdb)
+}
+
 // ScanMetadataResult represents a single OCSP response entry in redis.
 // `Serial` is the stringified serial number of the response. `Metadata` is the
 // parsed metadata. If this object represents an error, `Err` will
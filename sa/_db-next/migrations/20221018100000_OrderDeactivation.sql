@@ -0,0 +1 @@
+../../_db/migrations/20221018100000_OrderDeactivation.sql
\ No newline at end of file
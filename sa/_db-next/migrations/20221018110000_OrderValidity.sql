@@ -0,0 +1 @@
+../../_db/migrations/20221018110000_OrderValidity.sql
\ No newline at end of file
@@ -0,0 +1 @@
+../../_db/migrations/20221018120000_OrderCertProfile.sql
\ No newline at end of file
@@ -0,0 +1 @@
+../../_db/migrations/20221025100000_CertificateStatusCRLShard.sql
\ No newline at end of file
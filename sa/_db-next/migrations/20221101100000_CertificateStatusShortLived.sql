@@ -0,0 +1 @@
+../../_db/migrations/20221101100000_CertificateStatusShortLived.sql
\ No newline at end of file
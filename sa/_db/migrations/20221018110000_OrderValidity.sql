@@ -0,0 +1,12 @@
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TABLE `orders` ADD COLUMN `notBefore` datetime DEFAULT NULL,
+                     ADD COLUMN `notAfter` datetime DEFAULT NULL;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+ALTER TABLE `orders` DROP COLUMN `notBefore`,
+                     DROP COLUMN `notAfter`;
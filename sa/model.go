@@ -140,6 +140,7 @@ func CertStatusMetadataFields() []string {
 		"notAfter",
 		"isExpired",
 		"issuerID",
+		"isShortLived",
 	}
 }
 
@@ -172,6 +173,7 @@ func ScanCertStatusMetadataRow(rows *sql.Rows, status *CertStatusMetadata) error
 		&status.NotAfter,
 		&status.IsExpired,
 		&status.IssuerID,
+		&status.IsShortLived,
 	)
 	if err != nil {
 		return err
@@ -378,6 +380,10 @@ type orderModel struct {
 	CertificateSerial string
 	BeganProcessing   bool
 	TypeIdentifier    string
+	Deactivated       bool
+	NotBefore         *time.Time
+	NotAfter          *time.Time
+	CertProfile       string
 }
 
 type requestedNameModel struct {
@@ -399,6 +405,17 @@ func orderToModel(order *corepb.Order) (*orderModel, error) {
 		Created:           time.Unix(0, order.Created),
 		BeganProcessing:   order.BeganProcessing,
 		CertificateSerial: order.CertificateSerial,
+		TypeIdentifier:    order.TypeIdentifier,
+		CertProfile:       order.CertProfile,
+	}
+	// The notBefore and notAfter columns store whole seconds.
+	if order.NotBefore != 0 {
+		notBefore := time.Unix(0, order.NotBefore).Truncate(time.Second)
+		om.NotBefore = &notBefore
+	}
+	if order.NotAfter != 0 {
+		notAfter := time.Unix(0, order.NotAfter).Truncate(time.Second)
+		om.NotAfter = &notAfter
 	}
 
 	if order.Error != nil {
@@ -423,6 +440,18 @@ func modelToOrder(om *orderModel) (*corepb.Order, error) {
 		CertificateSerial: om.CertificateSerial,
 		BeganProcessing:   om.BeganProcessing,
 		TypeIdentifier:    om.TypeIdentifier,
+		CertProfile:       om.CertProfile,
+	}
+	if om.NotBefore != nil {
+		order.NotBefore = om.NotBefore.UnixNano()
+	}
+	if om.NotAfter != nil {
+		order.NotAfter = om.NotAfter.UnixNano()
+	}
+	// A deactivated order keeps its status regardless of the state of its
+	// authorizations, see statusForOrder.
+	if om.Deactivated {
+		order.Status = string(core.StatusDeactivated)
 	}
 	if len(om.Error) > 0 {
 		var problem corepb.ProblemDetails
@@ -443,6 +472,7 @@ var challTypeToUint = map[string]uint8{
 	"dns-01":         1,
 	"tls-alpn-01":    2,
 	"trusted-jwt-01": 3,
+	"dns-account-01": 4,
 }
 
 var uintToChallType = map[uint8]string{
@@ -450,16 +480,19 @@ var uintToChallType = map[uint8]string{
 	1: "dns-01",
 	2: "tls-alpn-01",
 	3: "trusted-jwt-01",
+	4: "dns-account-01",
 }
 
 var identifierTypeToUint = map[string]uint8{
 	"dns": 0,
 	"jwt": 1,
+	"ip":  2,
 }
 
 var uintToIdentifierType = map[uint8]string{
 	0: "dns",
 	1: "jwt",
+	2: "ip",
 }
 
 var statusToUint = map[core.AcmeStatus]uint8{
@@ -520,11 +553,9 @@ func hasMultipleNonPendingChallenges(challenges []*corepb.Challenge) bool {
 // authzPBToModel converts a protobuf authorization representation to the
 // authzModel storage representation.
 func authzPBToModel(authz *corepb.Authorization) (*authzModel, error) {
-	var identType uint8
-	if authz.TypeIdentifier == string(identifier.DNS) {
-		identType = 0
-	} else {
-		identType = 1
+	identType, ok := identifierTypeToUint[authz.TypeIdentifier]
+	if !ok {
+		identType = identifierTypeToUint[string(identifier.JWT)]
 	}
 	am := &authzModel{
 		IdentifierValue: authz.Identifier,
@@ -654,11 +685,9 @@ func populateAttemptedFields(am authzModel, challenge *corepb.Challenge) error {
 }
 
 func modelToAuthzPB(am authzModel) (*corepb.Authorization, error) {
-	var identType string
-	if am.IdentifierType == 0 {
-		identType = string(identifier.DNS)
-	} else {
-		identType = string(identifier.JWT)
+	identType, ok := uintToIdentifierType[am.IdentifierType]
+	if !ok {
+		return nil, fmt.Errorf("unknown identifier type: %d on authz id %d", am.IdentifierType, am.ID)
 	}
 	pb := &corepb.Authorization{
 		Id:             fmt.Sprintf("%d", am.ID),
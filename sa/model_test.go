@@ -250,12 +250,12 @@ func TestScanCertStatusMetadataRow(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error, got none")
 	}
-	expected := "incorrect number of columns in scanned rows: got 3, expected 10"
+	expected := "incorrect number of columns in scanned rows: got 3, expected 11"
 	if err.Error() != expected {
 		t.Errorf("wrong error: got %q, expected %q", err, expected)
 	}
 
-	rows, err = sa.dbMap.Query("SELECT id, status, serial, ocspLastUpdated, revokedDate, revokedReason, lastExpirationNagSent, notAfter, isExpired, issuerID FROM certificateStatus")
+	rows, err = sa.dbMap.Query("SELECT id, status, serial, ocspLastUpdated, revokedDate, revokedReason, lastExpirationNagSent, notAfter, isExpired, issuerID, isShortLived FROM certificateStatus")
 	test.AssertNotError(t, err, "selecting")
 
 	if !rows.Next() {
@@ -343,3 +343,44 @@ func makeKey() rsa.PrivateKey {
 	q := bigIntFromB64("uKE2dh-cTf6ERF4k4e_jy78GfPYUIaUyoSSJuBzp3Cubk3OCqs6grT8bR_cu0Dm1MZwWmtdqDyI95HrUeq3MP15vMMON8lHTeZu2lmKvwqW7anV5UzhM1iZ7z4yMkuUwFWoBvyY898EXvRD-hdqRxHlSqAZ192zB3pVFJ0s7pFc=")
 	return rsa.PrivateKey{PublicKey: rsa.PublicKey{N: n, E: e}, D: d, Primes: []*big.Int{p, q}}
 }
+
+func TestOrderModelRoundTrip(t *testing.T) {
+	clk := clock.NewFake()
+	notBefore := clk.Now().Add(-time.Hour)
+	notAfter := clk.Now().Add(24 * time.Hour)
+	order := &corepb.Order{
+		Id:             1,
+		RegistrationID: 2,
+		Expires:        clk.Now().Add(7 * 24 * time.Hour).UnixNano(),
+		Created:        clk.Now().UnixNano(),
+		TypeIdentifier: "dns",
+		NotBefore:      notBefore.UnixNano(),
+		NotAfter:       notAfter.UnixNano(),
+		CertProfile:    "shortlived",
+	}
+
+	model, err := orderToModel(order)
+	test.AssertNotError(t, err, "orderToModel failed")
+	test.AssertNotNil(t, model.NotBefore, "model should have a notBefore")
+	test.AssertNotNil(t, model.NotAfter, "model should have a notAfter")
+
+	roundTripped, err := modelToOrder(model)
+	test.AssertNotError(t, err, "modelToOrder failed")
+	test.AssertEquals(t, roundTripped.TypeIdentifier, order.TypeIdentifier)
+	test.AssertEquals(t, roundTripped.NotBefore, order.NotBefore)
+	test.AssertEquals(t, roundTripped.NotAfter, order.NotAfter)
+	test.AssertEquals(t, roundTripped.CertProfile, order.CertProfile)
+
+	// An order without a requested validity period should have none after
+	// being stored and loaded.
+	order.NotBefore = 0
+	order.NotAfter = 0
+	model, err = orderToModel(order)
+	test.AssertNotError(t, err, "orderToModel failed")
+	test.Assert(t, model.NotBefore == nil, "model should not have a notBefore")
+	test.Assert(t, model.NotAfter == nil, "model should not have a notAfter")
+	roundTripped, err = modelToOrder(model)
+	test.AssertNotError(t, err, "modelToOrder failed")
+	test.AssertEquals(t, roundTripped.NotBefore, int64(0))
+	test.AssertEquals(t, roundTripped.NotAfter, int64(0))
+}
@@ -88,6 +88,13 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 		Expires:        parsed.NotAfter,
 	}
 
+	// Short-lived certificates never have an OCSP response, so they are
+	// recorded as never having been updated.
+	ocspLastUpdated := ssa.clk.Now()
+	if req.ShortLived {
+		ocspLastUpdated = time.Time{}
+	}
+
 	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
 		// Select to see if precert exists
 		var row struct {
@@ -110,7 +117,7 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 			&core.CertificateStatus{
 				Serial:                serialHex,
 				Status:                core.OCSPStatusGood,
-				OCSPLastUpdated:       ssa.clk.Now(),
+				OCSPLastUpdated:       ocspLastUpdated,
 				RevokedDate:           time.Time{},
 				RevokedReason:         0,
 				LastExpirationNagSent: time.Time{},
@@ -118,6 +125,8 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 				NotAfter:              parsed.NotAfter,
 				IsExpired:             false,
 				IssuerID:              req.IssuerID,
+				CRLShard:              req.CrlShard,
+				IsShortLived:          req.ShortLived,
 			},
 		)
 		if err != nil {
@@ -156,7 +165,7 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 	// Store the OCSP response in Redis (if configured) on a best effort
 	// basis. We don't want to fail on an error here while mysql is the
 	// source of truth.
-	if ssa.rocspWriteClient != nil {
+	if ssa.rocspWriteClient != nil && !req.ShortLived {
 		// Use a new context for the goroutine. We aren't going to wait on
 		// the goroutine to complete, so we don't want it to be canceled
 		// when the parent function ends. The rocsp client has a
@@ -160,6 +160,30 @@ func TestAddPrecertificate(t *testing.T) {
 	addPrecert(true)
 }
 
+func TestAddPrecertificateShortLived(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := createWorkingRegistration(t, sa)
+	serial, testCert := test.ThrowAwayCert(t, 1)
+	_, err := sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:        testCert.Raw,
+		RegID:      reg.Id,
+		Issued:     time.Date(2018, 4, 1, 7, 0, 0, 0, time.UTC).UnixNano(),
+		IssuerID:   1,
+		ShortLived: true,
+	})
+	test.AssertNotError(t, err, "Couldn't add short-lived test cert")
+
+	// A short-lived certificate has no OCSP response, and has never been
+	// updated.
+	certStatus, err := sa.GetCertificateStatus(ctx, &sapb.Serial{Serial: serial})
+	test.AssertNotError(t, err, "Couldn't get status for test cert")
+	test.Assert(t, certStatus.IsShortLived, "certificate status should be short-lived")
+	test.AssertEquals(t, len(certStatus.OcspResponse), 0)
+	test.AssertEquals(t, certStatus.OcspLastUpdated, time.Time{}.UnixNano())
+}
+
 func TestAddPreCertificateDuplicate(t *testing.T) {
 	sa, clk, cleanUp := initSA(t)
 	defer cleanUp()
@@ -105,6 +105,25 @@ func addNewOrdersRateLimit(ctx context.Context, dbMap db.SelectExecer, regID int
 	return nil
 }
 
+// removeNewOrdersRateLimit subtracts 1 from the rate limit count for the
+// provided ID, in the time bucket the order was counted in. It's used when an
+// order is deactivated so that it no longer counts against the account. The
+// input timeToTheMinute must be a time rounded to a minute.
+func removeNewOrdersRateLimit(ctx context.Context, dbMap db.SelectExecer, regID int64, timeToTheMinute time.Time) error {
+	_, err := dbMap.Exec(`UPDATE newOrdersRL
+		SET count=count-1
+		WHERE regID = ? AND
+		time = ? AND
+		count > 0;`,
+		regID,
+		timeToTheMinute,
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // countNewOrders returns the count of orders created in the given time range
 // for the given registration ID
 func countNewOrders(ctx context.Context, dbMap db.Selector, req *sapb.CountOrdersRequest) (*sapb.Count, error) {
@@ -579,7 +579,8 @@ func (ssa *SQLStorageAuthority) CountOrders(ctx context.Context, req *sapb.Count
 		`SELECT count(1) FROM orders
 		WHERE registrationID = :acctID AND
 		created >= :earliest AND
-		created < :latest`,
+		created < :latest AND
+		deactivated = false`,
 		map[string]interface{}{
 			"acctID":   req.AccountID,
 			"earliest": time.Unix(0, req.Range.Earliest),
@@ -965,13 +966,19 @@ func (ssa *SQLStorageAuthority) NewOrderAndAuthzs(ctx context.Context, req *sapb
 			}
 		}
 		// Second, insert the new order.
-		order := &orderModel{
+		order, err := orderToModel(&corepb.Order{
 			RegistrationID: req.NewOrder.RegistrationID,
-			Expires:        time.Unix(0, req.NewOrder.Expires),
-			Created:        ssa.clk.Now(),
+			Expires:        req.NewOrder.Expires,
+			Created:        ssa.clk.Now().UnixNano(),
 			TypeIdentifier: req.NewOrder.TypeIdentifier,
+			NotBefore:      req.NewOrder.NotBefore,
+			NotAfter:       req.NewOrder.NotAfter,
+			CertProfile:    req.NewOrder.CertProfile,
+		})
+		if err != nil {
+			return nil, err
 		}
-		err := txWithCtx.Insert(order)
+		err = txWithCtx.Insert(order)
 		if err != nil {
 			return nil, err
 		}
@@ -1030,6 +1037,9 @@ func (ssa *SQLStorageAuthority) NewOrderAndAuthzs(ctx context.Context, req *sapb
 			// A new order is never processing because it can't be finalized yet.
 			BeganProcessing: false,
 			TypeIdentifier:  req.NewOrder.TypeIdentifier,
+			NotBefore:       req.NewOrder.NotBefore,
+			NotAfter:        req.NewOrder.NotAfter,
+			CertProfile:     req.NewOrder.CertProfile,
 		}, nil
 	})
 	if err != nil {
@@ -1130,6 +1140,116 @@ func (ssa *SQLStorageAuthority) SetOrderError(ctx context.Context, req *sapb.Set
 	return &emptypb.Empty{}, nil
 }
 
+// DeactivateOrder marks a pending or ready order as deactivated, and
+// deactivates any of the order's authorizations that are still pending and
+// not referenced by another pending or ready order. Both happen in a single
+// transaction. Orders that have begun processing can't be deactivated.
+func (ssa *SQLStorageAuthority) DeactivateOrder(ctx context.Context, req *sapb.OrderRequest) (*emptypb.Empty, error) {
+	if req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+	_, overallError := db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
+		result, err := txWithCtx.Exec(`
+		UPDATE orders
+		SET deactivated = ?
+		WHERE id = ?
+		AND deactivated = ?
+		AND beganProcessing = ?`,
+			true,
+			req.Id,
+			false,
+			false)
+		if err != nil {
+			return nil, berrors.InternalServerError("error updating order to deactivated status")
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil || n == 0 {
+			return nil, berrors.MalformedError("Order is already deactivated or has begun processing")
+		}
+
+		if features.Enabled(features.FasterNewOrdersRateLimit) {
+			// Give the order's creation back to the account so that the
+			// deactivated order no longer counts against its new orders limit
+			var om orderModel
+			err = txWithCtx.SelectOne(
+				&om,
+				"SELECT registrationID, created FROM orders WHERE id = ?",
+				req.Id,
+			)
+			if err != nil {
+				return nil, err
+			}
+			err = removeNewOrdersRateLimit(ctx, txWithCtx, om.RegistrationID, om.Created.Truncate(time.Minute))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Authzs may be shared with the account's other orders, so only the
+		// ones that no other unexpired pending or ready order refers to are
+		// deactivated.
+		var authzIDs []int64
+		_, err = txWithCtx.Select(
+			&authzIDs,
+			`SELECT authzID FROM orderToAuthz2
+			WHERE orderID = ?
+			AND authzID NOT IN (
+				SELECT ota.authzID FROM orderToAuthz2 AS ota
+				JOIN orders AS o ON o.id = ota.orderID
+				WHERE ota.orderID != ?
+				AND o.expires > ?
+				AND o.beganProcessing = ?
+				AND o.deactivated = ?
+			)`,
+			req.Id,
+			req.Id,
+			ssa.clk.Now(),
+			false,
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(authzIDs) > 0 {
+			qmarks := make([]string, len(authzIDs))
+			params := []interface{}{
+				statusUint(core.StatusDeactivated),
+				statusUint(core.StatusPending),
+			}
+			for i, id := range authzIDs {
+				qmarks[i] = "?"
+				params = append(params, id)
+			}
+			_, err = txWithCtx.Exec(fmt.Sprintf(`
+			UPDATE authz2
+			SET status = ?
+			WHERE status = ?
+			AND id IN (%s)`, strings.Join(qmarks, ",")),
+				params...)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Delete the orderFQDNSet row for the order so that it is no longer
+		// considered for reuse by GetOrderForNames.
+		_, err = txWithCtx.Exec(`
+		DELETE FROM orderFqdnSets
+		WHERE orderID = ?`,
+			req.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	})
+	if overallError != nil {
+		return nil, overallError
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // FinalizeOrder finalizes a provided *corepb.Order by persisting the
 // CertificateSerial and a valid status to the database. No fields other than
 // CertificateSerial and the order ID on the provided order are processed (e.g.
@@ -1253,6 +1373,7 @@ func (ssa *SQLStorageAuthority) GetOrder(ctx context.Context, req *sapb.OrderReq
 
 // statusForOrder examines the status of a provided order's authorizations to
 // determine what the overall status of the order should be. In summary:
+//   * If the order was deactivated, the order is deactivated
 //   * If the order has an error, the order is invalid
 //   * If any of the order's authorizations are in any state other than
 //     valid or pending, the order is invalid.
@@ -1265,6 +1386,12 @@ func (ssa *SQLStorageAuthority) GetOrder(ctx context.Context, req *sapb.OrderReq
 //     processing, then the order is status ready.
 // An error is returned for any other case.
 func (ssa *SQLStorageAuthority) statusForOrder(ctx context.Context, order *corepb.Order) (string, error) {
+	// An order that was deactivated stays deactivated. modelToOrder sets the
+	// status for these orders from the order row.
+	if order.Status == string(core.StatusDeactivated) {
+		return string(core.StatusDeactivated), nil
+	}
+
 	// Without any further work we know an order with an error is invalid
 	if order.Error != nil {
 		return string(core.StatusInvalid), nil
@@ -1596,6 +1723,7 @@ func (ssa *SQLStorageAuthority) GetAuthorizations2(ctx context.Context, req *sap
 		statusUint(core.StatusPending),
 		time.Unix(0, req.Now),
 		identifierTypeToUint[string(identifier.DNS)],
+		identifierTypeToUint[string(identifier.IP)],
 	}
 
 	useIndex := ""
@@ -1615,7 +1743,7 @@ func (ssa *SQLStorageAuthority) GetAuthorizations2(ctx context.Context, req *sap
 			WHERE registrationID = ? AND
 			status IN (?,?) AND
 			expires > ? AND
-			identifierType = ? AND
+			identifierType IN (?,?) AND
 			identifierValue IN (%s)`,
 		authzFields,
 		useIndex,
@@ -1734,9 +1862,13 @@ func (ssa *SQLStorageAuthority) FinalizeAuthorization2(ctx context.Context, req
 // RevokeCertificate stores revocation information about a certificate. It will only store this
 // information if the certificate is not already marked as revoked.
 func (ssa *SQLStorageAuthority) RevokeCertificate(ctx context.Context, req *sapb.RevokeCertificateRequest) (*emptypb.Empty, error) {
-	if req.Serial == "" || req.Date == 0 || req.Response == nil {
+	if req.Serial == "" || req.Date == 0 {
 		return nil, errIncompleteRequest
 	}
+	err := ssa.checkRevocationResponse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	revokedDate := time.Unix(0, req.Date)
 	res, err := ssa.dbMap.Exec(
 		`UPDATE certificateStatus SET
@@ -1767,17 +1899,46 @@ func (ssa *SQLStorageAuthority) RevokeCertificate(ctx context.Context, req *sapb
 	return &emptypb.Empty{}, nil
 }
 
+// checkRevocationResponse returns an error if the revocation request has no
+// OCSP response, unless it's for a short-lived certificate. Those never have
+// OCSP responses, so they are revoked without one.
+func (ssa *SQLStorageAuthority) checkRevocationResponse(ctx context.Context, req *sapb.RevokeCertificateRequest) error {
+	if req.Response != nil {
+		return nil
+	}
+	var isShortLived bool
+	err := ssa.dbMap.WithContext(ctx).SelectOne(
+		&isShortLived,
+		"SELECT isShortLived FROM certificateStatus WHERE serial = ?",
+		req.Serial,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return berrors.NotFoundError("no certificate with serial %s", req.Serial)
+		}
+		return err
+	}
+	if !isShortLived {
+		return errIncompleteRequest
+	}
+	return nil
+}
+
 // UpdateRevokedCertificate stores new revocation information about an
 // already-revoked certificate. It will only store this information if the
 // cert is already revoked, if the new revocation reason is `KeyCompromise`,
 // and if the revokedDate is identical to the current revokedDate.
 func (ssa *SQLStorageAuthority) UpdateRevokedCertificate(ctx context.Context, req *sapb.RevokeCertificateRequest) (*emptypb.Empty, error) {
-	if req.Serial == "" || req.Date == 0 || req.Backdate == 0 || req.Response == nil {
+	if req.Serial == "" || req.Date == 0 || req.Backdate == 0 {
 		return nil, errIncompleteRequest
 	}
 	if req.Reason != ocsp.KeyCompromise {
 		return nil, fmt.Errorf("cannot update revocation for any reason other than keyCompromise (1); got: %d", req.Reason)
 	}
+	err := ssa.checkRevocationResponse(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	thisUpdate := time.Unix(0, req.Date)
 	revokedDate := time.Unix(0, req.Backdate)
 	res, err := ssa.dbMap.Exec(
@@ -1810,12 +1971,20 @@ func (ssa *SQLStorageAuthority) UpdateRevokedCertificate(ctx context.Context, re
 }
 
 // GetPendingAuthorization2 returns the most recent Pending authorization with
-// the given identifier, if available. This method only supports DNS identifier types.
-// TODO(#5816): Consider removing this method, as it has no callers.
+// the given identifier, if available. If no identifier type is given the
+// identifier is assumed to be a DNS name.
 func (ssa *SQLStorageAuthority) GetPendingAuthorization2(ctx context.Context, req *sapb.GetPendingAuthorizationRequest) (*corepb.Authorization, error) {
 	if req.RegistrationID == 0 || req.IdentifierValue == "" || req.ValidUntil == 0 {
 		return nil, errIncompleteRequest
 	}
+	identType := req.IdentifierType
+	if identType == "" {
+		identType = string(identifier.DNS)
+	}
+	identTypeInt, ok := identifierTypeToUint[identType]
+	if !ok {
+		return nil, fmt.Errorf("unknown identifier type: %q", identType)
+	}
 	var am authzModel
 	err := ssa.dbMap.WithContext(ctx).SelectOne(
 		&am,
@@ -1823,7 +1992,7 @@ func (ssa *SQLStorageAuthority) GetPendingAuthorization2(ctx context.Context, re
 			registrationID = :regID AND
 			status = :status AND
 			expires > :validUntil AND
-			identifierType = :dnsType AND
+			identifierType = :identType AND
 			identifierValue = :ident
 			ORDER BY expires ASC
 			LIMIT 1 `, authzFields),
@@ -1831,7 +2000,7 @@ func (ssa *SQLStorageAuthority) GetPendingAuthorization2(ctx context.Context, re
 			"regID":      req.RegistrationID,
 			"status":     statusUint(core.StatusPending),
 			"validUntil": time.Unix(0, req.ValidUntil),
-			"dnsType":    identifierTypeToUint[string(identifier.DNS)],
+			"identType":  identTypeInt,
 			"ident":      req.IdentifierValue,
 		},
 	)
@@ -1900,8 +2069,7 @@ func (ssa *SQLStorageAuthority) GetValidOrderAuthorizations2(ctx context.Context
 
 	byName := make(map[string]authzModel)
 	for _, am := range ams {
-		if uintToIdentifierType[am.IdentifierType] != string(identifier.DNS) &&
-			uintToIdentifierType[am.IdentifierType] != string(identifier.JWT) {
+		if _, ok := uintToIdentifierType[am.IdentifierType]; !ok {
 			return nil, fmt.Errorf("unknown identifier type: %q on authz id %d", am.IdentifierType, am.ID)
 		}
 		existing, present := byName[am.IdentifierValue]
@@ -1948,7 +2116,7 @@ func (ssa *SQLStorageAuthority) CountInvalidAuthorizations2(ctx context.Context,
 // GetValidAuthorizations2 returns the latest authorization for all
 // domain names that the account has authorizations for. This method is
 // intended to deprecate GetValidAuthorizations. This method only supports
-// DNS identifier types.
+// DNS and IP identifier types.
 func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error) {
 	if len(req.Domains) == 0 || req.RegistrationID == 0 || req.Now == 0 {
 		return nil, errIncompleteRequest
@@ -1960,6 +2128,7 @@ func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req
 		statusUint(core.StatusValid),
 		time.Unix(0, req.Now),
 		identifierTypeToUint[string(identifier.DNS)],
+		identifierTypeToUint[string(identifier.IP)],
 	}
 	qmarks := make([]string, len(req.Domains))
 	for i, n := range req.Domains {
@@ -1973,7 +2142,7 @@ func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req
 			registrationID = ? AND
 			status = ? AND
 			expires > ? AND
-			identifierType = ? AND
+			identifierType IN (?,?) AND
 			identifierValue IN (%s)`,
 			authzFields,
 			strings.Join(qmarks, ","),
@@ -1986,8 +2155,9 @@ func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req
 
 	authzMap := make(map[string]authzModel, len(authzModels))
 	for _, am := range authzModels {
-		// Only allow DNS identifiers
-		if uintToIdentifierType[am.IdentifierType] != string(identifier.DNS) {
+		// Only allow DNS and IP identifiers
+		if uintToIdentifierType[am.IdentifierType] != string(identifier.DNS) &&
+			uintToIdentifierType[am.IdentifierType] != string(identifier.IP) {
 			continue
 		}
 		// If there is an existing authorization in the map only replace it with one
@@ -2068,3 +2238,55 @@ func (ssa *SQLStorageAuthority) KeyBlocked(ctx context.Context, req *sapb.KeyBlo
 
 	return &sapb.Exists{Exists: true}, nil
 }
+
+// crlEntryModel is a subset of the certificateStatus table, holding just the
+// columns needed to produce a CRL entry.
+type crlEntryModel struct {
+	Serial        string            `db:"serial"`
+	RevokedReason revocation.Reason `db:"revokedReason"`
+	RevokedDate   time.Time         `db:"revokedDate"`
+}
+
+// GetRevokedCerts gets the serials, revocation reasons and revocation dates of
+// the certificates issued by the given issuer and assigned to the given CRL
+// shard which were revoked before req.RevokedBefore and expire in the range
+// [req.ExpiresAfter, req.ExpiresBefore). These are the entries of a CRL for
+// that issuer and shard.
+func (ssa *SQLStorageAuthority) GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest) (*sapb.RevokedCerts, error) {
+	if req.IssuerNameID == 0 || req.ExpiresAfter == 0 || req.ExpiresBefore == 0 || req.RevokedBefore == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	var rows []crlEntryModel
+	_, err := ssa.dbReadOnlyMap.WithContext(ctx).Select(
+		&rows,
+		`SELECT serial, revokedReason, revokedDate FROM certificateStatus
+			WHERE notAfter >= :expiresAfter AND
+			notAfter < :expiresBefore AND
+			issuerID = :issuerID AND
+			crlShard = :crlShard AND
+			status = :status AND
+			revokedDate < :revokedBefore`,
+		map[string]interface{}{
+			"expiresAfter":  time.Unix(0, req.ExpiresAfter),
+			"expiresBefore": time.Unix(0, req.ExpiresBefore),
+			"issuerID":      req.IssuerNameID,
+			"crlShard":      req.CrlShard,
+			"status":        string(core.OCSPStatusRevoked),
+			"revokedBefore": time.Unix(0, req.RevokedBefore),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*corepb.CRLEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = &corepb.CRLEntry{
+			Serial:    row.Serial,
+			Reason:    int32(row.RevokedReason),
+			RevokedAt: row.RevokedDate.UnixNano(),
+		}
+	}
+	return &sapb.RevokedCerts{Entries: entries}, nil
+}
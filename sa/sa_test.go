@@ -1010,6 +1010,126 @@ func TestSetOrderProcessing(t *testing.T) {
 	test.AssertErrorIs(t, err, berrors.OrderNotReady)
 }
 
+func TestDeactivateOrder(t *testing.T) {
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	// Create a test registration to reference
+	key, _ := jose.JSONWebKey{Key: &rsa.PublicKey{N: big.NewInt(1), E: 1}}.MarshalJSON()
+	initialIP, _ := net.ParseIP("42.42.42.42").MarshalText()
+	reg, err := sa.NewRegistration(ctx, &corepb.Registration{
+		Key:       key,
+		InitialIP: initialIP,
+	})
+	test.AssertNotError(t, err, "Couldn't create test registration")
+
+	// Add one valid and one pending authz
+	expires := fc.Now().Add(time.Hour)
+	validAuthzID := createFinalizedAuthorization(t, sa, "example.com", expires, "valid", fc.Now())
+	pendingAuthzID := createPendingAuthorization(t, sa, "www.example.com", expires)
+
+	names := []string{"example.com", "www.example.com"}
+	order, err := sa.NewOrder(context.Background(), &sapb.NewOrderRequest{
+		RegistrationID:   reg.Id,
+		Expires:          expires.UnixNano(),
+		Names:            names,
+		V2Authorizations: []int64{validAuthzID, pendingAuthzID},
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	test.AssertEquals(t, order.Status, string(core.StatusPending))
+
+	_, err = sa.DeactivateOrder(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "DeactivateOrder failed")
+
+	updatedOrder, err := sa.GetOrder(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertEquals(t, updatedOrder.Status, string(core.StatusDeactivated))
+
+	// Only the pending authz should have been deactivated
+	authz, err := sa.GetAuthorization2(context.Background(), &sapb.AuthorizationID2{Id: pendingAuthzID})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, authz.Status, string(core.StatusDeactivated))
+	authz, err = sa.GetAuthorization2(context.Background(), &sapb.AuthorizationID2{Id: validAuthzID})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, authz.Status, string(core.StatusValid))
+
+	// The deactivated authz no longer counts towards the pending authz limit
+	// of the account that holds it (createPendingAuthorization uses regID 1)
+	count, err := sa.CountPendingAuthorizations2(context.Background(), &sapb.RegistrationID{Id: 1})
+	test.AssertNotError(t, err, "CountPendingAuthorizations2 failed")
+	test.AssertEquals(t, count.Count, int64(0))
+
+	// The deactivated order shouldn't be reused
+	_, err = sa.GetOrderForNames(context.Background(), &sapb.GetOrderForNamesRequest{
+		AcctID: reg.Id,
+		Names:  names,
+	})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	// Deactivating the same order again should fail
+	_, err = sa.DeactivateOrder(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+}
+
+func TestDeactivateOrderSharedAuthz(t *testing.T) {
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	// Create a test registration to reference
+	key, _ := jose.JSONWebKey{Key: &rsa.PublicKey{N: big.NewInt(1), E: 1}}.MarshalJSON()
+	initialIP, _ := net.ParseIP("42.42.42.42").MarshalText()
+	reg, err := sa.NewRegistration(ctx, &corepb.Registration{
+		Key:       key,
+		InitialIP: initialIP,
+	})
+	test.AssertNotError(t, err, "Couldn't create test registration")
+
+	// Two orders share a pending authz, and each has one of its own
+	expires := fc.Now().Add(time.Hour)
+	sharedAuthzID := createPendingAuthorization(t, sa, "example.com", expires)
+	firstAuthzID := createPendingAuthorization(t, sa, "www.example.com", expires)
+	secondAuthzID := createPendingAuthorization(t, sa, "mail.example.com", expires)
+
+	order, err := sa.NewOrder(context.Background(), &sapb.NewOrderRequest{
+		RegistrationID:   reg.Id,
+		Expires:          expires.UnixNano(),
+		Names:            []string{"example.com", "www.example.com"},
+		V2Authorizations: []int64{sharedAuthzID, firstAuthzID},
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+	otherOrder, err := sa.NewOrder(context.Background(), &sapb.NewOrderRequest{
+		RegistrationID:   reg.Id,
+		Expires:          expires.UnixNano(),
+		Names:            []string{"example.com", "mail.example.com"},
+		V2Authorizations: []int64{sharedAuthzID, secondAuthzID},
+	})
+	test.AssertNotError(t, err, "NewOrder failed")
+
+	_, err = sa.DeactivateOrder(context.Background(), &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "DeactivateOrder failed")
+
+	// Only the authz that isn't shared should have been deactivated
+	authz, err := sa.GetAuthorization2(context.Background(), &sapb.AuthorizationID2{Id: firstAuthzID})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, authz.Status, string(core.StatusDeactivated))
+	authz, err = sa.GetAuthorization2(context.Background(), &sapb.AuthorizationID2{Id: sharedAuthzID})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, authz.Status, string(core.StatusPending))
+
+	// The other order should be unaffected
+	updatedOrder, err := sa.GetOrder(context.Background(), &sapb.OrderRequest{Id: otherOrder.Id})
+	test.AssertNotError(t, err, "GetOrder failed")
+	test.AssertEquals(t, updatedOrder.Status, string(core.StatusPending))
+
+	// Once the other order is deactivated too, the shared authz is no longer
+	// referenced by a live order and is deactivated with it
+	_, err = sa.DeactivateOrder(context.Background(), &sapb.OrderRequest{Id: otherOrder.Id})
+	test.AssertNotError(t, err, "DeactivateOrder failed")
+	authz, err = sa.GetAuthorization2(context.Background(), &sapb.AuthorizationID2{Id: sharedAuthzID})
+	test.AssertNotError(t, err, "GetAuthorization2 failed")
+	test.AssertEquals(t, authz.Status, string(core.StatusDeactivated))
+}
+
 func TestFinalizeOrder(t *testing.T) {
 	sa, fc, cleanup := initSA(t)
 	defer cleanup()
@@ -1251,6 +1371,62 @@ func TestCountOrders(t *testing.T) {
 	test.AssertEquals(t, count.Count, int64(0))
 }
 
+func TestCountOrdersDeactivated(t *testing.T) {
+	testCases := []struct {
+		name     string
+		features map[string]bool
+	}{
+		{name: "orders table"},
+		{name: "newOrdersRL table", features: map[string]bool{"FasterNewOrdersRateLimit": true}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sa, _, cleanUp := initSA(t)
+			defer cleanUp()
+
+			err := features.Set(tc.features)
+			test.AssertNotError(t, err, "Failed to set features")
+			defer features.Reset()
+
+			reg := createWorkingRegistration(t, sa)
+			now := sa.clk.Now()
+			expires := now.Add(24 * time.Hour)
+
+			req := &sapb.CountOrdersRequest{
+				AccountID: reg.Id,
+				Range: &sapb.Range{
+					Earliest: now.Add(-time.Hour).UnixNano(),
+					Latest:   now.Add(time.Minute).UnixNano(),
+				},
+			}
+
+			var orderIDs []int64
+			for _, name := range []string{"example.com", "www.example.com"} {
+				order, err := sa.NewOrder(ctx, &sapb.NewOrderRequest{
+					RegistrationID:   reg.Id,
+					Expires:          expires.UnixNano(),
+					Names:            []string{name},
+					V2Authorizations: []int64{createPendingAuthorization(t, sa, name, expires)},
+				})
+				test.AssertNotError(t, err, "Couldn't create new pending order")
+				orderIDs = append(orderIDs, order.Id)
+			}
+
+			count, err := sa.CountOrders(ctx, req)
+			test.AssertNotError(t, err, "Couldn't count new orders for reg ID")
+			test.AssertEquals(t, count.Count, int64(2))
+
+			// Deactivating an order should free up its place in the count
+			_, err = sa.DeactivateOrder(ctx, &sapb.OrderRequest{Id: orderIDs[0]})
+			test.AssertNotError(t, err, "DeactivateOrder failed")
+
+			count, err = sa.CountOrders(ctx, req)
+			test.AssertNotError(t, err, "Couldn't count new orders for reg ID")
+			test.AssertEquals(t, count.Count, int64(1))
+		})
+	}
+}
+
 func TestFasterGetOrderForNames(t *testing.T) {
 	sa, fc, cleanUp := initSA(t)
 	defer cleanUp()
@@ -1648,6 +1824,153 @@ func TestRevokeCertificate(t *testing.T) {
 	test.AssertError(t, err, "RevokeCertificate should've failed when certificate already revoked")
 }
 
+func TestRevokeShortLivedCertificate(t *testing.T) {
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := createWorkingRegistration(t, sa)
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "Couldn't read example cert DER")
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:        certDER,
+		RegID:      reg.Id,
+		Issued:     sa.clk.Now().UnixNano(),
+		IssuerID:   1,
+		ShortLived: true,
+	})
+	test.AssertNotError(t, err, "Couldn't add www.eff.org.der")
+
+	serial := "000000000000000000000000000000021bd4"
+	fc.Add(1 * time.Hour)
+	now := fc.Now()
+
+	// A short-lived certificate is revoked without an OCSP response
+	_, err = sa.RevokeCertificate(context.Background(), &sapb.RevokeCertificateRequest{
+		Serial: serial,
+		Date:   now.UnixNano(),
+		Reason: ocsp.Superseded,
+	})
+	test.AssertNotError(t, err, "RevokeCertificate failed")
+
+	status, err := sa.GetCertificateStatus(ctx, &sapb.Serial{Serial: serial})
+	test.AssertNotError(t, err, "GetCertificateStatus failed")
+	test.AssertEquals(t, core.OCSPStatus(status.Status), core.OCSPStatusRevoked)
+	test.AssertEquals(t, status.RevokedReason, int64(ocsp.Superseded))
+	test.AssertEquals(t, len(status.OcspResponse), 0)
+
+	// Its revocation can be updated to keyCompromise without one too
+	_, err = sa.UpdateRevokedCertificate(context.Background(), &sapb.RevokeCertificateRequest{
+		Serial:   serial,
+		Date:     fc.Now().Add(time.Hour).UnixNano(),
+		Backdate: now.UnixNano(),
+		Reason:   ocsp.KeyCompromise,
+	})
+	test.AssertNotError(t, err, "UpdateRevokedCertificate failed")
+
+	// Other certificates still require an OCSP response
+	_, err = sa.RevokeCertificate(context.Background(), &sapb.RevokeCertificateRequest{
+		Serial: "000000000000000000000000000000000001",
+		Date:   now.UnixNano(),
+		Reason: ocsp.Superseded,
+	})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+}
+
+func TestGetRevokedCerts(t *testing.T) {
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	// Add a cert to the DB to test with. It expires on 2016-04-14.
+	reg := createWorkingRegistration(t, sa)
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "Couldn't read example cert DER")
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      certDER,
+		RegID:    reg.Id,
+		Ocsp:     nil,
+		Issued:   sa.clk.Now().UnixNano(),
+		IssuerID: 1,
+		CrlShard: 3,
+	})
+	test.AssertNotError(t, err, "Couldn't add www.eff.org.der")
+
+	expiresAfter := time.Date(2016, 4, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	expiresBefore := time.Date(2016, 5, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	countRevoked := func(req *sapb.GetRevokedCertsRequest) int {
+		t.Helper()
+		res, err := sa.GetRevokedCerts(ctx, req)
+		test.AssertNotError(t, err, "GetRevokedCerts failed")
+		return len(res.Entries)
+	}
+
+	// The certificate is not yet revoked, so it isn't returned.
+	test.AssertEquals(t, countRevoked(&sapb.GetRevokedCertsRequest{
+		IssuerNameID:  1,
+		ExpiresAfter:  expiresAfter,
+		ExpiresBefore: expiresBefore,
+		RevokedBefore: fc.Now().Add(time.Hour).UnixNano(),
+		CrlShard:      3,
+	}), 0)
+
+	revokedAt := fc.Now()
+	_, err = sa.RevokeCertificate(ctx, &sapb.RevokeCertificateRequest{
+		Serial:   "000000000000000000000000000000021bd4",
+		Date:     revokedAt.UnixNano(),
+		Reason:   ocsp.KeyCompromise,
+		Response: []byte{1, 2, 3},
+	})
+	test.AssertNotError(t, err, "RevokeCertificate failed")
+
+	res, err := sa.GetRevokedCerts(ctx, &sapb.GetRevokedCertsRequest{
+		IssuerNameID:  1,
+		ExpiresAfter:  expiresAfter,
+		ExpiresBefore: expiresBefore,
+		RevokedBefore: revokedAt.Add(time.Hour).UnixNano(),
+		CrlShard:      3,
+	})
+	test.AssertNotError(t, err, "GetRevokedCerts failed")
+	test.AssertEquals(t, len(res.Entries), 1)
+	test.AssertEquals(t, res.Entries[0].Serial, "000000000000000000000000000000021bd4")
+	test.AssertEquals(t, res.Entries[0].Reason, int32(ocsp.KeyCompromise))
+	test.AssertEquals(t, res.Entries[0].RevokedAt, revokedAt.UnixNano())
+
+	// Revoked after the cutoff.
+	test.AssertEquals(t, countRevoked(&sapb.GetRevokedCertsRequest{
+		IssuerNameID:  1,
+		ExpiresAfter:  expiresAfter,
+		ExpiresBefore: expiresBefore,
+		RevokedBefore: revokedAt.Add(-time.Hour).UnixNano(),
+		CrlShard:      3,
+	}), 0)
+	// Different issuer.
+	test.AssertEquals(t, countRevoked(&sapb.GetRevokedCertsRequest{
+		IssuerNameID:  2,
+		ExpiresAfter:  expiresAfter,
+		ExpiresBefore: expiresBefore,
+		RevokedBefore: revokedAt.Add(time.Hour).UnixNano(),
+		CrlShard:      3,
+	}), 0)
+	// Expires outside the requested range.
+	test.AssertEquals(t, countRevoked(&sapb.GetRevokedCertsRequest{
+		IssuerNameID:  1,
+		ExpiresAfter:  expiresBefore,
+		ExpiresBefore: time.Date(2016, 6, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+		RevokedBefore: revokedAt.Add(time.Hour).UnixNano(),
+		CrlShard:      3,
+	}), 0)
+
+	// Different shard.
+	test.AssertEquals(t, countRevoked(&sapb.GetRevokedCertsRequest{
+		IssuerNameID:  1,
+		ExpiresAfter:  expiresAfter,
+		ExpiresBefore: expiresBefore,
+		RevokedBefore: revokedAt.Add(time.Hour).UnixNano(),
+	}), 0)
+
+	_, err = sa.GetRevokedCerts(ctx, &sapb.GetRevokedCertsRequest{IssuerNameID: 1})
+	test.AssertError(t, err, "GetRevokedCerts succeeded with incomplete request")
+}
+
 func TestUpdateRevokedCertificate(t *testing.T) {
 	sa, fc, cleanUp := initSA(t)
 	defer cleanUp()
@@ -2137,6 +2460,16 @@ func TestGetPendingAuthorization2(t *testing.T) {
 	})
 	test.AssertNotError(t, err, "sa.GetPendingAuthorization2 failed")
 	test.AssertEquals(t, fmt.Sprintf("%d", authzIDA), dbVer.Id)
+
+	// The pending authorizations are for a DNS identifier, so looking them up
+	// as an IP identifier should find nothing.
+	_, err = sa.GetPendingAuthorization2(context.Background(), &sapb.GetPendingAuthorizationRequest{
+		RegistrationID:  regID,
+		IdentifierType:  "ip",
+		IdentifierValue: domain,
+		ValidUntil:      validUntil,
+	})
+	test.AssertErrorIs(t, err, berrors.NotFound)
 }
 
 func TestCountPendingAuthorizations2(t *testing.T) {
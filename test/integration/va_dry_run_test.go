@@ -0,0 +1,86 @@
+//go:build integration
+
+package integration
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// dryRunReport is the subset of va-dry-run's output checked by these tests.
+type dryRunReport struct {
+	Records []struct {
+		Hostname string
+	}
+	Problem *struct {
+		Type   string
+		Detail string
+	}
+}
+
+func runVADryRun(t *testing.T, domain, keyAuthorization string) dryRunReport {
+	t.Helper()
+	cmd := exec.Command("./bin/va-dry-run",
+		"--config", "./"+os.Getenv("BOULDER_CONFIG_DIR")+"/va-dry-run.json",
+		"--domain", domain,
+		"--type", "http-01",
+		"--key-authorization", keyAuthorization)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("va-dry-run failed (%s). Output was: %s", err, out)
+	}
+	var report dryRunReport
+	err = json.Unmarshal(out, &report)
+	if err != nil {
+		t.Fatalf("parsing va-dry-run output: %s. Output was: %s", err, out)
+	}
+	return report
+}
+
+func randomToken(t *testing.T) string {
+	t.Helper()
+	var b [32]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		t.Fatalf("generating token: %s", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// TestVADryRun runs va-dry-run against the VA's admin listener, which is only
+// configured in config-next.
+func TestVADryRun(t *testing.T) {
+	t.Parallel()
+	if !strings.Contains(os.Getenv("BOULDER_CONFIG_DIR"), "test/config-next") {
+		return
+	}
+
+	domain := random_domain()
+	token := randomToken(t)
+	keyAuthorization := token + "." + randomToken(t)
+	err := addHTTP01Response(token, keyAuthorization)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = delHTTP01Response(token) }()
+
+	report := runVADryRun(t, domain, keyAuthorization)
+	if report.Problem != nil {
+		t.Fatalf("unexpected problem %s: %s", report.Problem.Type, report.Problem.Detail)
+	}
+	if len(report.Records) == 0 || report.Records[0].Hostname != domain {
+		t.Fatalf("expected a validation record for %s, got %+v", domain, report.Records)
+	}
+
+	// A key authorization other than the one served is reported as a problem,
+	// without failing the tool.
+	report = runVADryRun(t, domain, token+"."+randomToken(t))
+	if report.Problem == nil || !strings.HasSuffix(report.Problem.Type, "unauthorized") {
+		t.Fatalf("expected an unauthorized problem, got %+v", report.Problem)
+	}
+}
@@ -56,19 +56,19 @@ SERVICES = (
         None),
     Service('boulder-va-1',
         8004, 'va1.boulder:9092',
-        ('./bin/boulder-va', '--config', os.path.join(config_dir, 'va.json'), '--addr', 'va1.boulder:9092', '--debug-addr', ':8004'),
+        ('./bin/boulder-va', '--config', os.path.join(config_dir, 'va.json'), '--addr', 'va1.boulder:9092', '--admin-addr', 'va1.boulder:9102', '--debug-addr', ':8004'),
         ('sd-test-srv', 'boulder-remoteva-a', 'boulder-remoteva-b')),
     Service('boulder-va-2',
         8104, 'va2.boulder:9092',
-        ('./bin/boulder-va', '--config', os.path.join(config_dir, 'va.json'), '--addr', 'va2.boulder:9092', '--debug-addr', ':8104'),
+        ('./bin/boulder-va', '--config', os.path.join(config_dir, 'va.json'), '--addr', 'va2.boulder:9092', '--admin-addr', 'va2.boulder:9102', '--debug-addr', ':8104'),
         ('sd-test-srv', 'boulder-remoteva-a', 'boulder-remoteva-b')),
     Service('boulder-ca-a',
         8001, 'ca1.boulder:9093',
-        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-a.json'), '--ca-addr', 'ca1.boulder:9093', '--ocsp-addr', 'ca1.boulder:9096', '--debug-addr', ':8001'),
+        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-a.json'), '--ca-addr', 'ca1.boulder:9093', '--ocsp-addr', 'ca1.boulder:9096', '--crl-addr', 'ca1.boulder:9106', '--debug-addr', ':8001'),
         ('sd-test-srv', 'boulder-sa-1', 'boulder-sa-2')),
     Service('boulder-ca-b',
         8101, 'ca2.boulder:9093',
-        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-b.json'), '--ca-addr', 'ca2.boulder:9093', '--ocsp-addr', 'ca2.boulder:9096', '--debug-addr', ':8101'),
+        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-b.json'), '--ca-addr', 'ca2.boulder:9093', '--ocsp-addr', 'ca2.boulder:9096', '--crl-addr', 'ca2.boulder:9106', '--debug-addr', ':8101'),
         ('sd-test-srv', 'boulder-sa-1', 'boulder-sa-2')),
     Service('akamai-test-srv',
         6789, None,
@@ -112,6 +112,14 @@ SERVICES = (
         None),
 )
 
+if CONFIG_NEXT:
+    SERVICES += (
+        Service('crl-updater',
+            8021, None,
+            ('./bin/crl-updater', '--config', os.path.join(config_dir, 'crl-updater.json')),
+            ('boulder-ca-a', 'boulder-ca-b', 'boulder-sa-1', 'boulder-sa-2')),
+    )
+
 def _service_toposort(services):
     """Yields Service objects in topologically sorted order.
 
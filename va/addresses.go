@@ -0,0 +1,93 @@
+package va
+
+import (
+	"errors"
+	"net"
+
+	"github.com/letsencrypt/boulder/core"
+)
+
+// validationAddresses iterates over the addresses of a validation target in
+// the order the VA connects to them: the first IPv6 address, if there is one,
+// and then the first IPv4 address, if there is one, as a fallback. It's used
+// by both http-01 and tls-alpn-01 validation.
+type validationAddresses struct {
+	// all of the IP addresses available for the host
+	available []net.IP
+	// the IP addresses that were tried for validation previously that were cycled
+	// out of cur by calls to nextIP()
+	tried []net.IP
+	// the IP addresses that will be drawn from by calls to nextIP() to set cur
+	next []net.IP
+	// the current IP address being used for validation (if any)
+	cur net.IP
+	// the errors from the IP addresses which failed, in the order they were
+	// tried, recorded by calls to failed()
+	errs []core.AddressError
+}
+
+// newValidationAddresses returns a validationAddresses for the given addresses,
+// with the first address to use already current. An error is returned if there
+// are no addresses.
+func newValidationAddresses(addrs []net.IP) (validationAddresses, error) {
+	a := validationAddresses{available: addrs}
+
+	// Separate the addresses into the available v4 and v6 addresses
+	v4Addrs, v6Addrs := availableAddresses(addrs)
+	hasV6Addrs := len(v6Addrs) > 0
+	hasV4Addrs := len(v4Addrs) > 0
+
+	if !hasV6Addrs && !hasV4Addrs {
+		return a, errors.New("no IPv4 or IPv6 addresses")
+	} else if !hasV6Addrs && hasV4Addrs {
+		// If there are no v6 addrs and there are v4 addrs then use the first v4
+		// address. There's no fallback address.
+		a.next = []net.IP{v4Addrs[0]}
+	} else if hasV6Addrs && hasV4Addrs {
+		// If there are both v6 addrs and v4 addrs then use the first v6 address and
+		// fallback with the first v4 address.
+		a.next = []net.IP{v6Addrs[0], v4Addrs[0]}
+	} else if hasV6Addrs && !hasV4Addrs {
+		// If there are just v6 addrs then use the first v6 address. There's no
+		// fallback address.
+		a.next = []net.IP{v6Addrs[0]}
+	}
+
+	// Advance using nextIP to populate the cur IP before returning
+	_ = a.nextIP()
+	return a, nil
+}
+
+// nextIP changes the cur IP by removing the first entry from the next slice and
+// setting it to cur. If cur was previously set the value will be added to the
+// tried slice to keep track of IPs that were previously used. If nextIP() is
+// called but next is empty an error is returned.
+func (a *validationAddresses) nextIP() error {
+	if len(a.next) == 0 {
+		return errors.New("no IP addresses remaining to use")
+	}
+	if a.cur != nil {
+		a.tried = append(a.tried, a.cur)
+	}
+	a.cur = a.next[0]
+	a.next = a.next[1:]
+	return nil
+}
+
+// failed records that validation against the current IP address failed with
+// the given error.
+func (a *validationAddresses) failed(err string) {
+	a.errs = append(a.errs, core.AddressError{Address: a.cur, Error: err})
+}
+
+// ip returns the current IP address. It may return nil if all possible IPs
+// have been expended by calls to nextIP.
+func (a *validationAddresses) ip() net.IP {
+	return a.cur
+}
+
+// isIPv4Fallback returns true if the current IP address is an IPv4 address
+// tried after an IPv6 address.
+func (a *validationAddresses) isIPv4Fallback() bool {
+	return a.cur.To4() != nil && len(a.tried) > 0 && a.tried[len(a.tried)-1].To4() == nil
+}
@@ -0,0 +1,47 @@
+package va
+
+import (
+	"net"
+	"testing"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestValidationAddresses(t *testing.T) {
+	_, err := newValidationAddresses(nil)
+	test.AssertError(t, err, "expected an error with no addresses")
+
+	v4 := net.ParseIP("127.0.0.1")
+	v6 := net.ParseIP("::1")
+	addresses, err := newValidationAddresses([]net.IP{v4, net.ParseIP("127.0.0.2"), v6, net.ParseIP("::2")})
+	test.AssertNotError(t, err, "newValidationAddresses failed")
+	test.AssertEquals(t, len(addresses.available), 4)
+
+	// The first IPv6 address is tried first, with nothing tried before it.
+	test.AssertEquals(t, addresses.ip().String(), v6.String())
+	test.AssertEquals(t, len(addresses.tried), 0)
+	test.Assert(t, !addresses.isIPv4Fallback(), "IPv6 address shouldn't be a fallback")
+
+	// Then the first IPv4 address, as a fallback, after the IPv6 address fails.
+	addresses.failed("connection refused")
+	test.AssertNotError(t, addresses.nextIP(), "nextIP failed")
+	test.AssertEquals(t, addresses.ip().String(), v4.String())
+	test.AssertDeepEquals(t, addresses.tried, []net.IP{v6})
+	test.Assert(t, addresses.isIPv4Fallback(), "IPv4 address after IPv6 should be a fallback")
+	addresses.failed("timeout")
+	test.AssertDeepEquals(t, addresses.errs, []core.AddressError{
+		{Address: v6, Error: "connection refused"},
+		{Address: v4, Error: "timeout"},
+	})
+
+	// And there's nothing else to try.
+	test.AssertError(t, addresses.nextIP(), "expected no addresses remaining")
+	test.AssertEquals(t, addresses.ip().String(), v4.String())
+
+	addresses, err = newValidationAddresses([]net.IP{v4})
+	test.AssertNotError(t, err, "newValidationAddresses failed")
+	test.AssertEquals(t, addresses.ip().String(), v4.String())
+	test.Assert(t, !addresses.isIPv4Fallback(), "lone IPv4 address shouldn't be a fallback")
+	test.AssertError(t, addresses.nextIP(), "expected no addresses remaining")
+}
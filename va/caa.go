@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/letsencrypt/boulder/bdns"
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
@@ -17,6 +18,10 @@ import (
 type caaParams struct {
 	accountURIID     int64
 	validationMethod string
+	// recheck is true if this is a recheck of CAA before issuance, rather
+	// than a check made while validating a challenge. It determines how
+	// stale a cached CAA lookup may be.
+	recheck bool
 }
 
 func (va *ValidationAuthorityImpl) IsCAAValid(ctx context.Context, req *vapb.IsCAAValidRequest) (*vapb.IsCAAValidResponse, error) {
@@ -27,6 +32,7 @@ func (va *ValidationAuthorityImpl) IsCAAValid(ctx context.Context, req *vapb.IsC
 	params := &caaParams{
 		accountURIID:     req.AccountURIID,
 		validationMethod: req.ValidationMethod,
+		recheck:          true,
 	}
 	if prob := va.checkCAA(ctx, acmeID, params); prob != nil {
 		return &vapb.IsCAAValidResponse{
@@ -45,7 +51,7 @@ func (va *ValidationAuthorityImpl) checkCAA(
 	ctx context.Context,
 	identifier identifier.ACMEIdentifier,
 	params *caaParams) *probs.ProblemDetails {
-	present, valid, response, err := va.checkCAARecords(ctx, identifier, params)
+	present, valid, response, dnssec, err := va.checkCAARecords(ctx, identifier, params)
 	if err != nil {
 		return probs.DNS(err.Error())
 	}
@@ -58,8 +64,8 @@ func (va *ValidationAuthorityImpl) checkCAA(
 		validationMethod = params.validationMethod
 	}
 
-	va.log.AuditInfof("Checked CAA records for %s, [Present: %t, Account ID: %s, Challenge: %s, Valid for issuance: %t] Response=%q",
-		identifier.Value, present, accountID, validationMethod, valid, response)
+	va.log.AuditInfof("Checked CAA records for %s, [Present: %t, Account ID: %s, Challenge: %s, Valid for issuance: %t, DNSSEC: %s] Response=%q",
+		identifier.Value, present, accountID, validationMethod, valid, dnssec, response)
 	if !valid {
 		return probs.CAA(fmt.Sprintf("CAA record for %s prevents issuance", identifier.Value))
 	}
@@ -116,23 +122,53 @@ func newCAASet(CAAs []*dns.CAA) *CAASet {
 type caaResult struct {
 	records  []*dns.CAA
 	response string
+	dnssec   bdns.DNSSECState
 	err      error
 }
 
-func parseResults(results []caaResult) (*CAASet, string, error) {
-	// Return first result
-	for _, res := range results {
+// parseResults returns the first non-empty CAA set among the results, which
+// are ordered from the most to the least specific name. The DNSSEC state
+// returned is the weakest of the results examined, since the absence of CAA
+// records at the more specific names matters as much as the set found.
+func parseResults(results []caaResult) (*CAASet, string, bdns.DNSSECState, error) {
+	var dnssec bdns.DNSSECState
+	for i, res := range results {
 		if res.err != nil {
-			return nil, "", res.err
+			return nil, "", res.dnssec, res.err
+		}
+		if i == 0 {
+			dnssec = res.dnssec
+		} else {
+			dnssec = bdns.WeakerDNSSECState(dnssec, res.dnssec)
 		}
 		if len(res.records) > 0 {
-			return newCAASet(res.records), res.response, nil
+			return newCAASet(res.records), res.response, dnssec, nil
 		}
 	}
-	return nil, "", nil
+	return nil, "", dnssec, nil
+}
+
+// lookupCAA looks up the CAA records at a single name, using the CAA cache if
+// one is configured.
+func (va *ValidationAuthorityImpl) lookupCAA(ctx context.Context, name string, recheck bool) caaResult {
+	if va.caaCache == nil {
+		var r caaResult
+		r.records, r.response, r.dnssec, r.err = va.dnsClient.LookupCAA(ctx, name)
+		return r
+	}
+	if entry := va.caaCache.get(ctx, name, recheck); entry != nil {
+		return caaResult{records: entry.Records, response: entry.Response, dnssec: entry.DNSSEC}
+	}
+	fetched := va.clk.Now()
+	var r caaResult
+	r.records, r.response, r.dnssec, r.err = va.dnsClient.LookupCAA(ctx, name)
+	if r.err == nil {
+		va.caaCache.set(ctx, name, fetched, r)
+	}
+	return r
 }
 
-func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name string) []caaResult {
+func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name string, recheck bool) []caaResult {
 	labels := strings.Split(name, ".")
 	results := make([]caaResult, len(labels))
 	var wg sync.WaitGroup
@@ -141,7 +177,7 @@ func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name s
 		// Start the concurrent DNS lookup.
 		wg.Add(1)
 		go func(name string, r *caaResult) {
-			r.records, r.response, r.err = va.dnsClient.LookupCAA(ctx, name)
+			*r = va.lookupCAA(ctx, name, recheck)
 			wg.Done()
 		}(strings.Join(labels[i:], "."), &results[i])
 	}
@@ -150,7 +186,7 @@ func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name s
 	return results
 }
 
-func (va *ValidationAuthorityImpl) getCAASet(ctx context.Context, hostname string) (*CAASet, string, error) {
+func (va *ValidationAuthorityImpl) getCAASet(ctx context.Context, hostname string, recheck bool) (*CAASet, string, bdns.DNSSECState, error) {
 	hostname = strings.TrimRight(hostname, ".")
 
 	// See RFC 6844 "Certification Authority Processing" for pseudocode, as
@@ -162,7 +198,7 @@ func (va *ValidationAuthorityImpl) getCAASet(ctx context.Context, hostname strin
 	// the RPC call.
 	//
 	// We depend on our resolver to snap CNAME and DNAME records.
-	results := va.parallelCAALookup(ctx, hostname)
+	results := va.parallelCAALookup(ctx, hostname, recheck)
 	return parseResults(results)
 }
 
@@ -174,12 +210,12 @@ func (va *ValidationAuthorityImpl) getCAASet(ctx context.Context, hostname strin
 // CAA records were present after filtering for known/supported CAA tags. The
 // second is a bool indicating whether issuance for the identifier is valid. The
 // unmodified *dns.CAA records that were processed/filtered are returned as the
-// third argument. Any  errors encountered are returned as the fourth return
-// value (or nil).
+// third argument, and their DNSSEC state as the fourth. Any errors encountered
+// are returned as the fifth return value (or nil).
 func (va *ValidationAuthorityImpl) checkCAARecords(
 	ctx context.Context,
 	identifier identifier.ACMEIdentifier,
-	params *caaParams) (bool, bool, string, error) {
+	params *caaParams) (bool, bool, string, bdns.DNSSECState, error) {
 	hostname := strings.ToLower(identifier.Value)
 	// If this is a wildcard name, remove the prefix
 	var wildcard bool
@@ -187,12 +223,24 @@ func (va *ValidationAuthorityImpl) checkCAARecords(
 		hostname = strings.TrimPrefix(identifier.Value, `*.`)
 		wildcard = true
 	}
-	caaSet, response, err := va.getCAASet(ctx, hostname)
+	caaSet, response, dnssec, err := va.getCAASet(ctx, hostname, params != nil && params.recheck)
 	if err != nil {
-		return false, false, "", err
+		return false, false, "", dnssec, err
 	}
 	present, valid := va.validateCAASet(caaSet, wildcard, params)
-	return present, valid, response, nil
+	if present && !valid && va.iodefReporter != nil {
+		va.iodefReporter.report(caaSet, identifier.Value, va.accountURI(params))
+	}
+	return present, valid, response, dnssec, nil
+}
+
+// accountURI returns the URI of the account on whose behalf CAA is checked,
+// using the first configured account URI prefix, or "" if it isn't known.
+func (va *ValidationAuthorityImpl) accountURI(params *caaParams) string {
+	if params == nil || params.accountURIID == 0 || len(va.accountURIPrefixes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s%d", va.accountURIPrefixes[0], params.accountURIID)
 }
 
 func containsMethod(commaSeparatedMethods, method string) bool {
@@ -0,0 +1,262 @@
+package va
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/bdns"
+)
+
+// maxCAACacheAge is the longest a CAA lookup may be relied upon. The Baseline
+// Requirements (Section 3.2.2.8) allow a CA to issue within 8 hours of
+// checking CAA, so no cached answer is served once it is older than this.
+const maxCAACacheAge = 8 * time.Hour
+
+// caaRecheckAge is how long after a validation the RA relies on the CAA check
+// made while validating, before asking for CAA to be rechecked. It must match
+// the RA's recheck window.
+const caaRecheckAge = 7 * time.Hour
+
+// maxCAAValidationCacheAge is the oldest a cached lookup may be when checking
+// CAA while validating a challenge. The RA may rely on that check for up to
+// caaRecheckAge, so the lookup must be no older than the remainder of
+// maxCAACacheAge.
+const maxCAAValidationCacheAge = maxCAACacheAge - caaRecheckAge
+
+// CAACacheEntry is the cached result of a successful CAA lookup for a single
+// name.
+type CAACacheEntry struct {
+	Records  []*dns.CAA
+	Response string
+	DNSSEC   bdns.DNSSECState
+	// Fetched is when the lookup was made.
+	Fetched time.Time
+	// Expires is when the entry must no longer be served: the lowest TTL of
+	// its records after Fetched, capped at maxCAACacheAge.
+	Expires time.Time
+}
+
+// CAACacheStore stores CAA cache entries by name. Get returns a nil entry and
+// no error on a miss. A store may drop an entry at any time, but must not
+// return one after its Expires time.
+type CAACacheStore interface {
+	Get(ctx context.Context, name string) (*CAACacheEntry, error)
+	Set(ctx context.Context, name string, entry *CAACacheEntry) error
+}
+
+// CAACache caches the results of CAA lookups for individual names, so that
+// checking CAA for many names sharing parent domains, or the same name
+// repeatedly, doesn't query the DNS each time. The records are cached rather
+// than the decision made from them, so a single entry serves checks for every
+// account and validation method.
+//
+// A check made while validating a challenge may accept entries up to
+// validationMaxAge old, and no entry is served beyond its DNS TTL. A recheck
+// before issuance is the last check CAA gets, so it's never served from the
+// cache, though its result is cached for later validations.
+type CAACache struct {
+	store            CAACacheStore
+	validationMaxAge time.Duration
+	negativeTTL      time.Duration
+	clk              clock.Clock
+	lookups          *prometheus.CounterVec
+}
+
+// NewCAACache returns a CAACache backed by store. The validationMaxAge
+// defaults to, and may not exceed, maxCAAValidationCacheAge. The negativeTTL is
+// how long the absence of CAA records at a name is cached, since their TTL
+// isn't known; if zero, it isn't cached.
+func NewCAACache(
+	store CAACacheStore,
+	validationMaxAge time.Duration,
+	negativeTTL time.Duration,
+	clk clock.Clock,
+	stats prometheus.Registerer,
+) (*CAACache, error) {
+	if validationMaxAge == 0 {
+		validationMaxAge = maxCAAValidationCacheAge
+	}
+	if validationMaxAge < 0 || validationMaxAge > maxCAAValidationCacheAge {
+		return nil, fmt.Errorf("CAA cache validationMaxAge must be between 0 and %s, got %s", maxCAAValidationCacheAge, validationMaxAge)
+	}
+	if negativeTTL < 0 || negativeTTL > maxCAACacheAge {
+		return nil, fmt.Errorf("CAA cache negativeTTL must be between 0 and %s, got %s", maxCAACacheAge, negativeTTL)
+	}
+
+	lookups := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "caa_cache_lookups",
+		Help: "A counter of CAA cache lookups labelled by check (validation or recheck) and result (hit, miss, bypass or error)",
+	}, []string{"check", "result"})
+	stats.MustRegister(lookups)
+
+	return &CAACache{
+		store:            store,
+		validationMaxAge: validationMaxAge,
+		negativeTTL:      negativeTTL,
+		clk:              clk,
+		lookups:          lookups,
+	}, nil
+}
+
+// get returns the cached result of looking up CAA for name, or nil if there
+// is none fresh enough for the kind of check. Rechecks always get nil. Errors
+// from the store are treated as misses.
+func (c *CAACache) get(ctx context.Context, name string, recheck bool) *CAACacheEntry {
+	if recheck {
+		c.lookups.WithLabelValues("recheck", "bypass").Inc()
+		return nil
+	}
+	entry, err := c.store.Get(ctx, name)
+	if err != nil {
+		c.lookups.WithLabelValues("validation", "error").Inc()
+		return nil
+	}
+	now := c.clk.Now()
+	if entry == nil || !now.Before(entry.Expires) || now.Sub(entry.Fetched) > c.validationMaxAge {
+		c.lookups.WithLabelValues("validation", "miss").Inc()
+		return nil
+	}
+	c.lookups.WithLabelValues("validation", "hit").Inc()
+	return entry
+}
+
+// set caches the result of a successful CAA lookup for name made at fetched.
+// Errors from the store are ignored, since the cache is only an optimization.
+func (c *CAACache) set(ctx context.Context, name string, fetched time.Time, res caaResult) {
+	ttl := maxCAACacheAge
+	if len(res.records) == 0 {
+		ttl = c.negativeTTL
+	}
+	for _, rr := range res.records {
+		if t := time.Duration(rr.Hdr.Ttl) * time.Second; t < ttl {
+			ttl = t
+		}
+	}
+	if ttl <= 0 {
+		return
+	}
+	_ = c.store.Set(ctx, name, &CAACacheEntry{
+		Records:  res.records,
+		Response: res.response,
+		DNSSEC:   res.dnssec,
+		Fetched:  fetched,
+		Expires:  fetched.Add(ttl),
+	})
+}
+
+// memoryCAACacheStore is a CAACacheStore holding entries in memory.
+type memoryCAACacheStore struct {
+	sync.Mutex
+	entries    map[string]*CAACacheEntry
+	maxEntries int
+	clk        clock.Clock
+}
+
+// NewMemoryCAACacheStore returns a CAACacheStore holding up to maxEntries
+// entries in memory. When it's full, expired entries are dropped to make room,
+// and if none have expired new entries aren't stored.
+func NewMemoryCAACacheStore(maxEntries int, clk clock.Clock) CAACacheStore {
+	return &memoryCAACacheStore{
+		entries:    make(map[string]*CAACacheEntry),
+		maxEntries: maxEntries,
+		clk:        clk,
+	}
+}
+
+func (m *memoryCAACacheStore) Get(_ context.Context, name string) (*CAACacheEntry, error) {
+	m.Lock()
+	defer m.Unlock()
+	entry, ok := m.entries[name]
+	if !ok {
+		return nil, nil
+	}
+	if !m.clk.Now().Before(entry.Expires) {
+		delete(m.entries, name)
+		return nil, nil
+	}
+	return entry, nil
+}
+
+func (m *memoryCAACacheStore) Set(_ context.Context, name string, entry *CAACacheEntry) error {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.entries[name]; !ok && len(m.entries) >= m.maxEntries {
+		now := m.clk.Now()
+		for k, v := range m.entries {
+			if !now.Before(v.Expires) {
+				delete(m.entries, k)
+			}
+		}
+		if len(m.entries) >= m.maxEntries {
+			return errors.New("CAA cache is full")
+		}
+	}
+	m.entries[name] = entry
+	return nil
+}
+
+// redisCAACacheStore is a CAACacheStore holding entries in Redis, so that they
+// can be shared between VAs.
+type redisCAACacheStore struct {
+	rdb     redis.UniversalClient
+	timeout time.Duration
+	clk     clock.Clock
+}
+
+// NewRedisCAACacheStore returns a CAACacheStore holding entries in Redis. The
+// timeout applies to all requests.
+func NewRedisCAACacheStore(rdb redis.UniversalClient, timeout time.Duration, clk clock.Clock) CAACacheStore {
+	return &redisCAACacheStore{rdb: rdb, timeout: timeout, clk: clk}
+}
+
+// makeCAACacheKey generates the Redis key under which the CAA cache entry for
+// name is stored.
+func makeCAACacheKey(name string) string {
+	return fmt.Sprintf("caa{%s}", strings.ToLower(name))
+}
+
+func (r *redisCAACacheStore) Get(ctx context.Context, name string) (*CAACacheEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	val, err := r.rdb.Get(ctx, makeCAACacheKey(name)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting CAA cache entry for %q: %w", name, err)
+	}
+	var entry CAACacheEntry
+	err = json.Unmarshal(val, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling CAA cache entry for %q: %w", name, err)
+	}
+	return &entry, nil
+}
+
+func (r *redisCAACacheStore) Set(ctx context.Context, name string, entry *CAACacheEntry) error {
+	ttl := entry.Expires.Sub(r.clk.Now())
+	if ttl <= 0 {
+		return nil
+	}
+	val, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling CAA cache entry for %q: %w", name, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	err = r.rdb.Set(ctx, makeCAACacheKey(name), val, ttl).Err()
+	if err != nil {
+		return fmt.Errorf("setting CAA cache entry for %q: %w", name, err)
+	}
+	return nil
+}
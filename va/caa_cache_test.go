@@ -0,0 +1,207 @@
+package va
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// caaCountingMockDNS counts CAA lookups, and returns a CAA record with a 300
+// second TTL for present.com and nothing for any other name.
+type caaCountingMockDNS struct {
+	bdns.MockClient
+	sync.Mutex
+	lookups map[string]int
+}
+
+func (mock *caaCountingMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, bdns.DNSSECState, error) {
+	mock.Lock()
+	defer mock.Unlock()
+	domain = strings.TrimRight(domain, ".")
+	mock.lookups[domain]++
+	if domain != "present.com" {
+		return nil, "", bdns.DNSSECUnchecked, nil
+	}
+	return []*dns.CAA{{
+		Hdr:   dns.RR_Header{Name: "present.com.", Rrtype: dns.TypeCAA, Class: dns.ClassINET, Ttl: 300},
+		Tag:   "issue",
+		Value: "letsencrypt.org",
+	}}, "present.com. 300 IN CAA 0 issue \"letsencrypt.org\"", bdns.DNSSECSecure, nil
+}
+
+func (mock *caaCountingMockDNS) count(domain string) int {
+	mock.Lock()
+	defer mock.Unlock()
+	return mock.lookups[domain]
+}
+
+func setupCAACache(t *testing.T, validationMaxAge, negativeTTL time.Duration) (*ValidationAuthorityImpl, *caaCountingMockDNS, clock.FakeClock) {
+	va, _ := setup(nil, 0, "", nil)
+	fc := va.clk.(clock.FakeClock)
+	fc.Set(time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC))
+	mock := &caaCountingMockDNS{lookups: make(map[string]int)}
+	va.dnsClient = mock
+	cache, err := NewCAACache(NewMemoryCAACacheStore(100, fc), validationMaxAge, negativeTTL, fc, metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating CAA cache")
+	va.caaCache = cache
+	return va, mock, fc
+}
+
+func TestCAACacheHit(t *testing.T) {
+	va, mock, _ := setupCAACache(t, 0, time.Minute)
+	ident := identifier.DNSIdentifier("present.com")
+
+	for i := 0; i < 2; i++ {
+		present, valid, response, dnssec, err := va.checkCAARecords(ctx, ident, nil)
+		test.AssertNotError(t, err, "checking CAA")
+		test.Assert(t, present, "CAA records should be present")
+		test.Assert(t, valid, "CAA records should permit issuance")
+		test.AssertEquals(t, response, "present.com. 300 IN CAA 0 issue \"letsencrypt.org\"")
+		test.AssertEquals(t, dnssec, bdns.DNSSECSecure)
+	}
+	test.AssertEquals(t, mock.count("present.com"), 1)
+	test.AssertEquals(t, mock.count("com"), 1)
+	test.AssertMetricWithLabelsEquals(t, va.caaCache.lookups, prometheus.Labels{"check": "validation", "result": "hit"}, 2)
+	test.AssertMetricWithLabelsEquals(t, va.caaCache.lookups, prometheus.Labels{"check": "validation", "result": "miss"}, 2)
+}
+
+func TestCAACacheExpiresWithTTL(t *testing.T) {
+	va, mock, fc := setupCAACache(t, 0, time.Hour)
+	ident := identifier.DNSIdentifier("present.com")
+
+	_, _, _, _, err := va.checkCAARecords(ctx, ident, nil)
+	test.AssertNotError(t, err, "checking CAA")
+
+	// The record's TTL has passed, but the negative TTL for com hasn't.
+	fc.Add(301 * time.Second)
+	_, _, _, _, err = va.checkCAARecords(ctx, ident, nil)
+	test.AssertNotError(t, err, "checking CAA")
+	test.AssertEquals(t, mock.count("present.com"), 2)
+	test.AssertEquals(t, mock.count("com"), 1)
+}
+
+func TestCAACacheNoNegativeTTL(t *testing.T) {
+	va, mock, _ := setupCAACache(t, 0, 0)
+	ident := identifier.DNSIdentifier("present.com")
+
+	for i := 0; i < 2; i++ {
+		_, _, _, _, err := va.checkCAARecords(ctx, ident, nil)
+		test.AssertNotError(t, err, "checking CAA")
+	}
+	test.AssertEquals(t, mock.count("present.com"), 1)
+	test.AssertEquals(t, mock.count("com"), 2)
+}
+
+func TestCAACacheValidationMaxAge(t *testing.T) {
+	va, mock, fc := setupCAACache(t, 0, 0)
+	ident := identifier.DNSIdentifier("present.com")
+
+	_, _, _, _, err := va.checkCAARecords(ctx, ident, &caaParams{})
+	test.AssertNotError(t, err, "checking CAA")
+
+	// The record's TTL is 300 seconds, so an entry within both it and the
+	// default validation max age is served...
+	fc.Add(4 * time.Minute)
+	_, _, _, _, err = va.checkCAARecords(ctx, ident, &caaParams{})
+	test.AssertNotError(t, err, "checking CAA")
+	test.AssertEquals(t, mock.count("present.com"), 1)
+	test.AssertEquals(t, va.caaCache.validationMaxAge, time.Hour)
+
+	// ...but not once it's older than a shorter configured max age.
+	va, mock, fc = setupCAACache(t, time.Minute, 0)
+	_, _, _, _, err = va.checkCAARecords(ctx, ident, &caaParams{})
+	test.AssertNotError(t, err, "checking CAA")
+	fc.Add(2 * time.Minute)
+	_, _, _, _, err = va.checkCAARecords(ctx, ident, &caaParams{})
+	test.AssertNotError(t, err, "checking CAA")
+	test.AssertEquals(t, mock.count("present.com"), 2)
+}
+
+func TestCAACacheRecheckBypassesCache(t *testing.T) {
+	va, mock, _ := setupCAACache(t, 0, 0)
+	ident := identifier.DNSIdentifier("present.com")
+
+	_, _, _, _, err := va.checkCAARecords(ctx, ident, &caaParams{})
+	test.AssertNotError(t, err, "checking CAA")
+
+	// A recheck always looks up CAA in the DNS...
+	_, _, _, _, err = va.checkCAARecords(ctx, ident, &caaParams{recheck: true})
+	test.AssertNotError(t, err, "checking CAA")
+	test.AssertEquals(t, mock.count("present.com"), 2)
+	test.AssertMetricWithLabelsEquals(t, va.caaCache.lookups, prometheus.Labels{"check": "recheck", "result": "bypass"}, 2)
+	test.AssertMetricWithLabelsEquals(t, va.caaCache.lookups, prometheus.Labels{"check": "recheck", "result": "hit"}, 0)
+
+	// ...but caches the result for later validations.
+	_, _, _, _, err = va.checkCAARecords(ctx, ident, &caaParams{})
+	test.AssertNotError(t, err, "checking CAA")
+	test.AssertEquals(t, mock.count("present.com"), 2)
+}
+
+func TestCAACacheDoesNotCacheErrors(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+	va.dnsClient = caaMockDNS{}
+	cache, err := NewCAACache(NewMemoryCAACacheStore(100, va.clk), 0, time.Hour, va.clk, metrics.NoopRegisterer)
+	test.AssertNotError(t, err, "creating CAA cache")
+	va.caaCache = cache
+
+	_, _, _, _, err = va.checkCAARecords(ctx, identifier.DNSIdentifier("servfail.com"), nil)
+	test.AssertError(t, err, "expected SERVFAIL")
+	entry, err := cache.store.Get(ctx, "servfail.com")
+	test.AssertNotError(t, err, "getting cache entry")
+	test.Assert(t, entry == nil, "failed lookup should not be cached")
+}
+
+func TestNewCAACacheLimits(t *testing.T) {
+	store := NewMemoryCAACacheStore(1, clock.NewFake())
+	_, err := NewCAACache(store, 2*time.Hour, 0, clock.NewFake(), metrics.NoopRegisterer)
+	test.AssertError(t, err, "validationMaxAge beyond 1 hour should be refused")
+	_, err = NewCAACache(store, 0, -time.Second, clock.NewFake(), metrics.NoopRegisterer)
+	test.AssertError(t, err, "negative negativeTTL should be refused")
+}
+
+func TestMemoryCAACacheStore(t *testing.T) {
+	fc := clock.NewFake()
+	store := NewMemoryCAACacheStore(1, fc)
+
+	err := store.Set(ctx, "a.com", &CAACacheEntry{Expires: fc.Now().Add(time.Minute)})
+	test.AssertNotError(t, err, "setting entry")
+	err = store.Set(ctx, "b.com", &CAACacheEntry{Expires: fc.Now().Add(time.Minute)})
+	test.AssertError(t, err, "expected full store to refuse new entry")
+
+	fc.Add(time.Minute)
+	entry, err := store.Get(ctx, "a.com")
+	test.AssertNotError(t, err, "getting entry")
+	test.Assert(t, entry == nil, "expired entry should not be returned")
+	err = store.Set(ctx, "b.com", &CAACacheEntry{Expires: fc.Now().Add(time.Minute)})
+	test.AssertNotError(t, err, "setting entry after expiry made room")
+}
+
+func TestCAACacheEntryJSON(t *testing.T) {
+	rr, err := dns.NewRR("present.com. 300 IN CAA 128 issue \"letsencrypt.org; accounturi=https://example.com/acct/1\"")
+	test.AssertNotError(t, err, "parsing CAA record")
+	entry := CAACacheEntry{
+		Records:  []*dns.CAA{rr.(*dns.CAA)},
+		Response: "response",
+		DNSSEC:   bdns.DNSSECSecure,
+		Fetched:  time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+		Expires:  time.Date(2022, 10, 1, 0, 5, 0, 0, time.UTC),
+	}
+	val, err := json.Marshal(entry)
+	test.AssertNotError(t, err, "marshaling entry")
+	var got CAACacheEntry
+	err = json.Unmarshal(val, &got)
+	test.AssertNotError(t, err, "unmarshaling entry")
+	test.AssertDeepEquals(t, got, entry)
+}
@@ -0,0 +1,339 @@
+package va
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/weppos/publicsuffix-go/publicsuffix"
+
+	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/core"
+	blog "github.com/letsencrypt/boulder/log"
+	bmail "github.com/letsencrypt/boulder/mail"
+)
+
+// iodefReport describes an issuance refused because of CAA, to be reported to
+// one iodef target of the CAA set which refused it.
+type iodefReport struct {
+	target     *url.URL
+	name       string
+	accountURI string
+	time       time.Time
+}
+
+// IodefReporter reports issuance refused because of CAA to the targets of the
+// iodef records in the CAA set (RFC 8659 Section 4.4), by email to mailto:
+// targets and by POST to https: targets. The report is an IODEF document (RFC
+// 7970) sent as in RFC 6546.
+//
+// Reports are sent in the background, so that a slow or unreachable target
+// doesn't delay validation; if too many are waiting, new ones are dropped.
+// Within each window a target is sent at most one report for each name and
+// account, and at most maxPerDomain reports are sent about the names under
+// each registered domain.
+type IodefReporter struct {
+	mailer       bmail.Mailer
+	httpClient   *http.Client
+	issuerDomain string
+	window       time.Duration
+	maxPerDomain int
+	clk          clock.Clock
+	log          blog.Logger
+	reports      *prometheus.CounterVec
+
+	queue chan iodefReport
+	done  chan struct{}
+
+	mu sync.Mutex
+	// windowStart is when the current window began. The sent and perDomain
+	// maps are cleared at the start of each window. The perDomain map is keyed
+	// by registered domain.
+	windowStart time.Time
+	sent        map[string]bool
+	perDomain   map[string]int
+}
+
+// NewIodefReporter returns an IodefReporter and starts sending its reports.
+// Reports to mailto: targets are sent with mailer, and aren't sent if it's
+// nil. Reports to https: targets are sent only to addresses returned by
+// resolver, and time out after timeout.
+func NewIodefReporter(
+	mailer bmail.Mailer,
+	resolver bdns.Client,
+	issuerDomain string,
+	window time.Duration,
+	maxPerDomain int,
+	queueSize int,
+	timeout time.Duration,
+	clk clock.Clock,
+	logger blog.Logger,
+	stats prometheus.Registerer,
+) *IodefReporter {
+	reports := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "caa_iodef_reports",
+		Help: "A counter of CAA iodef reports labelled by scheme and result",
+	}, []string{"scheme", "result"})
+	stats.MustRegister(reports)
+
+	r := &IodefReporter{
+		mailer: mailer,
+		httpClient: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:       publicDialer{resolver}.DialContext,
+				DisableKeepAlives: true,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		issuerDomain: issuerDomain,
+		window:       window,
+		maxPerDomain: maxPerDomain,
+		clk:          clk,
+		log:          logger,
+		reports:      reports,
+		queue:        make(chan iodefReport, queueSize),
+		done:         make(chan struct{}),
+		sent:         make(map[string]bool),
+		perDomain:    make(map[string]int),
+	}
+	go r.run(r.queue)
+	return r
+}
+
+// Stop stops accepting reports, and returns once those waiting have been sent.
+func (r *IodefReporter) Stop() {
+	r.mu.Lock()
+	close(r.queue)
+	r.queue = nil
+	r.mu.Unlock()
+	<-r.done
+}
+
+// report queues reports of an issuance for name by the account at accountURI
+// (which may be empty), refused by the given CAA set, to each of the set's
+// iodef targets.
+func (r *IodefReporter) report(caaSet *CAASet, name, accountURI string) {
+	now := r.clk.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.queue == nil {
+		return
+	}
+	if now.Sub(r.windowStart) >= r.window {
+		r.windowStart = now
+		r.sent = make(map[string]bool)
+		r.perDomain = make(map[string]int)
+	}
+
+	domain := registeredDomain(name)
+	for _, iodef := range caaSet.Iodef {
+		target, err := url.Parse(strings.TrimSpace(iodef.Value))
+		if err != nil || (target.Scheme != "mailto" && target.Scheme != "https") || (target.Scheme == "mailto" && r.mailer == nil) {
+			r.reports.WithLabelValues("unsupported", "skipped").Inc()
+			continue
+		}
+		key := fmt.Sprintf("%s %s %s", target, name, accountURI)
+		if r.sent[key] {
+			r.reports.WithLabelValues(target.Scheme, "duplicate").Inc()
+			continue
+		}
+		if r.perDomain[domain] >= r.maxPerDomain {
+			r.reports.WithLabelValues(target.Scheme, "rate_limited").Inc()
+			continue
+		}
+		select {
+		case r.queue <- iodefReport{target: target, name: name, accountURI: accountURI, time: now}:
+			r.sent[key] = true
+			r.perDomain[domain]++
+		default:
+			r.reports.WithLabelValues(target.Scheme, "dropped").Inc()
+		}
+	}
+}
+
+// registeredDomain returns the domain registered beneath a public suffix which
+// name belongs to, so that reports about the many names under a domain share
+// its limit. If name is itself a public suffix it's returned unchanged.
+func registeredDomain(name string) string {
+	domain, err := publicsuffix.Domain(strings.TrimPrefix(name, "*."))
+	if err != nil {
+		return name
+	}
+	return domain
+}
+
+func (r *IodefReporter) run(queue <-chan iodefReport) {
+	defer close(r.done)
+	for rep := range queue {
+		var err error
+		if rep.target.Scheme == "mailto" {
+			err = r.sendMail(rep)
+		} else {
+			err = r.post(rep)
+		}
+		if err != nil {
+			r.log.Warningf("Sending CAA iodef report for %s to %s: %s", rep.name, rep.target, err)
+			r.reports.WithLabelValues(rep.target.Scheme, "failed").Inc()
+			continue
+		}
+		r.reports.WithLabelValues(rep.target.Scheme, "sent").Inc()
+	}
+}
+
+func (r *IodefReporter) sendMail(rep iodefReport) error {
+	doc, err := r.document(rep)
+	if err != nil {
+		return err
+	}
+	body := fmt.Sprintf("%s refused to issue a certificate for %s because of the domain's CAA records.\n\n%s\n",
+		r.issuerDomain, rep.name, doc)
+	subject := fmt.Sprintf("Certificate issuance for %s refused by CAA", rep.name)
+
+	err = r.mailer.Connect()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.mailer.Close()
+	}()
+	return r.mailer.SendMail([]string{rep.target.Opaque}, subject, body)
+}
+
+func (r *IodefReporter) post(rep iodefReport) error {
+	doc, err := r.document(rep)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, rep.target.String(), bytes.NewReader(doc))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/rfc6546+xml")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	return nil
+}
+
+// iodefDocument is the subset of an IODEF document (RFC 7970) used to report
+// refused issuance.
+type iodefDocument struct {
+	XMLName  xml.Name      `xml:"urn:ietf:params:xml:ns:iodef-2.0 IODEF-Document"`
+	Version  string        `xml:"version,attr"`
+	Lang     string        `xml:"xml:lang,attr"`
+	Incident iodefIncident `xml:"Incident"`
+}
+
+type iodefIncident struct {
+	Purpose        string                `xml:"purpose,attr"`
+	IncidentID     iodefIncidentID       `xml:"IncidentID"`
+	GenerationTime string                `xml:"GenerationTime"`
+	Description    string                `xml:"Description"`
+	Contact        iodefContact          `xml:"Contact"`
+	AdditionalData []iodefAdditionalData `xml:"AdditionalData"`
+}
+
+type iodefIncidentID struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type iodefContact struct {
+	Role        string `xml:"role,attr"`
+	Type        string `xml:"type,attr"`
+	ContactName string `xml:"ContactName"`
+}
+
+type iodefAdditionalData struct {
+	DType   string `xml:"dtype,attr"`
+	Meaning string `xml:"meaning,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// document returns the IODEF document reporting rep.
+func (r *IodefReporter) document(rep iodefReport) ([]byte, error) {
+	doc := iodefDocument{
+		Version: "2.00",
+		Lang:    "en",
+		Incident: iodefIncident{
+			Purpose: "reporting",
+			IncidentID: iodefIncidentID{
+				Name:  r.issuerDomain,
+				Value: core.RandomString(16),
+			},
+			GenerationTime: rep.time.UTC().Format(time.RFC3339),
+			Description:    fmt.Sprintf("Certificate issuance for %s refused because of its CAA records", rep.name),
+			Contact: iodefContact{
+				Role:        "creator",
+				Type:        "organization",
+				ContactName: r.issuerDomain,
+			},
+			AdditionalData: []iodefAdditionalData{
+				{DType: "string", Meaning: "requested name", Value: rep.name},
+			},
+		},
+	}
+	if rep.accountURI != "" {
+		doc.Incident.AdditionalData = append(doc.Incident.AdditionalData,
+			iodefAdditionalData{DType: "string", Meaning: "account URI", Value: rep.accountURI})
+	}
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// publicDialer dials hosts only at the addresses its resolver returns for them,
+// so that iodef targets can't be used to reach private or reserved addresses.
+type publicDialer struct {
+	resolver bdns.Client
+}
+
+func (d publicDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		if bdns.IsReservedIP(ip) {
+			return nil, fmt.Errorf("refusing to connect to reserved address %s", ip)
+		}
+		addrs = []net.IP{ip}
+	} else {
+		addrs, _, err = d.resolver.LookupHost(ctx, dns.Fqdn(host))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var dialer net.Dialer
+	err = errors.New("no addresses to dial")
+	for _, ip := range addrs {
+		var conn net.Conn
+		conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
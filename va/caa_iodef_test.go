@@ -0,0 +1,172 @@
+package va
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/identifier"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/mocks"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// iodefMockDNS returns CAA records with the given iodef targets for
+// refused.com, which forbid issuance by letsencrypt.org, and for
+// permitted.com, which permit it.
+type iodefMockDNS struct {
+	bdns.MockClient
+	targets []string
+}
+
+func (mock *iodefMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, bdns.DNSSECState, error) {
+	var issuer string
+	switch strings.TrimRight(domain, ".") {
+	case "refused.com":
+		issuer = "ca.example.net"
+	case "permitted.com":
+		issuer = "letsencrypt.org"
+	default:
+		return nil, "", bdns.DNSSECUnchecked, nil
+	}
+	records := []*dns.CAA{{Tag: "issue", Value: issuer}}
+	for _, target := range mock.targets {
+		records = append(records, &dns.CAA{Tag: "iodef", Value: target})
+	}
+	return records, "", bdns.DNSSECUnchecked, nil
+}
+
+func setupIodef(t *testing.T, targets ...string) (*ValidationAuthorityImpl, *mocks.Mailer, clock.FakeClock) {
+	va, _ := setup(nil, 0, "", nil)
+	fc := va.clk.(clock.FakeClock)
+	va.dnsClient = &iodefMockDNS{targets: targets}
+	va.accountURIPrefixes = []string{"https://letsencrypt.org/acct/"}
+	mailer := &mocks.Mailer{}
+	va.iodefReporter = NewIodefReporter(mailer, va.dnsClient, va.issuerDomain, time.Hour, 2, 10, time.Second, fc, blog.NewMock(), metrics.NoopRegisterer)
+	return va, mailer, fc
+}
+
+func TestIodefReportMail(t *testing.T) {
+	va, mailer, _ := setupIodef(t, "mailto:security@refused.com")
+
+	_, valid, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier("refused.com"), &caaParams{accountURIID: 123})
+	test.AssertNotError(t, err, "checking CAA")
+	test.Assert(t, !valid, "CAA should forbid issuance")
+	va.iodefReporter.Stop()
+
+	test.AssertEquals(t, len(mailer.Messages), 1)
+	msg := mailer.Messages[0]
+	test.AssertEquals(t, msg.To, "security@refused.com")
+	test.AssertContains(t, msg.Subject, "refused.com")
+	test.AssertContains(t, msg.Body, "<AdditionalData dtype=\"string\" meaning=\"requested name\">refused.com</AdditionalData>")
+	test.AssertContains(t, msg.Body, "<AdditionalData dtype=\"string\" meaning=\"account URI\">https://letsencrypt.org/acct/123</AdditionalData>")
+	test.AssertMetricWithLabelsEquals(t, va.iodefReporter.reports, prometheus.Labels{"scheme": "mailto", "result": "sent"}, 1)
+}
+
+func TestIodefNoReportWhenPermitted(t *testing.T) {
+	va, mailer, _ := setupIodef(t, "mailto:security@permitted.com")
+
+	_, valid, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier("permitted.com"), nil)
+	test.AssertNotError(t, err, "checking CAA")
+	test.Assert(t, valid, "CAA should permit issuance")
+	va.iodefReporter.Stop()
+
+	test.AssertEquals(t, len(mailer.Messages), 0)
+}
+
+func TestIodefDeduplicationAndRateLimit(t *testing.T) {
+	va, mailer, fc := setupIodef(t, "mailto:security@refused.com", "ftp://refused.com/reports")
+	check := func(id int64) {
+		t.Helper()
+		_, _, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier("refused.com"), &caaParams{accountURIID: id})
+		test.AssertNotError(t, err, "checking CAA")
+	}
+
+	// The same name and account is reported once per window, and at most two
+	// reports are sent about the name.
+	check(1)
+	check(1)
+	check(2)
+	check(3)
+	// A new window resets both.
+	fc.Add(time.Hour)
+	check(1)
+	va.iodefReporter.Stop()
+
+	test.AssertEquals(t, len(mailer.Messages), 3)
+	reports := va.iodefReporter.reports
+	test.AssertMetricWithLabelsEquals(t, reports, prometheus.Labels{"scheme": "mailto", "result": "duplicate"}, 1)
+	test.AssertMetricWithLabelsEquals(t, reports, prometheus.Labels{"scheme": "mailto", "result": "rate_limited"}, 1)
+	test.AssertMetricWithLabelsEquals(t, reports, prometheus.Labels{"scheme": "unsupported", "result": "skipped"}, 5)
+}
+
+func TestIodefRateLimitByRegisteredDomain(t *testing.T) {
+	va, mailer, _ := setupIodef(t, "mailto:security@refused.com")
+	for _, name := range []string{"refused.com", "www.refused.com", "mail.refused.com"} {
+		_, _, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier(name), &caaParams{accountURIID: 1})
+		test.AssertNotError(t, err, "checking CAA")
+	}
+	va.iodefReporter.Stop()
+
+	// Each name is reported separately, but they share refused.com's limit.
+	test.AssertEquals(t, len(mailer.Messages), 2)
+	test.AssertMetricWithLabelsEquals(t, va.iodefReporter.reports, prometheus.Labels{"scheme": "mailto", "result": "rate_limited"}, 1)
+}
+
+func TestRegisteredDomain(t *testing.T) {
+	test.AssertEquals(t, registeredDomain("www.refused.com"), "refused.com")
+	test.AssertEquals(t, registeredDomain("*.www.refused.co.uk"), "refused.co.uk")
+	test.AssertEquals(t, registeredDomain("co.uk"), "co.uk")
+}
+
+func TestIodefReportPOST(t *testing.T) {
+	var body []byte
+	var contentType string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	va, _, _ := setupIodef(t, srv.URL+"/iodef")
+	// The test server is on a loopback address, which the reporter's own
+	// client refuses to connect to.
+	va.iodefReporter.httpClient = srv.Client()
+
+	_, _, _, _, err := va.checkCAARecords(ctx, identifier.DNSIdentifier("refused.com"), &caaParams{accountURIID: 123})
+	test.AssertNotError(t, err, "checking CAA")
+	va.iodefReporter.Stop()
+
+	test.AssertEquals(t, contentType, "application/rfc6546+xml")
+	var doc iodefDocument
+	err = xml.Unmarshal(body, &doc)
+	test.AssertNotError(t, err, "unmarshaling IODEF document")
+	test.AssertEquals(t, doc.Incident.Contact.ContactName, "letsencrypt.org")
+	test.AssertEquals(t, doc.Incident.IncidentID.Name, "letsencrypt.org")
+	test.AssertEquals(t, len(doc.Incident.AdditionalData), 2)
+	test.AssertEquals(t, doc.Incident.AdditionalData[0].Value, "refused.com")
+	test.AssertEquals(t, doc.Incident.AdditionalData[1].Value, "https://letsencrypt.org/acct/123")
+	test.AssertMetricWithLabelsEquals(t, va.iodefReporter.reports, prometheus.Labels{"scheme": "https", "result": "sent"}, 1)
+}
+
+func TestIodefPublicDialerRefusesReservedAddresses(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening")
+	defer l.Close()
+
+	_, err = publicDialer{&bdns.MockClient{}}.DialContext(ctx, "tcp", l.Addr().String())
+	test.AssertError(t, err, "dialing a loopback address should fail")
+	test.AssertContains(t, err.Error(), "reserved address")
+}
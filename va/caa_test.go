@@ -11,6 +11,7 @@ import (
 
 	"github.com/miekg/dns"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
@@ -25,21 +26,21 @@ import (
 // answers for CAA queries.
 type caaMockDNS struct{}
 
-func (mock caaMockDNS) LookupTXT(_ context.Context, hostname string) ([]string, error) {
-	return nil, nil
+func (mock caaMockDNS) LookupTXT(_ context.Context, hostname string) ([]string, bdns.DNSSECState, error) {
+	return nil, bdns.DNSSECUnchecked, nil
 }
 
-func (mock caaMockDNS) LookupHost(_ context.Context, hostname string) ([]net.IP, error) {
+func (mock caaMockDNS) LookupHost(_ context.Context, hostname string) ([]net.IP, bdns.DNSSECState, error) {
 	ip := net.ParseIP("127.0.0.1")
-	return []net.IP{ip}, nil
+	return []net.IP{ip}, bdns.DNSSECUnchecked, nil
 }
 
-func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, error) {
+func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, bdns.DNSSECState, error) {
 	var results []*dns.CAA
 	var record dns.CAA
 	switch strings.TrimRight(domain, ".") {
 	case "caa-timeout.com":
-		return nil, "", fmt.Errorf("error")
+		return nil, "", bdns.DNSSECUnchecked, fmt.Errorf("error")
 	case "reserved.com":
 		record.Tag = "issue"
 		record.Value = "ca.com"
@@ -59,9 +60,9 @@ func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA,
 		results = append(results, &record)
 	case "com":
 		// com has no CAA records.
-		return nil, "", nil
+		return nil, "", bdns.DNSSECUnchecked, nil
 	case "servfail.com", "servfail.present.com":
-		return results, "", fmt.Errorf("SERVFAIL")
+		return results, "", bdns.DNSSECUnchecked, fmt.Errorf("SERVFAIL")
 	case "multi-crit-present.com":
 		record.Flag = 1
 		record.Tag = "issue"
@@ -183,7 +184,7 @@ func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA,
 	if len(results) > 0 {
 		response = "foo"
 	}
-	return results, response, nil
+	return results, response, bdns.DNSSECUnchecked, nil
 }
 
 func TestCAATimeout(t *testing.T) {
@@ -409,7 +410,7 @@ func TestCAAChecking(t *testing.T) {
 		mockLog.Clear()
 		t.Run(caaTest.Name, func(t *testing.T) {
 			ident := identifier.DNSIdentifier(caaTest.Domain)
-			present, valid, _, err := va.checkCAARecords(ctx, ident, params)
+			present, valid, _, _, err := va.checkCAARecords(ctx, ident, params)
 			if err != nil {
 				t.Errorf("checkCAARecords error for %s: %s", caaTest.Domain, err)
 			}
@@ -427,41 +428,41 @@ func TestCAAChecking(t *testing.T) {
 
 	// present-dns-only.com should now be valid even with http-01
 	ident := identifier.DNSIdentifier("present-dns-only.com")
-	present, valid, _, err := va.checkCAARecords(ctx, ident, params)
+	present, valid, _, _, err := va.checkCAARecords(ctx, ident, params)
 	test.AssertNotError(t, err, "present-dns-only.com")
 	test.Assert(t, present, "Present should be true")
 	test.Assert(t, valid, "Valid should be true")
 
 	// present-incorrect-accounturi.com should now be also be valid
 	ident = identifier.DNSIdentifier("present-incorrect-accounturi.com")
-	present, valid, _, err = va.checkCAARecords(ctx, ident, params)
+	present, valid, _, _, err = va.checkCAARecords(ctx, ident, params)
 	test.AssertNotError(t, err, "present-incorrect-accounturi.com")
 	test.Assert(t, present, "Present should be true")
 	test.Assert(t, valid, "Valid should be true")
 
 	// nil params should be valid, too
-	present, valid, _, err = va.checkCAARecords(ctx, ident, nil)
+	present, valid, _, _, err = va.checkCAARecords(ctx, ident, nil)
 	test.AssertNotError(t, err, "present-dns-only.com")
 	test.Assert(t, present, "Present should be true")
 	test.Assert(t, valid, "Valid should be true")
 
 	ident.Value = "servfail.com"
-	present, valid, _, err = va.checkCAARecords(ctx, ident, nil)
+	present, valid, _, _, err = va.checkCAARecords(ctx, ident, nil)
 	test.AssertError(t, err, "servfail.com")
 	test.Assert(t, !present, "Present should be false")
 	test.Assert(t, !valid, "Valid should be false")
 
-	if _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
+	if _, _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
 		t.Errorf("Should have returned error on CAA lookup, but did not: %s", ident.Value)
 	}
 
 	ident.Value = "servfail.present.com"
-	present, valid, _, err = va.checkCAARecords(ctx, ident, nil)
+	present, valid, _, _, err = va.checkCAARecords(ctx, ident, nil)
 	test.AssertError(t, err, "servfail.present.com")
 	test.Assert(t, !present, "Present should be false")
 	test.Assert(t, !valid, "Valid should be false")
 
-	if _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
+	if _, _, _, _, err := va.checkCAARecords(ctx, ident, nil); err == nil {
 		t.Errorf("Should have returned error on CAA lookup, but did not: %s", ident.Value)
 	}
 }
@@ -479,55 +480,55 @@ func TestCAALogging(t *testing.T) {
 	}{
 		{
 			Domain:          "reserved.com",
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: unknown, Challenge: unknown, Valid for issuance: false] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: unknown, Challenge: unknown, Valid for issuance: false, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "reserved.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeHTTP01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "reserved.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeDNS01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: dns-01, Valid for issuance: false] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for reserved.com, [Present: true, Account ID: 12345, Challenge: dns-01, Valid for issuance: false, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "mixedcase.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeHTTP01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for mixedcase.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for mixedcase.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "critical.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeHTTP01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for critical.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for critical.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "present.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeHTTP01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "multi-crit-present.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeHTTP01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for multi-crit-present.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for multi-crit-present.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "present-with-parameter.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeHTTP01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present-with-parameter.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for present-with-parameter.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: true, DNSSEC: unchecked] Response=\"foo\"",
 		},
 		{
 			Domain:          "satisfiable-wildcard-override.com",
 			AccountURIID:    12345,
 			ChallengeType:   core.ChallengeTypeHTTP01,
-			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for satisfiable-wildcard-override.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false] Response=\"foo\"",
+			ExpectedLogline: "INFO: [AUDIT] Checked CAA records for satisfiable-wildcard-override.com, [Present: true, Account ID: 12345, Challenge: http-01, Valid for issuance: false, DNSSEC: unchecked] Response=\"foo\"",
 		},
 	}
 
@@ -594,27 +595,27 @@ func TestCAAFailure(t *testing.T) {
 func TestParseResults(t *testing.T) {
 	// An empty slice of caaResults should return nil, "", nil
 	r := []caaResult{}
-	s, response, err := parseResults(r)
+	s, response, _, err := parseResults(r)
 	test.Assert(t, s == nil, "set is not nil")
 	test.Assert(t, err == nil, "error is not nil")
 	test.Assert(t, response == "", "records is not nil")
 	// A slice of empty caaResults should return nil, "", nil
 	r = []caaResult{
-		{[]*dns.CAA{}, "", nil},
-		{[]*dns.CAA{}, "", nil},
-		{[]*dns.CAA{}, "", nil},
+		{[]*dns.CAA{}, "", bdns.DNSSECUnchecked, nil},
+		{[]*dns.CAA{}, "", bdns.DNSSECUnchecked, nil},
+		{[]*dns.CAA{}, "", bdns.DNSSECUnchecked, nil},
 	}
-	s, response, err = parseResults(r)
+	s, response, _, err = parseResults(r)
 	test.Assert(t, s == nil, "set is not nil")
 	test.Assert(t, err == nil, "error is not nil")
 	test.AssertEquals(t, response, "")
 	// A slice of caaResults containing an error followed by a CAA
 	// record should return the error
 	r = []caaResult{
-		{nil, "", errors.New("")},
-		{[]*dns.CAA{{Value: "test"}}, "", nil},
+		{nil, "", bdns.DNSSECUnchecked, errors.New("")},
+		{[]*dns.CAA{{Value: "test"}}, "", bdns.DNSSECUnchecked, nil},
 	}
-	s, response, err = parseResults(r)
+	s, response, _, err = parseResults(r)
 	test.Assert(t, s == nil, "set is not nil")
 	test.AssertEquals(t, err.Error(), "")
 	test.AssertEquals(t, response, "")
@@ -622,10 +623,10 @@ func TestParseResults(t *testing.T) {
 	//  error, should return that good record, not the error
 	expected := dns.CAA{Value: "foo"}
 	r = []caaResult{
-		{[]*dns.CAA{&expected}, "foo", nil},
-		{nil, "", errors.New("")},
+		{[]*dns.CAA{&expected}, "foo", bdns.DNSSECUnchecked, nil},
+		{nil, "", bdns.DNSSECUnchecked, errors.New("")},
 	}
-	s, response, err = parseResults(r)
+	s, response, _, err = parseResults(r)
 	test.AssertEquals(t, len(s.Unknown), 1)
 	test.Assert(t, s.Unknown[0] == &expected, "Incorrect record returned")
 	test.AssertEquals(t, response, "foo")
@@ -633,15 +634,25 @@ func TestParseResults(t *testing.T) {
 	// A slice of caaResults containing multiple CAA records should
 	// return the first non-empty CAA record
 	r = []caaResult{
-		{[]*dns.CAA{}, "", nil},
-		{[]*dns.CAA{&expected}, "foo", nil},
-		{[]*dns.CAA{{Value: "bar"}}, "bar", nil},
+		{[]*dns.CAA{}, "", bdns.DNSSECUnchecked, nil},
+		{[]*dns.CAA{&expected}, "foo", bdns.DNSSECUnchecked, nil},
+		{[]*dns.CAA{{Value: "bar"}}, "bar", bdns.DNSSECUnchecked, nil},
 	}
-	s, response, err = parseResults(r)
+	s, response, _, err = parseResults(r)
 	test.AssertEquals(t, len(s.Unknown), 1)
 	test.Assert(t, s.Unknown[0] == &expected, "Incorrect record returned")
 	test.AssertEquals(t, response, "foo")
 	test.AssertNotError(t, err, "no error should be returned")
+	// The DNSSEC state should be the weakest of the results examined, ignoring
+	// those after the first non-empty CAA record
+	r = []caaResult{
+		{[]*dns.CAA{}, "", bdns.DNSSECSecure, nil},
+		{[]*dns.CAA{&expected}, "foo", bdns.DNSSECInsecure, nil},
+		{[]*dns.CAA{}, "", bdns.DNSSECUnchecked, nil},
+	}
+	_, _, dnssec, err := parseResults(r)
+	test.AssertNotError(t, err, "no error should be returned")
+	test.AssertEquals(t, dnssec, bdns.DNSSECInsecure)
 }
 
 func TestCheckAccountURI(t *testing.T) {
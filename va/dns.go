@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/identifier"
@@ -19,20 +20,21 @@ import (
 // resolved. This is the same choice made by the Go internal resolution library
 // used by net/http. If there is an error resolving the hostname, or if no
 // usable IP addresses are available then a berrors.DNSError instance is
-// returned with a nil net.IP slice.
-func (va ValidationAuthorityImpl) getAddrs(ctx context.Context, hostname string) ([]net.IP, error) {
-	addrs, err := va.dnsClient.LookupHost(ctx, hostname)
+// returned with a nil net.IP slice. The DNSSEC state of the lookups is also
+// returned.
+func (va ValidationAuthorityImpl) getAddrs(ctx context.Context, hostname string) ([]net.IP, bdns.DNSSECState, error) {
+	addrs, dnssec, err := va.dnsClient.LookupHost(ctx, hostname)
 	if err != nil {
-		return nil, berrors.DNSError("%v", err)
+		return nil, dnssec, berrors.DNSError("%v", err)
 	}
 
 	if len(addrs) == 0 {
 		// This should be unreachable, as no valid IP addresses being found results
 		// in an error being returned from LookupHost.
-		return nil, berrors.DNSError("No valid IP addresses found for %s", hostname)
+		return nil, dnssec, berrors.DNSError("No valid IP addresses found for %s", hostname)
 	}
 	va.log.Debugf("Resolved addresses for %s: %s", hostname, addrs)
-	return addrs, nil
+	return addrs, dnssec, nil
 }
 
 // availableAddresses takes a ValidationRecord and splits the AddressesResolved
@@ -54,14 +56,51 @@ func (va *ValidationAuthorityImpl) validateDNS01(ctx context.Context, ident iden
 		return nil, probs.Malformed("Identifier type for DNS was not itself DNS")
 	}
 
+	challengeSubdomain := fmt.Sprintf("%s.%s", core.DNSPrefix, ident.Value)
+	return va.validateTXT(ctx, ident, challenge, challengeSubdomain)
+}
+
+// validateDNSAccount01 validates a dns-account-01 challenge, which differs from
+// dns-01 only in that the TXT record is published beneath an account-specific
+// label (see core.DNSAccountLabel). The account URL the client used to derive
+// the label isn't known, so each of the configured account URI prefixes is
+// tried in turn. If none of them succeeds the problem from the first is
+// returned.
+func (va *ValidationAuthorityImpl) validateDNSAccount01(ctx context.Context, ident identifier.ACMEIdentifier, challenge core.Challenge, regid int64) ([]core.ValidationRecord, *probs.ProblemDetails) {
+	if ident.Type != identifier.DNS {
+		va.log.Infof("Identifier type for DNS challenge was not DNS: %s", ident)
+		return nil, probs.Malformed("Identifier type for DNS was not itself DNS")
+	}
+	if len(va.accountURIPrefixes) == 0 {
+		return nil, probs.ServerInternal("No account URI prefixes configured for dns-account-01 validation")
+	}
+
+	var firstProb *probs.ProblemDetails
+	for _, prefix := range va.accountURIPrefixes {
+		accountURL := fmt.Sprintf("%s%d", prefix, regid)
+		challengeSubdomain := fmt.Sprintf("%s.%s.%s", core.DNSAccountLabel(accountURL), core.DNSPrefix, ident.Value)
+		records, prob := va.validateTXT(ctx, ident, challenge, challengeSubdomain)
+		if prob == nil {
+			return records, nil
+		}
+		if firstProb == nil {
+			firstProb = prob
+		}
+	}
+	return nil, firstProb
+}
+
+// validateTXT looks up the TXT records at challengeSubdomain and checks that
+// one of them is the digest of the challenge's key authorization, as the
+// dns-01 and dns-account-01 challenges require.
+func (va *ValidationAuthorityImpl) validateTXT(ctx context.Context, ident identifier.ACMEIdentifier, challenge core.Challenge, challengeSubdomain string) ([]core.ValidationRecord, *probs.ProblemDetails) {
 	// Compute the digest of the key authorization file
 	h := sha256.New()
 	h.Write([]byte(challenge.ProvidedKeyAuthorization))
 	authorizedKeysDigest := base64.RawURLEncoding.EncodeToString(h.Sum(nil))
 
 	// Look for the required record in the DNS
-	challengeSubdomain := fmt.Sprintf("%s.%s", core.DNSPrefix, ident.Value)
-	txts, err := va.dnsClient.LookupTXT(ctx, challengeSubdomain)
+	txts, dnssec, err := va.dnsClient.LookupTXT(ctx, challengeSubdomain)
 	if err != nil {
 		return nil, probs.DNS(err.Error())
 	}
@@ -76,7 +115,7 @@ func (va *ValidationAuthorityImpl) validateDNS01(ctx context.Context, ident iden
 	for _, element := range txts {
 		if subtle.ConstantTimeCompare([]byte(element), []byte(authorizedKeysDigest)) == 1 {
 			// Successful challenge validation
-			return []core.ValidationRecord{{Hostname: ident.Value}}, nil
+			return []core.ValidationRecord{{Hostname: ident.Value, DNSSEC: string(dnssec)}}, nil
 		}
 	}
 
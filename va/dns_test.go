@@ -95,7 +95,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 
 	chall := dnsChallenge()
 	chall.Token = ""
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 0, chall)
 	if prob.Type != probs.MalformedProblem {
 		t.Errorf("Got wrong error type: expected %s, got %s",
 			prob.Type, probs.MalformedProblem)
@@ -105,7 +105,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 	}
 
 	chall.Token = "yfCBb-bRTLz8Wd1C0lTUQK3qlKj3-t2tYGwx5Hj7r_"
-	_, prob = va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob = va.validateChallenge(ctx, dnsi("localhost"), 0, chall)
 	if prob.Type != probs.MalformedProblem {
 		t.Errorf("Got wrong error type: expected %s, got %s",
 			prob.Type, probs.MalformedProblem)
@@ -115,7 +115,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 	}
 
 	chall.ProvidedKeyAuthorization = "a"
-	_, prob = va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob = va.validateChallenge(ctx, dnsi("localhost"), 0, chall)
 	if prob.Type != probs.MalformedProblem {
 		t.Errorf("Got wrong error type: expected %s, got %s",
 			prob.Type, probs.MalformedProblem)
@@ -129,7 +129,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 func TestDNSValidationServFail(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("servfail.com"), dnsChallenge())
+	_, prob := va.validateChallenge(ctx, dnsi("servfail.com"), 0, dnsChallenge())
 
 	test.AssertEquals(t, prob.Type, probs.DNSProblem)
 }
@@ -142,12 +142,14 @@ func TestDNSValidationNoServer(t *testing.T) {
 	va.dnsClient = bdns.NewTest(
 		time.Second*5,
 		staticProvider,
+		nil,
+		nil,
 		metrics.NoopRegisterer,
 		clock.New(),
 		1,
 		log)
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), dnsChallenge())
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 0, dnsChallenge())
 
 	test.AssertEquals(t, prob.Type, probs.DNSProblem)
 }
@@ -155,7 +157,7 @@ func TestDNSValidationNoServer(t *testing.T) {
 func TestDNSValidationOK(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("good-dns01.com"), dnsChallenge())
+	_, prob := va.validateChallenge(ctx, dnsi("good-dns01.com"), 0, dnsChallenge())
 
 	test.Assert(t, prob == nil, "Should be valid.")
 }
@@ -163,11 +165,40 @@ func TestDNSValidationOK(t *testing.T) {
 func TestDNSValidationNoAuthorityOK(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("no-authority-dns01.com"), dnsChallenge())
+	_, prob := va.validateChallenge(ctx, dnsi("no-authority-dns01.com"), 0, dnsChallenge())
 
 	test.Assert(t, prob == nil, "Should be valid.")
 }
 
+func TestDNSAccountValidationOK(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	records, prob := va.validateChallenge(ctx, dnsi("good-dns-account01.com"), 1, createChallenge(core.ChallengeTypeDNSAccount01))
+
+	test.Assert(t, prob == nil, "Should be valid.")
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].Hostname, "good-dns-account01.com")
+}
+
+func TestDNSAccountValidationWrongAccount(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	// The record is published under the label for account 1, not account 2.
+	_, prob := va.validateChallenge(ctx, dnsi("good-dns-account01.com"), 2, createChallenge(core.ChallengeTypeDNSAccount01))
+
+	test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
+	test.AssertContains(t, prob.Detail, "._acme-challenge.good-dns-account01.com")
+}
+
+func TestDNSAccountValidationNoAccountURIPrefixes(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+	va.accountURIPrefixes = nil
+
+	_, prob := va.validateChallenge(ctx, dnsi("good-dns-account01.com"), 1, createChallenge(core.ChallengeTypeDNSAccount01))
+
+	test.AssertEquals(t, prob.Type, probs.ServerInternalProblem)
+}
+
 func TestAvailableAddresses(t *testing.T) {
 	v6a := net.ParseIP("::1")
 	v6b := net.ParseIP("2001:db8::2:1") // 2001:DB8 is reserved for docs (RFC 3849)
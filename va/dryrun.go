@@ -0,0 +1,116 @@
+package va
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
+	"github.com/letsencrypt/boulder/probs"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+	"github.com/miekg/dns"
+)
+
+// DryRunValidation performs a validation of the given challenge type and key
+// authorization against a domain, without an authorization, so that support
+// staff can see why a subscriber's validations fail. Like PerformValidation it
+// makes no changes to the database, but it also skips the remote VAs and
+// doesn't report refusals to CAA iodef targets. The result includes the
+// certificates presented for a tls-alpn-01 challenge and the CAA set found,
+// in addition to the validation records and problem.
+//
+// DryRunValidation is part of the VADebug service, which must only be served
+// to administrative clients.
+func (va *ValidationAuthorityImpl) DryRunValidation(ctx context.Context, req *vapb.DryRunValidationRequest) (*vapb.DryRunValidationResult, error) {
+	if core.IsAnyNilOrZero(req, req.Domain, req.ChallengeType, req.KeyAuthorization) {
+		return nil, berrors.InternalServerError("Incomplete dry run validation request")
+	}
+
+	ident := identifier.DNSIdentifier(req.Domain)
+	if req.TypeIdentifier == string(identifier.IP) {
+		ident = identifier.ACMEIdentifier{Type: identifier.IP, Value: req.Domain}
+	}
+	baseIdentifier := ident
+	baseIdentifier.Value = strings.TrimPrefix(ident.Value, "*.")
+
+	challenge := core.Challenge{
+		Type:                     core.AcmeChallenge(req.ChallengeType),
+		Status:                   core.StatusPending,
+		Token:                    strings.SplitN(req.KeyAuthorization, ".", 2)[0],
+		ProvidedKeyAuthorization: req.KeyAuthorization,
+	}
+
+	var records []core.ValidationRecord
+	var cs *tls.ConnectionState
+	var prob *probs.ProblemDetails
+	if challenge.Type == core.ChallengeTypeTLSALPN01 {
+		err := challenge.CheckConsistencyForValidation()
+		if err != nil {
+			prob = probs.Malformed("Challenge failed consistency check: %s", err)
+		} else {
+			records, cs, prob = va.checkTLSALPN01(ctx, baseIdentifier, challenge)
+		}
+	} else {
+		records, prob = va.validateChallenge(ctx, baseIdentifier, req.RegID, challenge)
+	}
+
+	result := &vapb.DryRunValidationResult{}
+	if cs != nil {
+		for _, cert := range cs.PeerCertificates {
+			result.TlsALPNCertificates = append(result.TlsALPNCertificates, cert.Raw)
+		}
+		result.TlsALPNProtocol = cs.NegotiatedProtocol
+	}
+
+	// CAA does not apply to IP address identifiers (RFC 8738 Section 7).
+	if ident.Type != identifier.IP {
+		caaRecords, dnssec, caaProb := va.dryRunCAA(ctx, ident, &caaParams{
+			accountURIID:     req.RegID,
+			validationMethod: req.ChallengeType,
+		})
+		result.CaaRecords = caaRecords
+		result.CaaDNSSEC = dnssec
+		if prob == nil {
+			prob = caaProb
+		}
+	}
+
+	va.log.AuditInfof("Dry run validation of %s for %s: problem=%v", req.ChallengeType, req.Domain, prob)
+
+	pbResult, err := bgrpc.ValidationResultToPB(records, prob)
+	if err != nil {
+		return nil, err
+	}
+	result.Records = pbResult.Records
+	result.Problem = pbResult.Problems
+	return result, nil
+}
+
+// dryRunCAA checks CAA for ident like checkCAA, but without reporting a
+// refusal to the CAA set's iodef targets. It returns the CAA set found, in
+// presentation format, and its DNSSEC state.
+func (va *ValidationAuthorityImpl) dryRunCAA(ctx context.Context, ident identifier.ACMEIdentifier, params *caaParams) ([]string, string, *probs.ProblemDetails) {
+	hostname := strings.ToLower(ident.Value)
+	wildcard := strings.HasPrefix(hostname, "*.")
+	caaSet, _, dnssec, err := va.getCAASet(ctx, strings.TrimPrefix(hostname, "*."), false)
+	if err != nil {
+		return nil, string(dnssec), probs.DNS(err.Error())
+	}
+	var records []string
+	if caaSet != nil {
+		for _, set := range [][]*dns.CAA{caaSet.Issue, caaSet.Issuewild, caaSet.Iodef, caaSet.Unknown} {
+			for _, caa := range set {
+				records = append(records, caa.String())
+			}
+		}
+	}
+	_, valid := va.validateCAASet(caaSet, wildcard, params)
+	if !valid {
+		return records, string(dnssec), probs.CAA(fmt.Sprintf("CAA record for %s prevents issuance", ident.Value))
+	}
+	return records, string(dnssec), nil
+}
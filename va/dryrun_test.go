@@ -0,0 +1,94 @@
+package va
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/letsencrypt/boulder/core"
+	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/test"
+	vapb "github.com/letsencrypt/boulder/va/proto"
+)
+
+func TestDryRunValidationHTTP01(t *testing.T) {
+	hs := httpSrv(t, expectedToken)
+	defer hs.Close()
+	va, _ := setup(hs, 0, "", nil)
+	va.dnsClient = caaMockDNS{}
+
+	testCases := []struct {
+		name             string
+		domain           string
+		keyAuthorization string
+		expectedProblem  probs.ProblemType
+	}{
+		{
+			name:             "Valid",
+			domain:           "present.com",
+			keyAuthorization: expectedKeyAuthorization,
+		},
+		{
+			name:             "Wrong key authorization",
+			domain:           "present.com",
+			keyAuthorization: expectedToken + "." + core.NewToken(),
+			expectedProblem:  probs.UnauthorizedProblem,
+		},
+		{
+			name:             "Refused by CAA",
+			domain:           "reserved.com",
+			keyAuthorization: expectedKeyAuthorization,
+			expectedProblem:  probs.CAAProblem,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := va.DryRunValidation(ctx, &vapb.DryRunValidationRequest{
+				Domain:           tc.domain,
+				ChallengeType:    string(core.ChallengeTypeHTTP01),
+				KeyAuthorization: tc.keyAuthorization,
+			})
+			test.AssertNotError(t, err, "DryRunValidation failed")
+			if tc.expectedProblem == "" {
+				test.Assert(t, res.Problem == nil, "unexpected problem")
+			} else {
+				test.AssertNotNil(t, res.Problem, "expected a problem")
+				test.AssertEquals(t, res.Problem.ProblemType, string(tc.expectedProblem))
+			}
+			test.AssertEquals(t, len(res.Records), 1)
+			test.AssertEquals(t, res.Records[0].Hostname, tc.domain)
+			test.AssertEquals(t, len(res.Records[0].AddressesResolved), 1)
+			test.AssertEquals(t, len(res.CaaRecords), 1)
+			test.AssertEquals(t, len(res.TlsALPNCertificates), 0)
+		})
+	}
+}
+
+func TestDryRunValidationTLSALPN01(t *testing.T) {
+	chall := tlsalpnChallenge()
+	hs, err := tlsalpn01Srv(t, chall, IdPeAcmeIdentifier, 0, "expected")
+	test.AssertNotError(t, err, "Error creating test server")
+	defer hs.Close()
+	va, _ := setup(hs, 0, "", nil)
+
+	res, err := va.DryRunValidation(ctx, &vapb.DryRunValidationRequest{
+		Domain:           "expected",
+		ChallengeType:    string(core.ChallengeTypeTLSALPN01),
+		KeyAuthorization: chall.ProvidedKeyAuthorization,
+	})
+	test.AssertNotError(t, err, "DryRunValidation failed")
+	test.Assert(t, res.Problem == nil, "unexpected problem")
+	test.AssertEquals(t, res.TlsALPNProtocol, ACMETLS1Protocol)
+	test.AssertEquals(t, len(res.TlsALPNCertificates), 1)
+	cert, err := x509.ParseCertificate(res.TlsALPNCertificates[0])
+	test.AssertNotError(t, err, "parsing presented certificate")
+	test.AssertDeepEquals(t, cert.DNSNames, []string{"expected"})
+}
+
+func TestDryRunValidationIncomplete(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+	_, err := va.DryRunValidation(ctx, &vapb.DryRunValidationRequest{
+		Domain:        "present.com",
+		ChallengeType: string(core.ChallengeTypeHTTP01),
+	})
+	test.AssertError(t, err, "DryRunValidation without a key authorization should fail")
+}
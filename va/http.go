@@ -3,6 +3,7 @@ package va
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/iana"
@@ -37,6 +39,159 @@ const (
 	maxPathSize = 2000
 )
 
+// The reasons for which the VA's http-01 policy refuses a validation, which
+// label the http01_policy_rejections metric. Each produces a distinct problem
+// detail, an example of which is given beside it.
+const (
+	// "Too many redirects"
+	rejectTooManyRedirects = "too_many_redirects"
+	// "received disallowed redirect status code"
+	rejectRedirectStatus = "redirect_status"
+	// `Invalid protocol scheme in redirect target. Only "http" and "https"
+	// protocol schemes are supported, not "gopher"`
+	rejectRedirectScheme = "redirect_scheme"
+	// "Invalid port in redirect target. Only ports 80 and 443 are supported,
+	// not 9999"
+	rejectRedirectPort = "redirect_port"
+	// "Invalid empty hostname in redirect target"
+	rejectRedirectEmptyHost = "redirect_empty_host"
+	// `Invalid host in redirect target "10.10.10.10". Only domain names are
+	// supported, not IP addresses`
+	rejectRedirectIPHost = "redirect_ip_host"
+	// `Invalid host in redirect target "example.com.well-known". Check
+	// webserver config for missing '/' in redirect target.`
+	rejectRedirectMissingSlash = "redirect_missing_slash"
+	// "Invalid hostname in redirect target, must end in IANA registered TLD"
+	rejectRedirectTLD = "redirect_tld"
+	// "Redirect target too long"
+	rejectRedirectPathLength = "redirect_path_length"
+	// "Redirect loop detected"
+	rejectRedirectLoop = "redirect_loop"
+	// `Invalid redirect target "example.com". It resolves to 192.0.2.1, an
+	// address the VA doesn't connect to`
+	rejectRedirectDeniedAddress = "redirect_denied_address"
+	// `Invalid certificate presented by HTTPS redirect target "example.com":
+	// x509: certificate signed by unknown authority`
+	rejectRedirectCertificate = "redirect_certificate"
+	// `Invalid response from http://example.com/.well-known/acme-challenge/xxx
+	// [192.0.2.1]: body of at least 128 bytes: "<html>..."`
+	rejectResponseTooLarge = "response_too_large"
+)
+
+// HTTP01Policy configures how the VA follows redirects and reads responses
+// while validating http-01 challenges. A nil *HTTP01Policy, or a zero field,
+// uses the VA's defaults.
+type HTTP01Policy struct {
+	// maxRedirects is the number of redirects followed. Defaults to
+	// maxRedirect.
+	maxRedirects int
+	// redirectPorts are the ports which a redirect target may specify
+	// explicitly. Defaults to the VA's HTTP and HTTPS ports.
+	redirectPorts []int
+	// verifyHTTPSRedirects requires the targets of HTTPS redirects to present
+	// a certificate which is valid for their hostname.
+	verifyHTTPSRedirects bool
+	// redirectRoots are the roots trusted when verifyHTTPSRedirects is set.
+	// If nil, the system roots are trusted.
+	redirectRoots *x509.CertPool
+	// maxResponseSize is the number of bytes of the response read, which the
+	// body must be shorter than. Defaults to maxResponseSize.
+	maxResponseSize int
+	// deniedRedirectNets are the networks, in addition to the private ranges
+	// the resolver already refuses, to which redirects aren't followed.
+	deniedRedirectNets []*net.IPNet
+}
+
+// NewHTTP01Policy returns an http-01 policy. Zero values of maxRedirects,
+// maxResponseSize and redirectPorts use the VA's defaults, and
+// deniedRedirectCIDRs are networks in CIDR notation, such as "192.0.2.0/24".
+func NewHTTP01Policy(
+	maxRedirects int,
+	redirectPorts []int,
+	verifyHTTPSRedirects bool,
+	maxResponseSize int,
+	deniedRedirectCIDRs []string,
+) (*HTTP01Policy, error) {
+	if maxRedirects < 0 {
+		return nil, fmt.Errorf("invalid maximum of %d http-01 redirects", maxRedirects)
+	}
+	if maxResponseSize < 0 {
+		return nil, fmt.Errorf("invalid maximum http-01 response size of %d", maxResponseSize)
+	}
+	for _, port := range redirectPorts {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid http-01 redirect port %d", port)
+		}
+	}
+	var deniedRedirectNets []*net.IPNet
+	for _, cidr := range deniedRedirectCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("parsing denied http-01 redirect network: %w", err)
+		}
+		deniedRedirectNets = append(deniedRedirectNets, ipNet)
+	}
+	return &HTTP01Policy{
+		maxRedirects:         maxRedirects,
+		redirectPorts:        redirectPorts,
+		verifyHTTPSRedirects: verifyHTTPSRedirects,
+		maxResponseSize:      maxResponseSize,
+		deniedRedirectNets:   deniedRedirectNets,
+	}, nil
+}
+
+// deniesRedirectTo returns true if ip is in one of the networks to which
+// redirects aren't followed.
+func (p *HTTP01Policy) deniesRedirectTo(ip net.IP) bool {
+	for _, ipNet := range p.deniedRedirectNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// rejectHTTP01 counts a refusal of an http-01 validation by the VA's http-01
+// policy, for the given reason, and returns err.
+func (va *ValidationAuthorityImpl) rejectHTTP01(reason string, err error) error {
+	va.metrics.http01PolicyRejections.WithLabelValues(reason).Inc()
+	return err
+}
+
+// redirectPorts returns the ports which a redirect target may specify
+// explicitly.
+func (va *ValidationAuthorityImpl) redirectPorts() []int {
+	if len(va.http01Policy.redirectPorts) > 0 {
+		return va.http01Policy.redirectPorts
+	}
+	return []int{va.httpPort, va.httpsPort}
+}
+
+// verifyRedirectConnection verifies the certificate presented by the target
+// of an HTTPS redirect. Since the initial http-01 request is always made over
+// HTTP, every TLS connection made during the validation is to the target of a
+// redirect.
+func (va *ValidationAuthorityImpl) verifyRedirectConnection(cs tls.ConnectionState) error {
+	opts := x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         va.http01Policy.redirectRoots,
+		Intermediates: x509.NewCertPool(),
+	}
+	if len(cs.PeerCertificates) == 0 {
+		return va.rejectHTTP01(rejectRedirectCertificate, berrors.ConnectionFailureError(
+			"Invalid certificate presented by HTTPS redirect target %q: no certificate", cs.ServerName))
+	}
+	for _, cert := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	if err != nil {
+		return va.rejectHTTP01(rejectRedirectCertificate, berrors.ConnectionFailureError(
+			"Invalid certificate presented by HTTPS redirect target %q: %s", cs.ServerName, err))
+	}
+	return nil
+}
+
 // preresolvedDialer is a struct type that provides a DialContext function which
 // will connect to the provided IP and port instead of letting DNS resolve
 // The hostname of the preresolvedDialer is used to ensure the dial only completes
@@ -46,6 +201,8 @@ type preresolvedDialer struct {
 	port     int
 	hostname string
 	timeout  time.Duration
+	// proxy, if not nil, is the egress proxy through which to connect.
+	proxy *EgressProxy
 }
 
 // a dialerMismatchError is produced when a preresolvedDialer is used to dial
@@ -131,6 +288,9 @@ func (d *preresolvedDialer) DialContext(
 		// Default KeepAlive - see Golang src/net/http/transport.go DefaultTransport
 		KeepAlive: 30 * time.Second,
 	}
+	if d.proxy != nil {
+		return d.proxy.dial(ctx, throwAwayDialer, network, targetAddr)
+	}
 	return throwAwayDialer.DialContext(ctx, network, targetAddr)
 }
 
@@ -169,95 +329,57 @@ type httpValidationTarget struct {
 	// query data for validation request (potentially populated when
 	// following redirects)
 	query string
-	// all of the IP addresses available for the host
-	available []net.IP
-	// the IP addresses that were tried for validation previously that were cycled
-	// out of cur by calls to nextIP()
-	tried []net.IP
-	// the IP addresses that will be drawn from by calls to nextIP() to set curIP
-	next []net.IP
-	// the current IP address being used for validation (if any)
-	cur net.IP
-}
-
-// nextIP changes the cur IP by removing the first entry from the next slice and
-// setting it to cur. If cur was previously set the value will be added to the
-// tried slice to keep track of IPs that were previously used. If nextIP() is
-// called but vt.next is empty an error is returned.
-func (vt *httpValidationTarget) nextIP() error {
-	if len(vt.next) == 0 {
-		return fmt.Errorf(
-			"host %q has no IP addresses remaining to use",
-			vt.host)
-	}
-	vt.tried = append(vt.tried, vt.cur)
-	vt.cur = vt.next[0]
-	vt.next = vt.next[1:]
-	return nil
-}
-
-// ip returns the current *net.IP for the validation target. It may return nil
-// if all possible IPs have been expended by calls to nextIP.
-func (vt *httpValidationTarget) ip() net.IP {
-	return vt.cur
+	// the IP addresses available for the host, and those tried
+	validationAddresses
+	// the DNSSEC state of the lookups for the host's IP addresses
+	dnssec bdns.DNSSECState
 }
 
 // newHTTPValidationTarget creates a httpValidationTarget for the given host,
-// port, and path. This involves querying DNS for the IP addresses for the host.
-// An error is returned if there are no usable IP addresses or if the DNS
-// lookups fail.
+// port, and path. This involves querying DNS for the IP addresses for the host,
+// unless the host is itself an IP address. An error is returned if there are
+// no usable IP addresses or if the DNS lookups fail.
 func (va *ValidationAuthorityImpl) newHTTPValidationTarget(
 	ctx context.Context,
 	host string,
 	port int,
 	path string,
 	query string) (*httpValidationTarget, error) {
-	// Resolve IP addresses for the hostname
-	addrs, err := va.getAddrs(ctx, host)
-	if err != nil {
-		return nil, err
-	}
-
-	target := &httpValidationTarget{
-		host:      host,
-		port:      port,
-		path:      path,
-		query:     query,
-		available: addrs,
+	// Resolve IP addresses for the hostname. An IP address identifier (RFC
+	// 8738) is validated using only the address itself.
+	var addrs []net.IP
+	var dnssec bdns.DNSSECState
+	if ip := net.ParseIP(host); ip != nil {
+		addrs = []net.IP{ip}
+	} else {
+		var err error
+		addrs, dnssec, err = va.getAddrs(ctx, host)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Separate the addresses into the available v4 and v6 addresses
-	v4Addrs, v6Addrs := availableAddresses(addrs)
-	hasV6Addrs := len(v6Addrs) > 0
-	hasV4Addrs := len(v4Addrs) > 0
-
-	if !hasV6Addrs && !hasV4Addrs {
+	addresses, err := newValidationAddresses(addrs)
+	if err != nil {
 		// If there are no v6 addrs and no v4addrs there was a bug with getAddrs or
 		// availableAddresses and we need to return an error.
-		return nil, fmt.Errorf("host %q has no IPv4 or IPv6 addresses", host)
-	} else if !hasV6Addrs && hasV4Addrs {
-		// If there are no v6 addrs and there are v4 addrs then use the first v4
-		// address. There's no fallback address.
-		target.next = []net.IP{v4Addrs[0]}
-	} else if hasV6Addrs && hasV4Addrs {
-		// If there are both v6 addrs and v4 addrs then use the first v6 address and
-		// fallback with the first v4 address.
-		target.next = []net.IP{v6Addrs[0], v4Addrs[0]}
-	} else if hasV6Addrs && !hasV4Addrs {
-		// If there are just v6 addrs then use the first v6 address. There's no
-		// fallback address.
-		target.next = []net.IP{v6Addrs[0]}
-	}
-
-	// Advance the target using nextIP to populate the cur IP before returning
-	_ = target.nextIP()
-	return target, nil
+		return nil, fmt.Errorf("host %q has %s", host, err)
+	}
+
+	return &httpValidationTarget{
+		host:                host,
+		port:                port,
+		path:                path,
+		query:               query,
+		validationAddresses: addresses,
+		dnssec:              dnssec,
+	}, nil
 }
 
 // extractRequestTarget extracts the hostname and port specified in the provided
 // HTTP redirect request. If the request's URL's protocol schema is not HTTP or
 // HTTPS an error is returned. If an explicit port is specified in the request's
-// URL and it isn't the VA's HTTP or HTTPS port, an error is returned. If the
+// URL and it isn't one of the VA's redirect ports, an error is returned. If the
 // request's URL's Host is a bare IPv4 or IPv6 address and not a domain name an
 // error is returned.
 func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (string, int, error) {
@@ -270,9 +392,9 @@ func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (stri
 
 	// The redirect request must use HTTP or HTTPs protocol schemes regardless of the port..
 	if reqScheme != "http" && reqScheme != "https" {
-		return "", 0, berrors.ConnectionFailureError(
+		return "", 0, va.rejectHTTP01(rejectRedirectScheme, berrors.ConnectionFailureError(
 			"Invalid protocol scheme in redirect target. "+
-				`Only "http" and "https" protocol schemes are supported, not %q`, reqScheme)
+				`Only "http" and "https" protocol schemes are supported, not %q`, reqScheme))
 	}
 
 	// Try and split an explicit port number from the request URL host. If there is
@@ -287,11 +409,13 @@ func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (stri
 			return "", 0, err
 		}
 
-		// The explicit port must match the VA's configured HTTP or HTTPS port.
-		if reqPort != va.httpPort && reqPort != va.httpsPort {
-			return "", 0, berrors.ConnectionFailureError(
-				"Invalid port in redirect target. Only ports %d and %d are supported, not %d",
-				va.httpPort, va.httpsPort, reqPort)
+		// The explicit port must be one of the VA's redirect ports, by default
+		// its configured HTTP and HTTPS ports.
+		allowed := va.redirectPorts()
+		if !portIn(reqPort, allowed) {
+			return "", 0, va.rejectHTTP01(rejectRedirectPort, berrors.ConnectionFailureError(
+				"Invalid port in redirect target. Only ports %s are supported, not %d",
+				formatPorts(allowed), reqPort))
 		}
 	} else if reqScheme == "http" {
 		reqPort = va.httpPort
@@ -304,15 +428,16 @@ func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (stri
 	}
 
 	if reqHost == "" {
-		return "", 0, berrors.ConnectionFailureError("Invalid empty hostname in redirect target")
+		return "", 0, va.rejectHTTP01(rejectRedirectEmptyHost,
+			berrors.ConnectionFailureError("Invalid empty hostname in redirect target"))
 	}
 
 	// Check that the request host isn't a bare IP address. We only follow
 	// redirects to hostnames.
 	if net.ParseIP(reqHost) != nil {
-		return "", 0, berrors.ConnectionFailureError(
+		return "", 0, va.rejectHTTP01(rejectRedirectIPHost, berrors.ConnectionFailureError(
 			"Invalid host in redirect target %q. "+
-				"Only domain names are supported, not IP addresses", reqHost)
+				"Only domain names are supported, not IP addresses", reqHost))
 	}
 
 	// Often folks will misconfigure their webserver to send an HTTP redirect
@@ -325,20 +450,42 @@ func (va *ValidationAuthorityImpl) extractRequestTarget(req *http.Request) (stri
 	// This happens frequently enough we want to return a distinct error message
 	// for this case by detecting the reqHost ending in ".well-known".
 	if strings.HasSuffix(reqHost, ".well-known") {
-		return "", 0, berrors.ConnectionFailureError(
+		return "", 0, va.rejectHTTP01(rejectRedirectMissingSlash, berrors.ConnectionFailureError(
 			"Invalid host in redirect target %q. Check webserver config for missing '/' in redirect target.",
 			reqHost,
-		)
+		))
 	}
 
 	if _, err := iana.ExtractSuffix(reqHost); err != nil {
-		return "", 0, berrors.ConnectionFailureError(
-			"Invalid hostname in redirect target, must end in IANA registered TLD")
+		return "", 0, va.rejectHTTP01(rejectRedirectTLD, berrors.ConnectionFailureError(
+			"Invalid hostname in redirect target, must end in IANA registered TLD"))
 	}
 
 	return reqHost, reqPort, nil
 }
 
+// portIn returns true if port is one of ports.
+func portIn(port int, ports []int) bool {
+	for _, p := range ports {
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPorts lists ports for a problem detail, e.g. "80, 8080 and 443".
+func formatPorts(ports []int) string {
+	strs := make([]string, len(ports))
+	for i, port := range ports {
+		strs[i] = strconv.Itoa(port)
+	}
+	if len(strs) < 2 {
+		return strings.Join(strs, "")
+	}
+	return strings.Join(strs[:len(strs)-1], ", ") + " and " + strs[len(strs)-1]
+}
+
 // setupHTTPValidation sets up a preresolvedDialer and a validation record for
 // the given request URL and httpValidationTarget. If the req URL is empty, or
 // the validation target is nil or has no available IP addresses, an error will
@@ -367,6 +514,8 @@ func (va *ValidationAuthorityImpl) setupHTTPValidation(
 		Port:              strconv.Itoa(target.port),
 		AddressesResolved: target.available,
 		URL:               reqURL,
+		DNSSEC:            string(target.dnssec),
+		Proxy:             va.egressProxy.String(),
 	}
 
 	// Get the target IP to build a preresolved dialer with
@@ -385,6 +534,7 @@ func (va *ValidationAuthorityImpl) setupHTTPValidation(
 		port:     target.port,
 		hostname: target.host,
 		timeout:  va.singleDialTimeout,
+		proxy:    va.egressProxy,
 	}
 	return dialer, record, nil
 }
@@ -419,6 +569,19 @@ func fallbackErr(err error) bool {
 	return errors.As(err, &netOpError) && netOpError.Op == "dial"
 }
 
+// recordTargetError records err against the current address of target, and
+// notes it in the last of the records, if that's the record of the request
+// which failed. If the request was instead to a redirect target, nothing is
+// recorded.
+func recordTargetError(target *httpValidationTarget, records []core.ValidationRecord, err error) {
+	last := &records[len(records)-1]
+	if last.Hostname != target.host || !last.AddressUsed.Equal(target.ip()) {
+		return
+	}
+	target.failed(err.Error())
+	last.AddressErrors = target.errs
+}
+
 // processHTTPValidation performs an HTTP validation for the given host, port
 // and path. If successful the body of the HTTP response is returned along with
 // the validation records created during the validation. If not successful
@@ -434,10 +597,15 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		return nil, nil, err
 	}
 
-	// Create an initial GET Request
+	// Create an initial GET Request. IPv6 address hosts must be enclosed in
+	// square brackets in the URL.
+	urlHost := host
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		urlHost = "[" + host + "]"
+	}
 	initialURL := url.URL{
 		Scheme: "http",
-		Host:   host,
+		Host:   urlHost,
 		Path:   path,
 	}
 	initialReq, err := http.NewRequest("GET", initialURL.String(), nil)
@@ -485,21 +653,25 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	// Build a transport for this validation that will use the preresolvedDialer's
 	// DialContext function
 	transport := httpTransport(dialer.DialContext)
+	if va.http01Policy.verifyHTTPSRedirects {
+		transport.TLSClientConfig.VerifyConnection = va.verifyRedirectConnection
+	}
 
 	va.log.AuditInfof("Attempting to validate HTTP-01 for %q with GET to %q",
 		initialReq.Host, initialReq.URL.String())
 
 	// Create a closure around records & numRedirects we can use with a HTTP
 	// client to process redirects per our own policy (e.g. resolving IP
-	// addresses explicitly, not following redirects to ports != [80,443], etc)
+	// addresses explicitly, not following redirects to ports other than the
+	// VA's redirect ports, etc)
 	records := []core.ValidationRecord{baseRecord}
 	numRedirects := 0
 	var oldTLS bool
 	processRedirect := func(req *http.Request, via []*http.Request) error {
 		va.log.Debugf("processing a HTTP redirect from the server to %q", req.URL.String())
-		// Only process up to maxRedirect redirects
-		if numRedirects > maxRedirect {
-			return berrors.ConnectionFailureError("Too many redirects")
+		// Only process up to the policy's maximum number of redirects
+		if numRedirects > va.http01Policy.maxRedirects {
+			return va.rejectHTTP01(rejectTooManyRedirects, berrors.ConnectionFailureError("Too many redirects"))
 		}
 		numRedirects++
 		va.metrics.http01Redirects.Inc()
@@ -519,7 +691,8 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 			301: {}, 302: {}, 307: {}, 308: {},
 		}
 		if _, present := acceptableRedirects[req.Response.StatusCode]; !present {
-			return berrors.ConnectionFailureError("received disallowed redirect status code")
+			return va.rejectHTTP01(rejectRedirectStatus,
+				berrors.ConnectionFailureError("received disallowed redirect status code"))
 		}
 
 		// Lowercase the redirect host immediately, as the dialer and redirect
@@ -535,7 +708,7 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 
 		redirPath := req.URL.Path
 		if len(redirPath) > maxPathSize {
-			return berrors.ConnectionFailureError("Redirect target too long")
+			return va.rejectHTTP01(rejectRedirectPathLength, berrors.ConnectionFailureError("Redirect target too long"))
 		}
 
 		// If the redirect URL has query parameters we need to preserve
@@ -549,7 +722,7 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		// redirect limit, return error.
 		for _, record := range records {
 			if req.URL.String() == record.URL {
-				return berrors.ConnectionFailureError("Redirect loop detected")
+				return va.rejectHTTP01(rejectRedirectLoop, berrors.ConnectionFailureError("Redirect loop detected"))
 			}
 		}
 
@@ -560,6 +733,16 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 			return err
 		}
 
+		// Refuse the redirect if any of the redirect host's addresses are in a
+		// denied network, rather than relying on which address is selected.
+		for _, ip := range redirTarget.available {
+			if va.http01Policy.deniesRedirectTo(ip) {
+				return va.rejectHTTP01(rejectRedirectDeniedAddress, berrors.ConnectionFailureError(
+					"Invalid redirect target %q. It resolves to %s, an address the VA doesn't connect to",
+					redirHost, ip))
+			}
+		}
+
 		// Setup validation for the target. This will produce a preresolved dialer we can
 		// assign to the client transport in order to connect to the redirect target using
 		// the IP address we selected.
@@ -589,6 +772,8 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	// If there was an error and its a kind of error we consider a fallback error,
 	// then try to fallback.
 	if err != nil && fallbackErr(err) {
+		recordTargetError(target, records, err)
+
 		// Try to advance to another IP. If there was an error advancing we don't
 		// have a fallback address to use and must return the original error.
 		if ipErr := target.nextIP(); ipErr != nil {
@@ -596,8 +781,10 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		}
 
 		// setup another validation to retry the target with the new IP and append
-		// the retry record.
+		// the retry record, which notes the addresses already tried.
 		retryDialer, retryRecord, err := va.setupHTTPValidation(ctx, initialReq.URL.String(), target)
+		retryRecord.AddressesTried = target.tried
+		retryRecord.AddressErrors = target.errs
 		records = append(records, retryRecord)
 		if err != nil {
 			return nil, records, err
@@ -612,6 +799,9 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		// If the retry still failed there isn't anything more to do, return the
 		// error immediately.
 		if err != nil {
+			if fallbackErr(err) {
+				recordTargetError(target, records, err)
+			}
 			return nil, records, err
 		}
 	} else if err != nil {
@@ -635,7 +825,8 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 
 	// At this point we've made a successful request (be it from a retry or
 	// otherwise) and can read and process the response body.
-	body, err := ioutil.ReadAll(&io.LimitedReader{R: httpResponse.Body, N: maxResponseSize})
+	maxSize := va.http01Policy.maxResponseSize
+	body, err := ioutil.ReadAll(&io.LimitedReader{R: httpResponse.Body, N: int64(maxSize)})
 	closeErr := httpResponse.Body.Close()
 	if err == nil {
 		err = closeErr
@@ -645,18 +836,19 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 	}
 
 	// io.LimitedReader will silently truncate a Reader so if the
-	// resulting payload is the same size as maxResponseSize fail
-	if len(body) >= maxResponseSize {
-		return nil, records, berrors.UnauthorizedError("Invalid response from %s [%s]: %q",
-			records[len(records)-1].URL, records[len(records)-1].AddressUsed, replaceInvalidUTF8(body))
+	// resulting payload is the same size as the maximum response size fail
+	if len(body) >= maxSize {
+		return nil, records, va.rejectHTTP01(rejectResponseTooLarge, berrors.UnauthorizedError(
+			"Invalid response from %s [%s]: body of at least %d bytes: %q",
+			records[len(records)-1].URL, records[len(records)-1].AddressUsed, maxSize, replaceInvalidUTF8(body)))
 	}
 	return body, records, nil
 }
 
 func (va *ValidationAuthorityImpl) validateHTTP01(ctx context.Context, ident identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
-	if ident.Type != identifier.DNS {
-		va.log.Infof("Got non-DNS identifier for HTTP validation: %s", ident)
-		return nil, probs.Malformed("Identifier type for HTTP validation was not DNS")
+	if ident.Type != identifier.DNS && ident.Type != identifier.IP {
+		va.log.Infof("Got non-DNS and non-IP identifier for HTTP validation: %s", ident)
+		return nil, probs.Malformed("Identifier type for HTTP validation was not DNS or IP")
 	}
 
 	// Perform the fetch
@@ -3,6 +3,7 @@ package va
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -24,6 +25,7 @@ import (
 	"github.com/letsencrypt/boulder/probs"
 	"github.com/letsencrypt/boulder/test"
 	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"testing"
 )
@@ -750,6 +752,11 @@ func TestFetchHTTP(t *testing.T) {
 	// We need to know the randomly assigned HTTP port for testcases as well
 	httpPort := getPort(testSrv)
 
+	// The test server only listens on IPv4, so connecting to its IPv6 address
+	// fails. How it fails depends on the host, so the error is checked
+	// separately below.
+	ipv6Failed := core.AddressError{Address: net.ParseIP("::1")}
+
 	// For the looped test case we expect one validation record per redirect
 	// until boulder detects that a url has been used twice indicating a
 	// redirect loop. Because it is hitting the /loop endpoint it will encounter
@@ -957,7 +964,7 @@ func TestFetchHTTP(t *testing.T) {
 			Path: "/resp-too-big",
 			ExpectedProblem: probs.Unauthorized(fmt.Sprintf(
 				"Invalid response from http://example.com/resp-too-big "+
-					"[127.0.0.1]: %q", expectedTruncatedResp.String(),
+					"[127.0.0.1]: body of at least %d bytes: %q", maxResponseSize, expectedTruncatedResp.String(),
 			)),
 			ExpectedRecords: []core.ValidationRecord{
 				{
@@ -982,6 +989,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://ipv6.localhost/ok",
 					AddressesResolved: []net.IP{net.ParseIP("::1")},
 					AddressUsed:       net.ParseIP("::1"),
+					AddressErrors:     []core.AddressError{ipv6Failed},
 				},
 			},
 		},
@@ -998,6 +1006,8 @@ func TestFetchHTTP(t *testing.T) {
 					AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
 					// The first validation record should have used the IPv6 addr
 					AddressUsed: net.ParseIP("::1"),
+					// and recorded the error from it
+					AddressErrors: []core.AddressError{ipv6Failed},
 				},
 				{
 					Hostname:          "ipv4.and.ipv6.localhost",
@@ -1006,6 +1016,9 @@ func TestFetchHTTP(t *testing.T) {
 					AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
 					// The second validation record should have used the IPv4 addr as a fallback
 					AddressUsed: net.ParseIP("127.0.0.1"),
+					// after trying the IPv6 addr, which failed
+					AddressesTried: []net.IP{net.ParseIP("::1")},
+					AddressErrors:  []core.AddressError{ipv6Failed},
 				},
 			},
 		},
@@ -1053,8 +1066,8 @@ func TestFetchHTTP(t *testing.T) {
 			ExpectedProblem: &probs.ProblemDetails{
 				Type: probs.UnauthorizedProblem,
 				Detail: fmt.Sprintf("Invalid response from "+
-					"http://example.com/printf-verbs [127.0.0.1]: %q",
-					("%2F.well-known%2F" + expectedTruncatedResp.String())[:maxResponseSize]),
+					"http://example.com/printf-verbs [127.0.0.1]: body of at least %d bytes: %q",
+					maxResponseSize, ("%2F.well-known%2F" + expectedTruncatedResp.String())[:maxResponseSize]),
 				HTTPStatus: http.StatusForbidden,
 			},
 			ExpectedRecords: []core.ValidationRecord{
@@ -1083,6 +1096,17 @@ func TestFetchHTTP(t *testing.T) {
 			} else {
 				test.AssertEquals(t, string(body), tc.ExpectedBody)
 			}
+			// Errors from addresses should name the address dialed
+			for _, record := range records {
+				for _, addrErr := range record.AddressErrors {
+					test.AssertContains(t, addrErr.Error, fmt.Sprintf("dial tcp [%s]", addrErr.Address))
+				}
+			}
+			for i := range records {
+				for j := range records[i].AddressErrors {
+					records[i].AddressErrors[j].Error = ""
+				}
+			}
 			// in all cases we expect validation records to be present and matching expected
 			test.AssertMarshaledEquals(t, records, tc.ExpectedRecords)
 		})
@@ -1106,6 +1130,163 @@ func TestFetchHTTPInvalidUTF8(t *testing.T) {
 	test.AssertContains(t, string(prob.Detail), expectedResult)
 }
 
+func TestNewHTTP01Policy(t *testing.T) {
+	policy, err := NewHTTP01Policy(3, []int{80, 8080}, true, 256, []string{"192.0.2.0/24", "2001:db8::/32"})
+	test.AssertNotError(t, err, "NewHTTP01Policy failed")
+	test.AssertEquals(t, policy.maxRedirects, 3)
+	test.AssertEquals(t, policy.maxResponseSize, 256)
+	test.Assert(t, policy.verifyHTTPSRedirects, "expected HTTPS redirects to be verified")
+	test.Assert(t, policy.deniesRedirectTo(net.ParseIP("192.0.2.1")), "expected 192.0.2.1 to be denied")
+	test.Assert(t, policy.deniesRedirectTo(net.ParseIP("2001:db8::1")), "expected 2001:db8::1 to be denied")
+	test.Assert(t, !policy.deniesRedirectTo(net.ParseIP("198.51.100.1")), "expected 198.51.100.1 to be allowed")
+
+	_, err = NewHTTP01Policy(-1, nil, false, 0, nil)
+	test.AssertError(t, err, "negative maximum redirects should fail")
+	_, err = NewHTTP01Policy(0, nil, false, -1, nil)
+	test.AssertError(t, err, "negative maximum response size should fail")
+	_, err = NewHTTP01Policy(0, []int{0}, false, 0, nil)
+	test.AssertError(t, err, "invalid redirect port should fail")
+	_, err = NewHTTP01Policy(0, nil, false, 0, []string{"192.0.2.1"})
+	test.AssertError(t, err, "denied network without a prefix length should fail")
+}
+
+func TestExtractRequestTargetRedirectPorts(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+	va.http01Policy.redirectPorts = []int{80, 8080, 443}
+
+	req, err := http.NewRequest("GET", "http://example.com:8080/ok", nil)
+	test.AssertNotError(t, err, "creating request")
+	host, port, err := va.extractRequestTarget(req)
+	test.AssertNotError(t, err, "redirect to a configured port should be allowed")
+	test.AssertEquals(t, host, "example.com")
+	test.AssertEquals(t, port, 8080)
+
+	req, err = http.NewRequest("GET", "http://example.com:9999/ok", nil)
+	test.AssertNotError(t, err, "creating request")
+	_, _, err = va.extractRequestTarget(req)
+	test.AssertError(t, err, "redirect to an unconfigured port should be refused")
+	test.AssertEquals(t, err.Error(),
+		"Invalid port in redirect target. Only ports 80, 8080 and 443 are supported, not 9999")
+	test.AssertMetricWithLabelsEquals(t, va.metrics.http01PolicyRejections,
+		prometheus.Labels{"reason": rejectRedirectPort}, 1)
+}
+
+func TestHTTP01PolicyRejections(t *testing.T) {
+	testSrv := httpTestSrv(t)
+	defer testSrv.Close()
+	httpPort := getPort(testSrv)
+
+	testCases := []struct {
+		Name           string
+		Path           string
+		Policy         HTTP01Policy
+		ExpectedDetail string
+		ExpectedReason string
+	}{
+		{
+			Name:   "Too many redirects",
+			Path:   "/max-redirect/0",
+			Policy: HTTP01Policy{maxRedirects: 1, maxResponseSize: maxResponseSize},
+			ExpectedDetail: fmt.Sprintf(
+				"Fetching http://example.com:%d/max-redirect/3: Too many redirects", httpPort),
+			ExpectedReason: rejectTooManyRedirects,
+		},
+		{
+			Name: "Redirect to denied network",
+			Path: "/max-redirect/0",
+			Policy: HTTP01Policy{
+				maxRedirects:       maxRedirect,
+				maxResponseSize:    maxResponseSize,
+				deniedRedirectNets: []*net.IPNet{{IP: net.IPv4(127, 0, 0, 0), Mask: net.CIDRMask(8, 32)}},
+			},
+			ExpectedDetail: fmt.Sprintf(
+				`Fetching http://example.com:%d/max-redirect/1: Invalid redirect target "example.com". `+
+					"It resolves to 127.0.0.1, an address the VA doesn't connect to", httpPort),
+			ExpectedReason: rejectRedirectDeniedAddress,
+		},
+		{
+			Name:           "Response too large",
+			Path:           "/ok",
+			Policy:         HTTP01Policy{maxRedirects: maxRedirect, maxResponseSize: 2},
+			ExpectedDetail: `Invalid response from http://example.com/ok [127.0.0.1]: body of at least 2 bytes: "ok"`,
+			ExpectedReason: rejectResponseTooLarge,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			va, _ := setup(testSrv, 0, "", nil)
+			va.http01Policy = tc.Policy
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*500)
+			defer cancel()
+			_, _, prob := va.fetchHTTP(ctx, "example.com", tc.Path)
+			test.AssertNotNil(t, prob, "expected a problem")
+			test.AssertEquals(t, prob.Detail, tc.ExpectedDetail)
+			test.AssertMetricWithLabelsEquals(t, va.metrics.http01PolicyRejections,
+				prometheus.Labels{"reason": tc.ExpectedReason}, 1)
+		})
+	}
+}
+
+func TestHTTP01PolicyVerifyHTTPSRedirects(t *testing.T) {
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		fmt.Fprint(resp, "ok")
+	}))
+	defer tlsSrv.Close()
+	tlsPort := getPort(tlsSrv)
+	roots := x509.NewCertPool()
+	roots.AddCert(tlsSrv.Certificate())
+
+	redirSrv := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		http.Redirect(resp, req, fmt.Sprintf("https://example.com:%d/ok", tlsPort), http.StatusFound)
+	}))
+	defer redirSrv.Close()
+
+	testCases := []struct {
+		Name           string
+		Verify         bool
+		Roots          *x509.CertPool
+		ExpectedDetail string
+	}{
+		{
+			Name: "Unverified",
+		},
+		{
+			Name:   "Verified",
+			Verify: true,
+			Roots:  roots,
+		},
+		{
+			Name:   "Untrusted",
+			Verify: true,
+			ExpectedDetail: fmt.Sprintf("Fetching https://example.com:%d/ok: "+
+				`Invalid certificate presented by HTTPS redirect target "example.com": `, tlsPort),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			va, _ := setup(redirSrv, 0, "", nil)
+			va.httpsPort = tlsPort
+			va.http01Policy.verifyHTTPSRedirects = tc.Verify
+			va.http01Policy.redirectRoots = tc.Roots
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			body, _, prob := va.fetchHTTP(ctx, "example.com", "/redirect")
+			if tc.ExpectedDetail == "" {
+				test.Assert(t, prob == nil, fmt.Sprintf("unexpected problem: %s", prob))
+				test.AssertEquals(t, string(body), "ok")
+				return
+			}
+			test.AssertNotNil(t, prob, "expected a problem")
+			test.Assert(t, strings.HasPrefix(prob.Detail, tc.ExpectedDetail),
+				fmt.Sprintf("expected detail starting %q, got %q", tc.ExpectedDetail, prob.Detail))
+			test.AssertMetricWithLabelsEquals(t, va.metrics.http01PolicyRejections,
+				prometheus.Labels{"reason": rejectRedirectCertificate}, 1)
+		})
+	}
+}
+
 // All paths that get assigned to tokens MUST be valid tokens
 const pathWrongToken = "i6lNAC4lOOLYCl-A08VJt9z_tKYvVk63Dumo8icsBjQ"
 const path404 = "404"
@@ -1300,10 +1481,10 @@ func TestHTTP(t *testing.T) {
 	test.AssertEquals(t, len(matchedValidRedirect), 1)
 	test.AssertEquals(t, len(matchedMovedRedirect), 1)
 
-	ipIdentifier := identifier.ACMEIdentifier{Type: identifier.IdentifierType("ip"), Value: "127.0.0.1"}
-	_, prob = va.validateHTTP01(ctx, ipIdentifier, chall)
+	emailIdentifier := identifier.ACMEIdentifier{Type: identifier.IdentifierType("email"), Value: "admin@localhost.com"}
+	_, prob = va.validateHTTP01(ctx, emailIdentifier, chall)
 	if prob == nil {
-		t.Fatalf("IdentifierType IP shouldn't have worked.")
+		t.Fatalf("IdentifierType email shouldn't have worked.")
 	}
 	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
 
@@ -1359,8 +1540,8 @@ type dnsMockReturnsUnroutable struct {
 	*bdns.MockClient
 }
 
-func (mock dnsMockReturnsUnroutable) LookupHost(_ context.Context, hostname string) ([]net.IP, error) {
-	return []net.IP{net.ParseIP("198.51.100.1")}, nil
+func (mock dnsMockReturnsUnroutable) LookupHost(_ context.Context, hostname string) ([]net.IP, bdns.DNSSECState, error) {
+	return []net.IP{net.ParseIP("198.51.100.1")}, bdns.DNSSECUnchecked, nil
 }
 
 // TestHTTPDialTimeout tests that we give the proper "Timeout during connect"
@@ -1533,10 +1714,33 @@ func TestValidateHTTP(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 0, chall)
 	test.Assert(t, prob == nil, "validation failed")
 }
 
+func TestValidateHTTPIP(t *testing.T) {
+	chall := core.HTTPChallenge01("")
+	setChallengeToken(&chall, core.NewToken())
+
+	var gotHost string
+	m := http.NewServeMux()
+	hs := httptest.NewUnstartedServer(m)
+	m.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		fmt.Fprint(w, chall.ProvidedKeyAuthorization)
+	})
+	hs.Start()
+	defer hs.Close()
+
+	va, _ := setup(hs, 0, "", nil)
+
+	records, prob := va.validateChallenge(ctx, identifier.IPIdentifier(net.ParseIP("127.0.0.1")), 0, chall)
+	test.Assert(t, prob == nil, fmt.Sprintf("validation failed: %s", prob))
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].AddressUsed.String(), "127.0.0.1")
+	test.AssertEquals(t, gotHost, "127.0.0.1")
+}
+
 func TestLimitedReader(t *testing.T) {
 	chall := core.HTTPChallenge01("")
 	setChallengeToken(&chall, core.NewToken())
@@ -1545,7 +1749,7 @@ func TestLimitedReader(t *testing.T) {
 	va, _ := setup(hs, 0, "", nil)
 	defer hs.Close()
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 0, chall)
 
 	test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
 	test.Assert(t, strings.HasPrefix(prob.Detail, "Invalid response from "),
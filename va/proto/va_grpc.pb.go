@@ -189,3 +189,89 @@ var CAA_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "va.proto",
 }
+
+// VADebugClient is the client API for VADebug service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VADebugClient interface {
+	DryRunValidation(ctx context.Context, in *DryRunValidationRequest, opts ...grpc.CallOption) (*DryRunValidationResult, error)
+}
+
+type vADebugClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVADebugClient(cc grpc.ClientConnInterface) VADebugClient {
+	return &vADebugClient{cc}
+}
+
+func (c *vADebugClient) DryRunValidation(ctx context.Context, in *DryRunValidationRequest, opts ...grpc.CallOption) (*DryRunValidationResult, error) {
+	out := new(DryRunValidationResult)
+	err := c.cc.Invoke(ctx, "/va.VADebug/DryRunValidation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VADebugServer is the server API for VADebug service.
+// All implementations must embed UnimplementedVADebugServer
+// for forward compatibility
+type VADebugServer interface {
+	DryRunValidation(context.Context, *DryRunValidationRequest) (*DryRunValidationResult, error)
+	mustEmbedUnimplementedVADebugServer()
+}
+
+// UnimplementedVADebugServer must be embedded to have forward compatible implementations.
+type UnimplementedVADebugServer struct {
+}
+
+func (UnimplementedVADebugServer) DryRunValidation(context.Context, *DryRunValidationRequest) (*DryRunValidationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunValidation not implemented")
+}
+func (UnimplementedVADebugServer) mustEmbedUnimplementedVADebugServer() {}
+
+// UnsafeVADebugServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VADebugServer will
+// result in compilation errors.
+type UnsafeVADebugServer interface {
+	mustEmbedUnimplementedVADebugServer()
+}
+
+func RegisterVADebugServer(s grpc.ServiceRegistrar, srv VADebugServer) {
+	s.RegisterService(&VADebug_ServiceDesc, srv)
+}
+
+func _VADebug_DryRunValidation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunValidationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VADebugServer).DryRunValidation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/va.VADebug/DryRunValidation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VADebugServer).DryRunValidation(ctx, req.(*DryRunValidationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VADebug_ServiceDesc is the grpc.ServiceDesc for VADebug service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VADebug_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "va.VADebug",
+	HandlerType: (*VADebugServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DryRunValidation",
+			Handler:    _VADebug_DryRunValidation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "va.proto",
+}
@@ -0,0 +1,308 @@
+package va
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// EgressProxy is an HTTP CONNECT or SOCKS5 proxy through which the VA makes
+// its connections to validation targets. The VA still resolves the target
+// itself and asks the proxy to connect to the address it selected, so the
+// proxy never resolves names on the VA's behalf.
+type EgressProxy struct {
+	// scheme is "http" for an HTTP CONNECT proxy or "socks5" for a SOCKS5
+	// proxy.
+	scheme string
+	// addr is the host:port of the proxy.
+	addr string
+	// user, if not nil, holds the credentials presented to the proxy.
+	user *url.Userinfo
+}
+
+// ParseEgressProxy parses the URL of an egress proxy, which is either
+// "http://[user:pass@]host[:port]" for an HTTP CONNECT proxy or
+// "socks5://[user:pass@]host[:port]" for a SOCKS5 proxy.
+func ParseEgressProxy(rawURL string) (*EgressProxy, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing egress proxy URL: %w", err)
+	}
+	var defaultPort string
+	switch u.Scheme {
+	case "http":
+		defaultPort = "80"
+	case "socks5":
+		defaultPort = "1080"
+	default:
+		return nil, fmt.Errorf("unsupported egress proxy scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+		return nil, fmt.Errorf("egress proxy URL %q must contain only a scheme, host and port", u.Redacted())
+	}
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	if u.Scheme == "socks5" && u.User != nil {
+		pass, _ := u.User.Password()
+		if len(u.User.Username()) > 255 || len(pass) > 255 {
+			return nil, errors.New("SOCKS5 egress proxy username and password must be at most 255 bytes")
+		}
+	}
+	return &EgressProxy{
+		scheme: u.Scheme,
+		addr:   net.JoinHostPort(u.Hostname(), port),
+		user:   u.User,
+	}, nil
+}
+
+// String returns the URL of the proxy without its credentials, as recorded in
+// validation records. It returns "" for a nil *EgressProxy.
+func (p *EgressProxy) String() string {
+	if p == nil {
+		return ""
+	}
+	return p.scheme + "://" + p.addr
+}
+
+// egressProxyError is an error connecting through an egress proxy. Its
+// message is suitable to show to subscribers, so it doesn't describe the
+// proxy. When the proxy reports that it couldn't connect to the target, it is
+// wrapped in a "dial" *net.OpError, so that it's treated like a failure to
+// connect to the target directly.
+type egressProxyError struct {
+	detail string
+}
+
+func (e *egressProxyError) Error() string {
+	return e.detail
+}
+
+// dial connects to targetAddr, which must be an IP address and port, through
+// the proxy. The dialer is used to connect to the proxy, and the whole
+// exchange with the proxy must complete before ctx's deadline.
+func (p *EgressProxy) dial(ctx context.Context, dialer *net.Dialer, network, targetAddr string) (net.Conn, error) {
+	host, portStr, err := net.SplitHostPort(targetAddr)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(host)
+	port, err := strconv.Atoi(portStr)
+	if ip == nil || err != nil || port < 1 || port > 65535 {
+		return nil, fmt.Errorf("egress proxy target %q is not an IP address and port", targetAddr)
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return nil, &egressProxyError{detail: "Error connecting to the VA's egress proxy"}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	var refusal string
+	switch p.scheme {
+	case "http":
+		conn, refusal, err = p.connectHTTP(conn, targetAddr)
+	case "socks5":
+		refusal, err = p.connectSOCKS5(conn, ip, port)
+	}
+	if err != nil {
+		_ = conn.Close()
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, &egressProxyError{detail: "Timeout communicating with the VA's egress proxy"}
+		}
+		return nil, &egressProxyError{detail: "Error communicating with the VA's egress proxy"}
+	}
+	if refusal != "" {
+		_ = conn.Close()
+		return nil, &net.OpError{
+			Op:   "dial",
+			Net:  network,
+			Addr: &net.TCPAddr{IP: ip, Port: port},
+			Err:  &egressProxyError{detail: refusal},
+		}
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// connectHTTP asks an HTTP proxy to connect to targetAddr with the CONNECT
+// method. If the proxy couldn't connect, refusal describes why.
+func (p *EgressProxy) connectHTTP(conn net.Conn, targetAddr string) (net.Conn, string, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: targetAddr},
+		Host:   targetAddr,
+		Header: make(http.Header),
+	}
+	if p.user != nil {
+		pass, _ := p.user.Password()
+		req.SetBasicAuth(p.user.Username(), pass)
+		// SetBasicAuth sets the header for authenticating to the origin.
+		req.Header["Proxy-Authorization"] = req.Header["Authorization"]
+		delete(req.Header, "Authorization")
+	}
+	err := req.Write(conn)
+	if err != nil {
+		return conn, "", err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return conn, "", err
+	}
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+	case resp.StatusCode == http.StatusGatewayTimeout:
+		return conn, "Timeout during connect (likely firewall problem)", nil
+	case resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable:
+		return conn, "Connection refused", nil
+	case resp.StatusCode == http.StatusForbidden:
+		return conn, "Connection not allowed by the VA's egress policy", nil
+	default:
+		return conn, "", fmt.Errorf("unexpected response to CONNECT: %s", resp.Status)
+	}
+	if br.Buffered() > 0 {
+		// The target spoke before we did, and the proxy relayed it along with
+		// its response.
+		return &bufferedConn{Conn: conn, r: br}, "", nil
+	}
+	return conn, "", nil
+}
+
+// bufferedConn is a net.Conn whose reads are served first from the data
+// already buffered in r.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// SOCKS5 protocol constants (RFC 1928 and RFC 1929).
+const (
+	socks5Version        = 0x05
+	socks5AuthNone       = 0x00
+	socks5AuthPassword   = 0x02
+	socks5CmdConnect     = 0x01
+	socks5AddrIPv4       = 0x01
+	socks5AddrDomainName = 0x03
+	socks5AddrIPv6       = 0x04
+)
+
+// socks5Refusals describes the SOCKS5 replies reporting that the proxy
+// couldn't connect to the target.
+var socks5Refusals = map[byte]string{
+	0x02: "Connection not allowed by the VA's egress policy",
+	0x03: "Network unreachable",
+	0x04: "Host unreachable",
+	0x05: "Connection refused",
+	0x06: "Timeout during connect (likely firewall problem)",
+}
+
+// connectSOCKS5 asks a SOCKS5 proxy to connect to ip and port. If the proxy
+// couldn't connect, refusal describes why.
+func (p *EgressProxy) connectSOCKS5(conn net.Conn, ip net.IP, port int) (string, error) {
+	method := byte(socks5AuthNone)
+	if p.user != nil {
+		method = socks5AuthPassword
+	}
+	_, err := conn.Write([]byte{socks5Version, 1, method})
+	if err != nil {
+		return "", err
+	}
+	var reply [2]byte
+	_, err = io.ReadFull(conn, reply[:])
+	if err != nil {
+		return "", err
+	}
+	if reply[0] != socks5Version || reply[1] != method {
+		return "", fmt.Errorf("SOCKS5 proxy didn't accept authentication method %d", method)
+	}
+
+	if method == socks5AuthPassword {
+		pass, _ := p.user.Password()
+		msg := []byte{0x01, byte(len(p.user.Username()))}
+		msg = append(msg, p.user.Username()...)
+		msg = append(msg, byte(len(pass)))
+		msg = append(msg, pass...)
+		_, err = conn.Write(msg)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.ReadFull(conn, reply[:])
+		if err != nil {
+			return "", err
+		}
+		if reply[1] != 0x00 {
+			return "", errors.New("SOCKS5 proxy rejected credentials")
+		}
+	}
+
+	req := []byte{socks5Version, socks5CmdConnect, 0x00}
+	if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socks5AddrIPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socks5AddrIPv6)
+		req = append(req, ip.To16()...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+	_, err = conn.Write(req)
+	if err != nil {
+		return "", err
+	}
+
+	// The reply is the version, reply code, a reserved byte and the bound
+	// address, whose length depends on its type.
+	var head [4]byte
+	_, err = io.ReadFull(conn, head[:])
+	if err != nil {
+		return "", err
+	}
+	if head[0] != socks5Version {
+		return "", fmt.Errorf("unexpected SOCKS5 reply version %d", head[0])
+	}
+	var addrLen int
+	switch head[3] {
+	case socks5AddrIPv4:
+		addrLen = net.IPv4len
+	case socks5AddrIPv6:
+		addrLen = net.IPv6len
+	case socks5AddrDomainName:
+		var l [1]byte
+		_, err = io.ReadFull(conn, l[:])
+		if err != nil {
+			return "", err
+		}
+		addrLen = int(l[0])
+	default:
+		return "", fmt.Errorf("unexpected SOCKS5 address type %d", head[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, addrLen+2))
+	if err != nil {
+		return "", err
+	}
+
+	if head[1] == 0x00 {
+		return "", nil
+	}
+	if refusal, ok := socks5Refusals[head[1]]; ok {
+		return refusal, nil
+	}
+	return "", fmt.Errorf("SOCKS5 proxy failed with reply code %d", head[1])
+}
@@ -16,9 +16,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
+	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
+	"github.com/miekg/dns"
 )
 
 const (
@@ -57,15 +60,25 @@ func certAltNames(cert *x509.Certificate) []string {
 }
 
 func (va *ValidationAuthorityImpl) tryGetChallengeCert(ctx context.Context,
-	identifier identifier.ACMEIdentifier, challenge core.Challenge,
+	ident identifier.ACMEIdentifier, challenge core.Challenge,
 	tlsConfig *tls.Config) (*x509.Certificate, *tls.ConnectionState, []core.ValidationRecord, *probs.ProblemDetails) {
 
-	allAddrs, err := va.getAddrs(ctx, identifier.Value)
+	var allAddrs []net.IP
+	var dnssec bdns.DNSSECState
+	var err error
+	if ident.Type == identifier.IP {
+		// An IP address identifier is validated using only the address itself
+		allAddrs = []net.IP{net.ParseIP(ident.Value)}
+	} else {
+		allAddrs, dnssec, err = va.getAddrs(ctx, ident.Value)
+	}
 	validationRecords := []core.ValidationRecord{
 		{
-			Hostname:          identifier.Value,
+			Hostname:          ident.Value,
 			AddressesResolved: allAddrs,
 			Port:              strconv.Itoa(va.tlsPort),
+			DNSSEC:            string(dnssec),
+			Proxy:             va.egressProxy.String(),
 		},
 	}
 	if err != nil {
@@ -73,49 +86,39 @@ func (va *ValidationAuthorityImpl) tryGetChallengeCert(ctx context.Context,
 	}
 	thisRecord := &validationRecords[0]
 
-	// Split the available addresses into v4 and v6 addresses
-	v4, v6 := availableAddresses(allAddrs)
-	addresses := append(v4, v6...)
-
-	// This shouldn't happen, but be defensive about it anyway
-	if len(addresses) < 1 {
-		return nil, nil, validationRecords, probs.Malformed("no IP addresses found for %q", identifier.Value)
+	// Try the first IPv6 address, if any, then fall back to the first IPv4
+	// address if it can't be dialed, as for http-01.
+	addresses, err := newValidationAddresses(allAddrs)
+	if err != nil {
+		// This shouldn't happen, but be defensive about it anyway
+		return nil, nil, validationRecords, probs.Malformed("no IP addresses found for %q", ident.Value)
 	}
-
-	// If there is at least one IPv6 address then try it first
-	if len(v6) > 0 {
-		address := net.JoinHostPort(v6[0].String(), thisRecord.Port)
-		thisRecord.AddressUsed = v6[0]
-
-		cert, cs, prob := va.getChallengeCert(ctx, address, identifier, challenge, tlsConfig)
-
-		// If there is no problem, return immediately
+	for {
+		thisRecord.AddressUsed = addresses.ip()
+		thisRecord.AddressesTried = addresses.tried
+		address := net.JoinHostPort(thisRecord.AddressUsed.String(), thisRecord.Port)
+		cert, cs, err := va.getChallengeCert(ctx, address, ident, challenge, tlsConfig)
 		if err == nil {
-			return cert, cs, validationRecords, prob
+			return cert, cs, validationRecords, nil
+		}
+		// By policy, only dial errors are eligible for fallback to another
+		// address.
+		if !fallbackErr(err) {
+			return nil, nil, validationRecords, detailedError(err)
+		}
+		va.log.Infof("%s [%s] failed to connect to %s: %s",
+			challenge.Type, ident, thisRecord.AddressUsed, err)
+		addresses.failed(err.Error())
+		thisRecord.AddressErrors = addresses.errs
+
+		// If there's no address left to try, return the problem from the last one.
+		if addresses.nextIP() != nil {
+			return nil, nil, validationRecords, detailedError(err)
+		}
+		if addresses.isIPv4Fallback() {
+			va.metrics.ipv4FallbackCounter.Inc()
 		}
-
-		// Otherwise, we note that we tried an address and fall back to trying IPv4
-		thisRecord.AddressesTried = append(thisRecord.AddressesTried, thisRecord.AddressUsed)
-		va.metrics.ipv4FallbackCounter.Inc()
-	}
-
-	// If there are no IPv4 addresses and we tried an IPv6 address return
-	// an error - there's nothing left to try
-	if len(v4) == 0 && len(thisRecord.AddressesTried) > 0 {
-		return nil, nil, validationRecords, probs.Malformed("Unable to contact %q at %q, no IPv4 addresses to try as fallback",
-			thisRecord.Hostname, thisRecord.AddressesTried[0])
-	} else if len(v4) == 0 && len(thisRecord.AddressesTried) == 0 {
-		// It shouldn't be possible that there are no IPv4 addresses and no previous
-		// attempts at an IPv6 address connection but be defensive about it anyway
-		return nil, nil, validationRecords, probs.Malformed("No IP addresses found for %q", thisRecord.Hostname)
 	}
-
-	// Otherwise if there are no IPv6 addresses, or there was an error
-	// talking to the first IPv6 address, try the first IPv4 address
-	thisRecord.AddressUsed = v4[0]
-	cert, cs, prob := va.getChallengeCert(ctx, net.JoinHostPort(v4[0].String(), thisRecord.Port),
-		identifier, challenge, tlsConfig)
-	return cert, cs, validationRecords, prob
 }
 
 func (va *ValidationAuthorityImpl) getChallengeCert(
@@ -124,7 +127,7 @@ func (va *ValidationAuthorityImpl) getChallengeCert(
 	identifier identifier.ACMEIdentifier,
 	challenge core.Challenge,
 	config *tls.Config,
-) (*x509.Certificate, *tls.ConnectionState, *probs.ProblemDetails) {
+) (*x509.Certificate, *tls.ConnectionState, error) {
 	va.log.Info(fmt.Sprintf("%s [%s] Attempting to validate for %s %s", challenge.Type, identifier, hostPort, config.ServerName))
 	// We expect a self-signed challenge certificate, do not verify it here.
 	config.InsecureSkipVerify = true
@@ -132,7 +135,7 @@ func (va *ValidationAuthorityImpl) getChallengeCert(
 
 	if err != nil {
 		va.log.Infof("%s connection failure for %s. err=[%#v] errStr=[%s]", challenge.Type, identifier, err, err)
-		return nil, nil, detailedError(err)
+		return nil, nil, err
 	}
 	// close errors are not important here
 	defer func() {
@@ -143,7 +146,7 @@ func (va *ValidationAuthorityImpl) getChallengeCert(
 	certs := cs.PeerCertificates
 	if len(certs) == 0 {
 		va.log.Infof("%s challenge for %s resulted in no certificates", challenge.Type, identifier.Value)
-		return nil, nil, probs.Unauthorized(fmt.Sprintf("No certs presented for %s challenge", challenge.Type))
+		return nil, nil, berrors.UnauthorizedError("No certs presented for %s challenge", challenge.Type)
 	}
 	for i, cert := range certs {
 		va.log.AuditInfof("%s challenge for %s received certificate (%d of %d): cert=[%s]",
@@ -158,7 +161,13 @@ func (va *ValidationAuthorityImpl) tlsDial(ctx context.Context, hostPort string,
 	ctx, cancel := context.WithTimeout(ctx, va.singleDialTimeout)
 	defer cancel()
 	dialer := &net.Dialer{}
-	netConn, err := dialer.DialContext(ctx, "tcp", hostPort)
+	var netConn net.Conn
+	var err error
+	if va.egressProxy != nil {
+		netConn, err = va.egressProxy.dial(ctx, dialer, "tcp", hostPort)
+	} else {
+		netConn, err = dialer.DialContext(ctx, "tcp", hostPort)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -177,6 +186,9 @@ func (va *ValidationAuthorityImpl) tlsDial(ctx context.Context, hostPort string,
 }
 
 func checkExpectedSAN(cert *x509.Certificate, name identifier.ACMEIdentifier) error {
+	if name.Type == identifier.IP {
+		return checkExpectedIPSAN(cert, net.ParseIP(name.Value))
+	}
 	if len(cert.DNSNames) != 1 {
 		return errors.New("wrong number of dNSNames")
 	}
@@ -199,6 +211,46 @@ func checkExpectedSAN(cert *x509.Certificate, name identifier.ACMEIdentifier) er
 	return nil
 }
 
+// checkExpectedIPSAN checks that the certificate's subjectAltName extension
+// contains exactly one entry, an iPAddress matching the expected IP address.
+func checkExpectedIPSAN(cert *x509.Certificate, ip net.IP) error {
+	if len(cert.DNSNames) != 0 || len(cert.IPAddresses) != 1 {
+		return errors.New("wrong number of identifiers")
+	}
+
+	ipBytes := cert.IPAddresses[0].To4()
+	if ipBytes == nil {
+		ipBytes = cert.IPAddresses[0].To16()
+	}
+	for _, ext := range cert.Extensions {
+		if IdCeSubjectAltName.Equal(ext.Id) {
+			expectedSANs, err := asn1.Marshal([]asn1.RawValue{
+				{Tag: 7, Class: 2, Bytes: ipBytes},
+			})
+			if err != nil || !bytes.Equal(expectedSANs, ext.Value) {
+				return errors.New("SAN extension does not match expected bytes")
+			}
+		}
+	}
+
+	if !cert.IPAddresses[0].Equal(ip) {
+		return errors.New("iPAddress does not match expected identifier")
+	}
+
+	return nil
+}
+
+// reverseName returns the reverse DNS name (in the in-addr.arpa or ip6.arpa
+// zone) for the given IP address, without a trailing dot. It is sent as the
+// TLS SNI value when validating IP address identifiers (RFC 8738 Section 6).
+func reverseName(ip string) (string, error) {
+	name, err := dns.ReverseAddr(ip)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(name, "."), nil
+}
+
 // Confirm that of the OIDs provided, all of them are in the provided list of
 // extensions. Also confirms that of the extensions provided that none are
 // repeated. Per RFC8737, allows unexpected extensions.
@@ -222,18 +274,37 @@ func checkAcceptableExtensions(exts []pkix.Extension, requiredOIDs []asn1.Object
 }
 
 func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identifier identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
-	if identifier.Type != "dns" {
-		va.log.Info(fmt.Sprintf("Identifier type for TLS-ALPN-01 was not DNS: %s", identifier))
-		return nil, probs.Malformed("Identifier type for TLS-ALPN-01 was not DNS")
+	validationRecords, _, problem := va.checkTLSALPN01(ctx, identifier, challenge)
+	return validationRecords, problem
+}
+
+// checkTLSALPN01 performs tls-alpn-01 validation like validateTLSALPN01, and
+// also returns the state of the connection on which the challenge certificate
+// was received, if any.
+func (va *ValidationAuthorityImpl) checkTLSALPN01(ctx context.Context, ident identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *tls.ConnectionState, *probs.ProblemDetails) {
+	if ident.Type != identifier.DNS && ident.Type != identifier.IP {
+		va.log.Info(fmt.Sprintf("Identifier type for TLS-ALPN-01 was not DNS or IP: %s", ident))
+		return nil, nil, probs.Malformed("Identifier type for TLS-ALPN-01 was not DNS or IP")
+	}
+
+	// IP address identifiers are validated using the reverse DNS name of the
+	// address as the SNI value.
+	serverName := ident.Value
+	if ident.Type == identifier.IP {
+		var err error
+		serverName, err = reverseName(ident.Value)
+		if err != nil {
+			return nil, nil, probs.Malformed("Invalid IP address identifier %q", ident.Value)
+		}
 	}
 
-	cert, cs, validationRecords, problem := va.tryGetChallengeCert(ctx, identifier, challenge, &tls.Config{
+	cert, cs, validationRecords, problem := va.tryGetChallengeCert(ctx, ident, challenge, &tls.Config{
 		MinVersion: tls.VersionTLS12,
 		NextProtos: []string{ACMETLS1Protocol},
-		ServerName: identifier.Value,
+		ServerName: serverName,
 	})
 	if problem != nil {
-		return validationRecords, problem
+		return validationRecords, cs, problem
 	}
 
 	if cs.NegotiatedProtocol != ACMETLS1Protocol {
@@ -242,7 +313,7 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 			ACMETLS1Protocol,
 			core.ChallengeTypeTLSALPN01,
 		)
-		return validationRecords, probs.Unauthorized(errText)
+		return validationRecords, cs, probs.Unauthorized(errText)
 	}
 
 	hostPort := net.JoinHostPort(validationRecords[0].AddressUsed.String(), validationRecords[0].Port)
@@ -254,8 +325,8 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 			"Incorrect validation certificate for %s challenge. "+
 				"Requested %s from %s. "+
 				"Received certificate which is not self-signed.",
-			challenge.Type, identifier.Value, hostPort)
-		return validationRecords, probs.Unauthorized(errText)
+			challenge.Type, ident.Value, hostPort)
+		return validationRecords, cs, probs.Unauthorized(errText)
 	}
 
 	// The certificate must have the subjectAltName and acmeIdentifier
@@ -270,13 +341,13 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 				"Requested %s from %s. "+
 				"Received certificate with unexpected extensions. "+
 				"Got error: %q",
-			challenge.Type, identifier.Value, hostPort, err)
-		return validationRecords, probs.Unauthorized(errText)
+			challenge.Type, ident.Value, hostPort, err)
+		return validationRecords, cs, probs.Unauthorized(errText)
 	}
 
 	// The certificate returned must have a subjectAltName extension containing
-	// only the dNSName being validated and no other entries.
-	err = checkExpectedSAN(cert, identifier)
+	// only the dNSName or iPAddress being validated and no other entries.
+	err = checkExpectedSAN(cert, ident)
 	if err != nil {
 		names := certAltNames(cert)
 		errText := fmt.Sprintf(
@@ -284,8 +355,8 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 				"Requested %s from %s. "+
 				"Received certificate with unexpected identifiers: %q. "+
 				"Got error: %q",
-			challenge.Type, identifier.Value, hostPort, strings.Join(names, ", "), err)
-		return validationRecords, probs.Unauthorized(errText)
+			challenge.Type, ident.Value, hostPort, strings.Join(names, ", "), err)
+		return validationRecords, cs, probs.Unauthorized(errText)
 	}
 
 	// Verify key authorization in acmeValidation extension
@@ -296,22 +367,22 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 			if !ext.Critical {
 				errText := fmt.Sprintf("Incorrect validation certificate for %s challenge. "+
 					"acmeValidationV1 extension not critical", core.ChallengeTypeTLSALPN01)
-				return validationRecords, probs.Unauthorized(errText)
+				return validationRecords, cs, probs.Unauthorized(errText)
 			}
 			var extValue []byte
 			rest, err := asn1.Unmarshal(ext.Value, &extValue)
 			if err != nil || len(rest) > 0 || len(h) != len(extValue) {
 				errText := fmt.Sprintf("Incorrect validation certificate for %s challenge. "+
 					"Malformed acmeValidationV1 extension value", core.ChallengeTypeTLSALPN01)
-				return validationRecords, probs.Unauthorized(errText)
+				return validationRecords, cs, probs.Unauthorized(errText)
 			}
 			if subtle.ConstantTimeCompare(h[:], extValue) != 1 {
 				errText := fmt.Sprintf("Incorrect validation certificate for %s challenge. "+
 					"Expected acmeValidationV1 extension value %s for this challenge but got %s",
 					core.ChallengeTypeTLSALPN01, hex.EncodeToString(h[:]), hex.EncodeToString(extValue))
-				return validationRecords, probs.Unauthorized(errText)
+				return validationRecords, cs, probs.Unauthorized(errText)
 			}
-			return validationRecords, nil
+			return validationRecords, cs, nil
 		}
 	}
 
@@ -319,5 +390,5 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 		"Incorrect validation certificate for %s challenge. "+
 			"Missing acmeValidationV1 extension.",
 		core.ChallengeTypeTLSALPN01)
-	return validationRecords, probs.Unauthorized(errText)
+	return validationRecords, cs, probs.Unauthorized(errText)
 }
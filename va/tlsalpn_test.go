@@ -151,7 +151,7 @@ func TestTLSALPN01FailIP(t *testing.T) {
 		Value: net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
 	}, chall)
 	if prob == nil {
-		t.Fatalf("IdentifierType IP shouldn't have worked.")
+		t.Fatalf("IP identifier including a port shouldn't have worked.")
 	}
 	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
 }
@@ -272,6 +272,61 @@ func TestTLSALPN01Refused(t *testing.T) {
 	}
 }
 
+func TestTLSALPN01IPv4Fallback(t *testing.T) {
+	// The server listens only on 127.0.0.1, so connecting to ::1 fails.
+	hs := httptest.NewTLSServer(http.NewServeMux())
+	defer hs.Close()
+
+	va, _ := setup(hs, 0, "", nil)
+	cert, _, records, prob := va.tryGetChallengeCert(ctx, dnsi("ipv4.and.ipv6.localhost"), tlsalpnChallenge(), &tls.Config{})
+	test.Assert(t, prob == nil, fmt.Sprintf("unexpected problem: %s", prob))
+	test.AssertNotNil(t, cert, "expected a certificate")
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].AddressUsed.String(), "127.0.0.1")
+	test.AssertDeepEquals(t, records[0].AddressesTried, []net.IP{net.ParseIP("::1")})
+	test.AssertEquals(t, len(records[0].AddressErrors), 1)
+	test.AssertEquals(t, records[0].AddressErrors[0].Address.String(), "::1")
+	test.Assert(t, records[0].AddressErrors[0].Error != "", "expected an error for ::1")
+	test.AssertMetricWithLabelsEquals(t, va.metrics.ipv4FallbackCounter, prometheus.Labels{}, 1)
+
+	// With no IPv4 address to fall back to, the problem connecting to the IPv6
+	// address is returned.
+	_, _, records, prob = va.tryGetChallengeCert(ctx, dnsi("ipv6.localhost"), tlsalpnChallenge(), &tls.Config{})
+	test.AssertNotNil(t, prob, "expected a problem")
+	test.AssertEquals(t, prob.Type, probs.ConnectionProblem)
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].AddressUsed.String(), "::1")
+	test.AssertEquals(t, len(records[0].AddressesTried), 0)
+	test.AssertEquals(t, len(records[0].AddressErrors), 1)
+	test.AssertContains(t, records[0].AddressErrors[0].Error, "dial tcp [::1]")
+	test.AssertMetricWithLabelsEquals(t, va.metrics.ipv4FallbackCounter, prometheus.Labels{}, 1)
+}
+
+func TestTLSALPN01NoIPv4FallbackAfterConnect(t *testing.T) {
+	// The server listens on 127.0.0.1, and an HTTP server listens on the same
+	// port of ::1, so connecting to ::1 succeeds but the handshake fails.
+	hs := httptest.NewTLSServer(http.NewServeMux())
+	defer hs.Close()
+	httpOnly := httptest.NewUnstartedServer(http.NewServeMux())
+	l, err := net.Listen("tcp", net.JoinHostPort("::1", strconv.Itoa(getPort(hs))))
+	if err != nil {
+		t.Skipf("listening on ::1: %s", err)
+	}
+	httpOnly.Listener = l
+	httpOnly.Start()
+	defer httpOnly.Close()
+
+	va, _ := setup(hs, 0, "", nil)
+	_, _, records, prob := va.tryGetChallengeCert(ctx, dnsi("ipv4.and.ipv6.localhost"), tlsalpnChallenge(), &tls.Config{})
+	test.AssertNotNil(t, prob, "expected a problem")
+	test.AssertEquals(t, prob.Detail, "Server only speaks HTTP, not TLS")
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].AddressUsed.String(), "::1")
+	test.AssertEquals(t, len(records[0].AddressesTried), 0)
+	test.AssertEquals(t, len(records[0].AddressErrors), 0)
+	test.AssertMetricWithLabelsEquals(t, va.metrics.ipv4FallbackCounter, prometheus.Labels{}, 0)
+}
+
 func TestTLSALPN01TalkingToHTTP(t *testing.T) {
 	chall := tlsalpnChallenge()
 	hs, err := tlsalpn01Srv(t, chall, IdPeAcmeIdentifier, 0, "expected")
@@ -397,7 +452,7 @@ func TestTLSALPN01Success(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	if prob != nil {
 		t.Errorf("Validation failed: %v", prob)
 	}
@@ -407,6 +462,45 @@ func TestTLSALPN01Success(t *testing.T) {
 	hs.Close()
 }
 
+func TestTLSALPN01SuccessIP(t *testing.T) {
+	chall := tlsalpnChallenge()
+	template := tlsCertTemplate(nil)
+	template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+
+	shasum := sha256.Sum256([]byte(chall.ProvidedKeyAuthorization))
+	encHash, err := asn1.Marshal(shasum[:])
+	test.AssertNotError(t, err, "failed to create key authorization")
+	template.ExtraExtensions = []pkix.Extension{{Id: IdPeAcmeIdentifier, Critical: true, Value: encHash}}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &TheKey.PublicKey, &TheKey)
+	test.AssertNotError(t, err, "failed to create acme-tls/1 cert")
+	acmeCert := &tls.Certificate{
+		Certificate: [][]byte{certBytes},
+		PrivateKey:  &TheKey,
+	}
+
+	hs := tlsalpn01SrvWithCert(t, chall, IdPeAcmeIdentifier, nil, acmeCert, 0)
+	defer hs.Close()
+	var gotServerName string
+	getCert := hs.TLS.GetCertificate
+	hs.TLS.GetCertificate = func(clientHello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		gotServerName = clientHello.ServerName
+		return getCert(clientHello)
+	}
+
+	va, _ := setup(hs, 0, "", nil)
+
+	_, prob := va.validateChallenge(ctx, identifier.IPIdentifier(net.ParseIP("127.0.0.1")), 0, chall)
+	if prob != nil {
+		t.Errorf("Validation failed: %v", prob)
+	}
+	test.AssertEquals(t, gotServerName, "1.0.0.127.in-addr.arpa")
+
+	// A certificate for a different IP address must be rejected.
+	_, prob = va.validateChallenge(ctx, identifier.IPIdentifier(net.ParseIP("127.0.0.2")), 0, chall)
+	test.Assert(t, prob != nil, "Validation succeeded for the wrong IP address")
+}
+
 func TestTLSALPN01ObsoleteFailure(t *testing.T) {
 	// NOTE: unfortunately another document claimed the OID we were using in
 	// draft-ietf-acme-tls-alpn-01 for their own extension and IANA chose to
@@ -423,7 +517,7 @@ func TestTLSALPN01ObsoleteFailure(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	test.AssertNotNil(t, prob, "expected validation to fail")
 }
 
@@ -569,7 +663,7 @@ func TestTLSALPN01TLSVersion(t *testing.T) {
 
 		va, _ := setup(hs, 0, "", nil)
 
-		_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+		_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 		if !tc.expectError {
 			if prob != nil {
 				t.Errorf("expected success, got: %v", prob)
@@ -596,7 +690,7 @@ func TestTLSALPN01WrongName(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	test.AssertError(t, prob, "validation should have failed")
 }
 
@@ -609,7 +703,7 @@ func TestTLSALPN01ExtraNames(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	test.AssertError(t, prob, "validation should have failed")
 }
 
@@ -667,7 +761,7 @@ func TestTLSALPN01NotSelfSigned(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	test.AssertError(t, prob, "validation should have failed")
 	test.AssertContains(t, prob.Detail, "not self-signed")
 }
@@ -714,7 +808,7 @@ func TestTLSALPN01ExtraIdentifiers(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	test.AssertError(t, prob, "validation should have failed")
 }
 
@@ -774,7 +868,7 @@ func TestTLSALPN01ExtraSANs(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	test.AssertError(t, prob, "validation should have failed")
 	test.AssertContains(t, prob.Error(), "Extension OID 2.5.29.17 seen twice")
 }
@@ -827,7 +921,7 @@ func TestTLSALPN01ExtraAcmeExtensions(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("expected"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("expected"), 0, chall)
 	test.AssertError(t, prob, "validation should have failed")
 	test.AssertContains(t, prob.Error(), "Extension OID 1.3.6.1.5.5.7.1.31 seen twice")
 }
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -74,6 +75,9 @@ var (
 type RemoteVA struct {
 	vapb.VAClient
 	Address string
+	// Perspective optionally labels the network perspective (for instance,
+	// the zone) from which the remote VA validates.
+	Perspective string
 }
 
 type vaMetrics struct {
@@ -85,6 +89,7 @@ type vaMetrics struct {
 	tlsALPNOIDCounter                   *prometheus.CounterVec
 	http01Fallbacks                     prometheus.Counter
 	http01Redirects                     prometheus.Counter
+	http01PolicyRejections              *prometheus.CounterVec
 	caaCounter                          *prometheus.CounterVec
 	ipv4FallbackCounter                 prometheus.Counter
 }
@@ -146,6 +151,13 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 			Help: "Number of HTTP-01 redirects followed",
 		})
 	stats.MustRegister(http01Redirects)
+	http01PolicyRejections := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http01_policy_rejections",
+			Help: "Number of HTTP-01 validations refused by the VA's HTTP-01 policy, labelled by reason",
+		},
+		[]string{"reason"})
+	stats.MustRegister(http01PolicyRejections)
 	caaCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "caa_sets_processed",
 		Help: "A counter of CAA sets processed labelled by result",
@@ -166,6 +178,7 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 		tlsALPNOIDCounter:                   tlsALPNOIDCounter,
 		http01Fallbacks:                     http01Fallbacks,
 		http01Redirects:                     http01Redirects,
+		http01PolicyRejections:              http01PolicyRejections,
 		caaCounter:                          caaCounter,
 		ipv4FallbackCounter:                 ipv4FallbackCounter,
 	}
@@ -175,6 +188,7 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 type ValidationAuthorityImpl struct {
 	vapb.UnimplementedVAServer
 	vapb.UnimplementedCAAServer
+	vapb.UnimplementedVADebugServer
 	log                blog.Logger
 	dnsClient          bdns.Client
 	issuerDomain       string
@@ -187,6 +201,21 @@ type ValidationAuthorityImpl struct {
 	maxRemoteFailures  int
 	accountURIPrefixes []string
 	singleDialTimeout  time.Duration
+	// remoteVAQuorum is the number of remote VAs in each perspective which
+	// must succeed for a validation to succeed, in addition to no more than
+	// maxRemoteFailures failing overall.
+	remoteVAQuorum map[string]int
+	// caaCache is nil unless CAA lookups are cached.
+	caaCache *CAACache
+	// iodefReporter is nil unless issuance refused by CAA is reported to the
+	// CAA set's iodef targets.
+	iodefReporter *IodefReporter
+	// egressProxy is nil unless http-01 and tls-alpn-01 connections are made
+	// through an egress proxy.
+	egressProxy *EgressProxy
+	// http01Policy governs how redirects are followed and responses read
+	// during http-01 validation, with the defaults filled in.
+	http01Policy HTTP01Policy
 
 	metrics *vaMetrics
 }
@@ -197,12 +226,17 @@ func NewValidationAuthorityImpl(
 	resolver bdns.Client,
 	remoteVAs []RemoteVA,
 	maxRemoteFailures int,
+	remoteVAQuorum map[string]int,
 	userAgent string,
 	issuerDomain string,
 	stats prometheus.Registerer,
 	clk clock.Clock,
 	logger blog.Logger,
 	accountURIPrefixes []string,
+	caaCache *CAACache,
+	iodefReporter *IodefReporter,
+	egressProxy *EgressProxy,
+	http01Policy *HTTP01Policy,
 ) (*ValidationAuthorityImpl, error) {
 	if pc.HTTPPort == 0 {
 		pc.HTTPPort = 80
@@ -218,6 +252,32 @@ func NewValidationAuthorityImpl(
 		return nil, errors.New("no account URI prefixes configured")
 	}
 
+	for perspective, min := range remoteVAQuorum {
+		if perspective == "" || min < 1 {
+			return nil, fmt.Errorf("invalid remote VA quorum of %d for perspective %q", min, perspective)
+		}
+		count := 0
+		for _, rva := range remoteVAs {
+			if rva.Perspective == perspective {
+				count++
+			}
+		}
+		if count < min {
+			return nil, fmt.Errorf("remote VA quorum requires %d successes from perspective %q, which has %d remote VAs", min, perspective, count)
+		}
+	}
+
+	var policy HTTP01Policy
+	if http01Policy != nil {
+		policy = *http01Policy
+	}
+	if policy.maxRedirects == 0 {
+		policy.maxRedirects = maxRedirect
+	}
+	if policy.maxResponseSize == 0 {
+		policy.maxResponseSize = maxResponseSize
+	}
+
 	va := &ValidationAuthorityImpl{
 		log:                logger,
 		dnsClient:          resolver,
@@ -230,7 +290,12 @@ func NewValidationAuthorityImpl(
 		metrics:            initMetrics(stats),
 		remoteVAs:          remoteVAs,
 		maxRemoteFailures:  maxRemoteFailures,
+		remoteVAQuorum:     remoteVAQuorum,
 		accountURIPrefixes: accountURIPrefixes,
+		caaCache:           caaCache,
+		iodefReporter:      iodefReporter,
+		egressProxy:        egressProxy,
+		http01Policy:       policy,
 		// singleDialTimeout specifies how long an individual `DialContext` operation may take
 		// before timing out. This timeout ignores the base RPC timeout and is strictly
 		// used for the DialContext operations that take place during an
@@ -265,6 +330,11 @@ func detailedError(err error) *probs.ProblemDetails {
 		return prob
 	}
 
+	var proxyErr *egressProxyError
+	if errors.As(err, &proxyErr) {
+		return probs.ConnectionFailure(proxyErr.Error())
+	}
+
 	var tlsErr tls.RecordHeaderError
 	if errors.As(err, &tlsErr) && bytes.Equal(tlsErr.RecordHeader[:], badTLSHeader) {
 		return probs.Malformed("Server only speaks HTTP, not TLS")
@@ -320,7 +390,7 @@ func detailedError(err error) *probs.ProblemDetails {
 // validation attempt.
 func (va *ValidationAuthorityImpl) validate(
 	ctx context.Context,
-	identifier identifier.ACMEIdentifier,
+	ident identifier.ACMEIdentifier,
 	regid int64,
 	challenge core.Challenge,
 ) ([]core.ValidationRecord, *probs.ProblemDetails) {
@@ -328,26 +398,31 @@ func (va *ValidationAuthorityImpl) validate(
 	// If the identifier is a wildcard domain we need to validate the base
 	// domain by removing the "*." wildcard prefix. We create a separate
 	// `baseIdentifier` here before starting the `va.checkCAA` goroutine with the
-	// `identifier` to avoid a data race.
-	baseIdentifier := identifier
-	if strings.HasPrefix(identifier.Value, "*.") {
-		baseIdentifier.Value = strings.TrimPrefix(identifier.Value, "*.")
+	// `ident` to avoid a data race.
+	baseIdentifier := ident
+	if strings.HasPrefix(ident.Value, "*.") {
+		baseIdentifier.Value = strings.TrimPrefix(ident.Value, "*.")
 	}
 
 	// va.checkCAA accepts wildcard identifiers and handles them appropriately so
-	// we can dispatch `checkCAA` with the provided `identifier` instead of
+	// we can dispatch `checkCAA` with the provided `ident` instead of
 	// `baseIdentifier`
 	ch := make(chan *probs.ProblemDetails, 1)
-	go func() {
-		params := &caaParams{
-			accountURIID:     regid,
-			validationMethod: string(challenge.Type),
-		}
-		ch <- va.checkCAA(ctx, identifier, params)
-	}()
+	if ident.Type == identifier.IP {
+		// CAA does not apply to IP address identifiers (RFC 8738 Section 7)
+		ch <- nil
+	} else {
+		go func() {
+			params := &caaParams{
+				accountURIID:     regid,
+				validationMethod: string(challenge.Type),
+			}
+			ch <- va.checkCAA(ctx, ident, params)
+		}()
+	}
 
 	// TODO(#1292): send into another goroutine
-	validationRecords, err := va.validateChallenge(ctx, baseIdentifier, challenge)
+	validationRecords, err := va.validateChallenge(ctx, baseIdentifier, regid, challenge)
 	if err != nil {
 		return validationRecords, err
 	}
@@ -360,7 +435,7 @@ func (va *ValidationAuthorityImpl) validate(
 	return validationRecords, nil
 }
 
-func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identifier identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
+func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identifier identifier.ACMEIdentifier, regid int64, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
 	err := challenge.CheckConsistencyForValidation()
 	if err != nil {
 		return nil, probs.Malformed("Challenge failed consistency check: %s", err)
@@ -374,6 +449,8 @@ func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identi
 		return va.validateTLSALPN01(ctx, identifier, challenge)
 	case core.ChallengeTypeTrustedJWT:
 		return va.validateTrustedJWT(ctx, identifier, challenge)
+	case core.ChallengeTypeDNSAccount01:
+		return va.validateDNSAccount01(ctx, identifier, challenge, regid)
 	}
 	return nil, probs.Malformed("invalid challenge type %s", challenge.Type)
 }
@@ -394,7 +471,8 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 		remoteVA := va.remoteVAs[i]
 		go func(rva RemoteVA, index int) {
 			result := &remoteValidationResult{
-				VAHostname: rva.Address,
+				VAHostname:  rva.Address,
+				Perspective: rva.Perspective,
 			}
 			res, err := rva.PerformValidation(ctx, req)
 			if err != nil && canceled.Is(err) {
@@ -423,10 +501,66 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 	}
 }
 
+// perspectiveTally counts the results of the remote VAs in one perspective.
+type perspectiveTally struct {
+	successes int
+	failures  int
+	// pending is the number of remote VAs in the perspective yet to respond.
+	pending int
+	// firstProb is the first problem returned by a remote VA in the
+	// perspective.
+	firstProb *probs.ProblemDetails
+}
+
+// perspectiveResults summarizes the tallies of the labelled perspectives, in
+// order of perspective name.
+func perspectiveResults(tallies map[string]*perspectiveTally) []core.PerspectiveResult {
+	var results []core.PerspectiveResult
+	for name, tally := range tallies {
+		if name == "" {
+			continue
+		}
+		results = append(results, core.PerspectiveResult{
+			Perspective: name,
+			Successes:   tally.successes,
+			Failures:    tally.failures,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Perspective < results[j].Perspective
+	})
+	return results
+}
+
+// quorumMet returns true if every perspective in the VA's remoteVAQuorum has
+// at least the required number of successes.
+func (va *ValidationAuthorityImpl) quorumMet(tallies map[string]*perspectiveTally) bool {
+	for name, min := range va.remoteVAQuorum {
+		if tallies[name] == nil || tallies[name].successes < min {
+			return false
+		}
+	}
+	return true
+}
+
+// quorumUnreachable returns a perspective in the VA's remoteVAQuorum which
+// can no longer reach its required number of successes, or "" if there is
+// none.
+func (va *ValidationAuthorityImpl) quorumUnreachable(tallies map[string]*perspectiveTally) string {
+	for name, min := range va.remoteVAQuorum {
+		if tallies[name] == nil || tallies[name].successes+tallies[name].pending < min {
+			return name
+		}
+	}
+	return ""
+}
+
 // processRemoteResults evaluates a primary VA result, and a channel of remote
 // VA problems to produce a single overall validation result based on configured
 // feature flags. The overall result is calculated based on the VA's configured
-// `maxRemoteFailures` value.
+// `maxRemoteFailures` value and, if set, its `remoteVAQuorum` of successes
+// required from each perspective. Alongside the overall result it returns the
+// results of each labelled perspective.
 //
 // If the `MultiVAFullResults` feature is enabled then `processRemoteResults`
 // will expect to read a result from the `remoteErrors` channel for each VA and
@@ -445,7 +579,7 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 	challengeType string,
 	primaryResult *probs.ProblemDetails,
 	remoteResultsChan chan *remoteValidationResult,
-	numRemoteVAs int) *probs.ProblemDetails {
+	numRemoteVAs int) (*probs.ProblemDetails, []core.PerspectiveResult) {
 
 	state := "failure"
 	start := va.clk.Now()
@@ -461,6 +595,40 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 	good := 0
 	bad := 0
 
+	tallies := make(map[string]*perspectiveTally)
+	for name := range va.remoteVAQuorum {
+		tallies[name] = &perspectiveTally{}
+	}
+	for _, rva := range va.remoteVAs {
+		if tallies[rva.Perspective] == nil {
+			tallies[rva.Perspective] = &perspectiveTally{}
+		}
+		tallies[rva.Perspective].pending++
+	}
+
+	// outcome returns the overall result once it's decided, and false if it
+	// isn't yet.
+	outcome := func(firstProb *probs.ProblemDetails) (*probs.ProblemDetails, bool) {
+		if good >= required && va.quorumMet(tallies) {
+			state = "success"
+			return nil, true
+		}
+		failed := va.quorumUnreachable(tallies)
+		if bad > va.maxRemoteFailures || failed != "" {
+			prob := firstProb
+			if failed != "" && tallies[failed] != nil && tallies[failed].firstProb != nil {
+				prob = tallies[failed].firstProb
+			}
+			if prob == nil {
+				return probs.ServerInternal(fmt.Sprintf("Too few remote VAs in perspective %q", failed)), true
+			}
+			modifiedProblem := *prob
+			modifiedProblem.Detail = "During secondary validation: " + prob.Detail
+			return &modifiedProblem, true
+		}
+		return nil, false
+	}
+
 	var remoteResults []*remoteValidationResult
 	var firstProb *probs.ProblemDetails
 	// Due to channel behavior this could block indefinitely and we rely on gRPC
@@ -469,10 +637,21 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 	for result := range remoteResultsChan {
 		// Add the result to the slice
 		remoteResults = append(remoteResults, result)
+		tally := tallies[result.Perspective]
+		if tally == nil {
+			tally = &perspectiveTally{}
+			tallies[result.Perspective] = tally
+		}
+		tally.pending--
 		if result.Problem == nil {
 			good++
+			tally.successes++
 		} else {
 			bad++
+			tally.failures++
+			if tally.firstProb == nil {
+				tally.firstProb = result.Problem
+			}
 		}
 
 		// Store the first non-nil problem to return later (if `MultiVAFullResults`
@@ -484,13 +663,8 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		// If MultiVAFullResults isn't enabled then return early whenever the
 		// success or failure threshold is met.
 		if !features.Enabled(features.MultiVAFullResults) {
-			if good >= required {
-				state = "success"
-				return nil
-			} else if bad > va.maxRemoteFailures {
-				modifiedProblem := *result.Problem
-				modifiedProblem.Detail = "During secondary validation: " + firstProb.Detail
-				return &modifiedProblem
+			if prob, done := outcome(firstProb); done {
+				return prob, perspectiveResults(tallies)
 			}
 		}
 
@@ -512,23 +686,26 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		remoteResults)
 
 	// Based on the threshold of good/bad return nil or a problem.
-	if good >= required {
-		state = "success"
-		return nil
-	} else if bad > va.maxRemoteFailures {
-		modifiedProblem := *firstProb
-		modifiedProblem.Detail = "During secondary validation: " + firstProb.Detail
-		return &modifiedProblem
+	if prob, done := outcome(firstProb); done {
+		return prob, perspectiveResults(tallies)
 	}
 
 	// This condition should not occur - it indicates the good/bad counts didn't
 	// meet either the required threshold or the maxRemoteFailures threshold.
-	return probs.ServerInternal("Too few remote PerformValidation RPC results")
+	return probs.ServerInternal("Too few remote PerformValidation RPC results"), perspectiveResults(tallies)
+}
+
+// perspectiveDifferential is the part of a remoteVADifferentials log line
+// describing the results of the remote VAs in one perspective.
+type perspectiveDifferential struct {
+	RemoteSuccesses int
+	RemoteFailures  []*remoteValidationResult
 }
 
 // logRemoteValidationDifferentials is called by `processRemoteResults` when the
 // `MultiVAFullResults` feature flag is enabled. It produces a JSON log line
-// that contains the primary VA result and the results each remote VA returned.
+// that contains the primary VA result and the results each remote VA returned,
+// also grouped by perspective if the remote VAs are labelled with them.
 func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 	domain string,
 	acctID int64,
@@ -538,16 +715,38 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 
 	var successes []*remoteValidationResult
 	var failures []*remoteValidationResult
+	var perspectives map[string]*perspectiveDifferential
+	tallies := make(map[string]*perspectiveTally)
 
 	allEqual := true
 	for _, result := range remoteResults {
 		if result.Problem != primaryResult {
 			allEqual = false
 		}
+		if tallies[result.Perspective] == nil {
+			tallies[result.Perspective] = &perspectiveTally{}
+		}
+		var perspective *perspectiveDifferential
+		if result.Perspective != "" {
+			if perspectives == nil {
+				perspectives = make(map[string]*perspectiveDifferential)
+			}
+			if perspectives[result.Perspective] == nil {
+				perspectives[result.Perspective] = &perspectiveDifferential{}
+			}
+			perspective = perspectives[result.Perspective]
+		}
 		if result.Problem == nil {
 			successes = append(successes, result)
+			tallies[result.Perspective].successes++
+			if perspective != nil {
+				perspective.RemoteSuccesses++
+			}
 		} else {
 			failures = append(failures, result)
+			if perspective != nil {
+				perspective.RemoteFailures = append(perspective.RemoteFailures, result)
+			}
 		}
 	}
 	if allEqual {
@@ -557,9 +756,10 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 	}
 
 	// If the primary result was OK and there were more failures than the allowed
-	// threshold increment a stat that indicates this overall validation will have
-	// failed if features.EnforceMultiVA is enabled.
-	if primaryResult == nil && len(failures) > va.maxRemoteFailures {
+	// threshold, or too few successes from a perspective, increment a stat that
+	// indicates this overall validation will have failed if
+	// features.EnforceMultiVA is enabled.
+	if primaryResult == nil && (len(failures) > va.maxRemoteFailures || !va.quorumMet(tallies)) {
 		va.metrics.prospectiveRemoteValidationFailures.Inc()
 	}
 
@@ -570,6 +770,7 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 		PrimaryResult   *probs.ProblemDetails
 		RemoteSuccesses int
 		RemoteFailures  []*remoteValidationResult
+		Perspectives    map[string]*perspectiveDifferential `json:",omitempty"`
 	}{
 		Domain:          domain,
 		AccountID:       acctID,
@@ -577,6 +778,7 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 		PrimaryResult:   primaryResult,
 		RemoteSuccesses: len(successes),
 		RemoteFailures:  failures,
+		Perspectives:    perspectives,
 	}
 
 	logJSON, err := json.Marshal(logOb)
@@ -593,10 +795,12 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 }
 
 // remoteValidationResult is a struct that combines a problem details instance
-// (that may be nil) with the remote VA hostname that produced it.
+// (that may be nil) with the remote VA hostname and perspective that produced
+// it.
 type remoteValidationResult struct {
-	VAHostname string
-	Problem    *probs.ProblemDetails
+	VAHostname  string
+	Perspective string `json:",omitempty"`
+	Problem     *probs.ProblemDetails
 }
 
 // PerformValidation validates the challenge for the domain in the request.
@@ -627,7 +831,12 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, req *v
 		return nil, probs.ServerInternal("Challenge failed to deserialize")
 	}
 
-	records, prob := va.validate(ctx, identifier.DNSIdentifier(req.Domain), req.Authz.RegID, challenge)
+	ident := identifier.DNSIdentifier(req.Domain)
+	if req.TypeIdentifier == string(identifier.IP) {
+		ident = identifier.ACMEIdentifier{Type: identifier.IP, Value: req.Domain}
+	}
+
+	records, prob := va.validate(ctx, ident, req.Authz.RegID, challenge)
 	challenge.ValidationRecord = records
 	localValidationLatency := time.Since(vStart)
 
@@ -649,7 +858,7 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, req *v
 			// differentials then collect and log the remote results in a separate go
 			// routine to avoid blocking the primary VA.
 			go func() {
-				_ = va.processRemoteResults(
+				_, _ = va.processRemoteResults(
 					req.Domain,
 					req.Authz.RegID,
 					string(challenge.Type),
@@ -662,13 +871,18 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, req *v
 			// validationTime metrics increment has the correct result label.
 			challenge.Status = core.StatusValid
 		} else if features.Enabled(features.EnforceMultiVA) {
-			remoteProb := va.processRemoteResults(
+			remoteProb, perspectives := va.processRemoteResults(
 				req.Domain,
 				req.Authz.RegID,
 				string(challenge.Type),
 				prob,
 				remoteResults,
 				len(va.remoteVAs))
+			// Record the results of each perspective in the first validation
+			// record, as evidence of corroboration.
+			if len(records) > 0 {
+				records[0].Perspectives = perspectives
+			}
 
 			// If the remote result was a non-nil problem then fail the validation
 			if remoteProb != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/letsencrypt/boulder/core"
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/identifier"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/metrics"
@@ -137,12 +138,17 @@ func setup(srv *httptest.Server, maxRemoteFailures int, userAgent string, remote
 		&bdns.MockClient{Log: logger},
 		nil,
 		maxRemoteFailures,
+		nil,
 		userAgent,
 		"letsencrypt.org",
 		metrics.NoopRegisterer,
 		fc,
 		logger,
 		accountURIPrefixes,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to create validation authority: %v", err))
@@ -236,7 +242,7 @@ func (lrva localRemoteVA) PerformValidation(ctx context.Context, req *vapb.Perfo
 func TestValidateMalformedChallenge(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("example.com"), createChallenge("fake-type-01"))
+	_, prob := va.validateChallenge(ctx, dnsi("example.com"), 0, createChallenge("fake-type-01"))
 
 	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
 }
@@ -332,8 +338,8 @@ func TestMultiVA(t *testing.T) {
 	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
 
 	remoteVAs := []RemoteVA{
-		{remoteVA1, remoteUA1},
-		{remoteVA2, remoteUA2},
+		{VAClient: remoteVA1, Address: remoteUA1},
+		{VAClient: remoteVA2, Address: remoteUA2},
 	}
 
 	enforceMultiVA := map[string]bool{
@@ -401,8 +407,8 @@ func TestMultiVA(t *testing.T) {
 			// If a remote VA fails with an internal err it should fail when enforcing multi VA
 			Name: "Local VA ok, remote VA internal err, enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{remoteVA1, remoteUA1},
-				{&brokenRemoteVA{}, "broken"},
+				{VAClient: remoteVA1, Address: remoteUA1},
+				{VAClient: &brokenRemoteVA{}, Address: "broken"},
 			},
 			AllowedUAs:   allowedUAs,
 			Features:     enforceMultiVA,
@@ -415,8 +421,8 @@ func TestMultiVA(t *testing.T) {
 			// enforcing multi VA
 			Name: "Local VA ok, remote VA internal err, no enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{remoteVA1, remoteUA1},
-				{&brokenRemoteVA{}, "broken"},
+				{VAClient: remoteVA1, Address: remoteUA1},
+				{VAClient: &brokenRemoteVA{}, Address: "broken"},
 			},
 			AllowedUAs: allowedUAs,
 			Features:   noEnforceMultiVA,
@@ -446,8 +452,8 @@ func TestMultiVA(t *testing.T) {
 			// When enforcing multi-VA, any cancellations are a problem.
 			Name: "Local VA and one remote VA OK, one cancelled VA, enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{remoteVA1, remoteUA1},
-				{cancelledVA{}, remoteUA2},
+				{VAClient: remoteVA1, Address: remoteUA1},
+				{VAClient: cancelledVA{}, Address: remoteUA2},
 			},
 			AllowedUAs:   allowedUAs,
 			Features:     enforceMultiVA,
@@ -457,8 +463,8 @@ func TestMultiVA(t *testing.T) {
 			// When enforcing multi-VA, any cancellations are a problem.
 			Name: "Local VA OK, two cancelled remote VAs, enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{cancelledVA{}, remoteUA1},
-				{cancelledVA{}, remoteUA2},
+				{VAClient: cancelledVA{}, Address: remoteUA1},
+				{VAClient: cancelledVA{}, Address: remoteUA2},
 			},
 			AllowedUAs:   allowedUAs,
 			Features:     enforceMultiVA,
@@ -537,8 +543,8 @@ func TestMultiVAEarlyReturn(t *testing.T) {
 	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
 
 	remoteVAs := []RemoteVA{
-		{remoteVA1, remoteUA1},
-		{remoteVA2, remoteUA2},
+		{VAClient: remoteVA1, Address: remoteUA1},
+		{VAClient: remoteVA2, Address: remoteUA2},
 	}
 
 	// Create a local test VA with the two remote VAs
@@ -625,8 +631,8 @@ func TestMultiVAPolicy(t *testing.T) {
 	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
 
 	remoteVAs := []RemoteVA{
-		{remoteVA1, remoteUA1},
-		{remoteVA2, remoteUA2},
+		{VAClient: remoteVA1, Address: remoteUA1},
+		{VAClient: remoteVA2, Address: remoteUA2},
 	}
 
 	// Create a local test VA with the two remote VAs
@@ -692,9 +698,9 @@ func TestLogRemoteValidationDifferentials(t *testing.T) {
 	remoteVA2, _ := setupRemote(nil, 0, "remote 2")
 	remoteVA3, _ := setupRemote(nil, 0, "remote 3")
 	remoteVAs := []RemoteVA{
-		{remoteVA1, "remote 1"},
-		{remoteVA2, "remote 2"},
-		{remoteVA3, "remote 3"},
+		{VAClient: remoteVA1, Address: "remote 1"},
+		{VAClient: remoteVA2, Address: "remote 2"},
+		{VAClient: remoteVA3, Address: "remote 3"},
 	}
 
 	// Set up a local VA that allows a max of 2 remote failures.
@@ -747,6 +753,16 @@ func TestLogRemoteValidationDifferentials(t *testing.T) {
 			},
 			expectedLog: `INFO: remoteVADifferentials JSON={"Domain":"example.com","AccountID":1999,"ChallengeType":"blorpus-01","PrimaryResult":{"type":"dns","detail":"root DNS servers closed at 4:30pm","status":400},"RemoteSuccesses":2,"RemoteFailures":[{"VAHostname":"remoteB","Problem":{"type":"orderNotReady","detail":"please take a number","status":403}}]}`,
 		},
+		{
+			name:          "remote and primary differ (grouped by perspective)",
+			primaryResult: nil,
+			remoteProbs: []*remoteValidationResult{
+				{Problem: nil, VAHostname: "remoteA", Perspective: "zone-a"},
+				{Problem: egProbB, VAHostname: "remoteB", Perspective: "zone-a"},
+				{Problem: nil, VAHostname: "remoteC", Perspective: "zone-b"},
+			},
+			expectedLog: `INFO: remoteVADifferentials JSON={"Domain":"example.com","AccountID":1999,"ChallengeType":"blorpus-01","PrimaryResult":null,"RemoteSuccesses":2,"RemoteFailures":[{"VAHostname":"remoteB","Perspective":"zone-a","Problem":{"type":"orderNotReady","detail":"please take a number","status":403}}],"Perspectives":{"zone-a":{"RemoteSuccesses":1,"RemoteFailures":[{"VAHostname":"remoteB","Perspective":"zone-a","Problem":{"type":"orderNotReady","detail":"please take a number","status":403}}]},"zone-b":{"RemoteSuccesses":1,"RemoteFailures":null}}}`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -766,3 +782,145 @@ func TestLogRemoteValidationDifferentials(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoteVAQuorum(t *testing.T) {
+	const (
+		remoteUA1 = "remote a1"
+		remoteUA2 = "remote a2"
+		remoteUA3 = "remote b1"
+		localUA   = "local 1"
+	)
+	ms := httpMultiSrv(t, expectedToken, nil)
+	defer ms.Close()
+
+	remoteVA1, _ := setupRemote(ms.Server, 0, remoteUA1)
+	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
+	remoteVA3, _ := setupRemote(ms.Server, 0, remoteUA3)
+	remoteVAs := []RemoteVA{
+		{VAClient: remoteVA1, Address: remoteUA1, Perspective: "zone-a"},
+		{VAClient: remoteVA2, Address: remoteUA2, Perspective: "zone-a"},
+		{VAClient: remoteVA3, Address: remoteUA3, Perspective: "zone-b"},
+	}
+
+	unauthorized := probs.Unauthorized(fmt.Sprintf(
+		`The key authorization file from the server did not match this challenge %q != "???"`,
+		expectedKeyAuthorization))
+
+	testCases := []struct {
+		Name                 string
+		ForbiddenUAs         []string
+		ExpectedProb         *probs.ProblemDetails
+		ExpectedPerspectives []core.PerspectiveResult
+	}{
+		{
+			Name: "all remote VAs succeed",
+			ExpectedPerspectives: []core.PerspectiveResult{
+				{Perspective: "zone-a", Successes: 2},
+				{Perspective: "zone-b", Successes: 1},
+			},
+		},
+		{
+			Name:         "one failure, with a success from each perspective",
+			ForbiddenUAs: []string{remoteUA1},
+			ExpectedPerspectives: []core.PerspectiveResult{
+				{Perspective: "zone-a", Successes: 1, Failures: 1},
+				{Perspective: "zone-b", Successes: 1},
+			},
+		},
+		{
+			Name:         "one failure, with no success from a perspective",
+			ForbiddenUAs: []string{remoteUA3},
+			ExpectedProb: probs.Unauthorized("During secondary validation: " + unauthorized.Detail),
+			ExpectedPerspectives: []core.PerspectiveResult{
+				{Perspective: "zone-a", Successes: 2},
+				{Perspective: "zone-b", Failures: 1},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			allowedUAs := map[string]bool{localUA: true, remoteUA1: true, remoteUA2: true, remoteUA3: true}
+			for _, ua := range tc.ForbiddenUAs {
+				allowedUAs[ua] = false
+			}
+			ms.setAllowedUAs(allowedUAs)
+
+			localVA, _ := setup(ms.Server, 1, localUA, remoteVAs)
+			localVA.remoteVAQuorum = map[string]int{"zone-a": 1, "zone-b": 1}
+			err := features.Set(map[string]bool{
+				"EnforceMultiVA":     true,
+				"MultiVAFullResults": true,
+			})
+			test.AssertNotError(t, err, "Failed to set feature flags")
+			defer features.Reset()
+
+			res, err := localVA.PerformValidation(ctx, createValidationRequest("localhost", core.ChallengeTypeHTTP01))
+			test.AssertNotError(t, err, "PerformValidation failed")
+			if tc.ExpectedProb == nil {
+				test.Assert(t, res.Problems == nil, fmt.Sprintf("expected no problem, got %v", res.Problems))
+			} else {
+				test.Assert(t, res.Problems != nil, "expected a problem")
+				test.AssertEquals(t, res.Problems.ProblemType, string(tc.ExpectedProb.Type))
+				test.AssertEquals(t, res.Problems.Detail, tc.ExpectedProb.Detail)
+			}
+			record, err := bgrpc.PBToValidationRecord(res.Records[0])
+			test.AssertNotError(t, err, "PBToValidationRecord failed")
+			test.AssertDeepEquals(t, record.Perspectives, tc.ExpectedPerspectives)
+		})
+	}
+}
+
+func TestNewValidationAuthorityImplRemoteVAQuorum(t *testing.T) {
+	remoteVAs := []RemoteVA{
+		{VAClient: &brokenRemoteVA{}, Address: "a1", Perspective: "zone-a"},
+		{VAClient: &brokenRemoteVA{}, Address: "b1", Perspective: "zone-b"},
+	}
+	newVA := func(quorum map[string]int) error {
+		_, err := NewValidationAuthorityImpl(
+			&cmd.PortConfig{},
+			&bdns.MockClient{Log: blog.NewMock()},
+			remoteVAs,
+			1,
+			quorum,
+			"user agent",
+			"letsencrypt.org",
+			metrics.NoopRegisterer,
+			clock.NewFake(),
+			blog.NewMock(),
+			accountURIPrefixes,
+			nil,
+			nil,
+			nil,
+			nil)
+		return err
+	}
+
+	test.AssertNotError(t, newVA(map[string]int{"zone-a": 1, "zone-b": 1}), "valid quorum")
+	test.AssertError(t, newVA(map[string]int{"zone-a": 2}), "quorum exceeding a perspective's remote VAs")
+	test.AssertError(t, newVA(map[string]int{"zone-c": 1}), "quorum for a perspective without remote VAs")
+	test.AssertError(t, newVA(map[string]int{"zone-a": 0}), "quorum of zero")
+}
+
+func TestProcessRemoteResultsQuorumEarlyReturn(t *testing.T) {
+	remoteVAs := []RemoteVA{
+		{VAClient: &brokenRemoteVA{}, Address: "a1", Perspective: "zone-a"},
+		{VAClient: &brokenRemoteVA{}, Address: "a2", Perspective: "zone-a"},
+		{VAClient: &brokenRemoteVA{}, Address: "b1", Perspective: "zone-b"},
+	}
+	localVA, _ := setup(nil, 1, "local 1", remoteVAs)
+	localVA.remoteVAQuorum = map[string]int{"zone-a": 1, "zone-b": 1}
+
+	// The only remote VA in zone-b fails first, so the quorum can't be met
+	// whatever the remote VAs in zone-a return.
+	results := make(chan *remoteValidationResult, 3)
+	results <- &remoteValidationResult{VAHostname: "b1", Perspective: "zone-b", Problem: probs.ConnectionFailure("refused")}
+
+	prob, perspectives := localVA.processRemoteResults("example.com", 1, "http-01", nil, results, len(remoteVAs))
+	test.Assert(t, prob != nil, "expected a problem")
+	test.AssertEquals(t, prob.Detail, "During secondary validation: refused")
+	test.AssertDeepEquals(t, perspectives, []core.PerspectiveResult{
+		{Perspective: "zone-a"},
+		{Perspective: "zone-b", Failures: 1},
+	})
+}
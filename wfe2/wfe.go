@@ -60,6 +60,7 @@ const (
 	rolloverPath            = "/acme/key-change"
 	newNoncePath            = "/acme/new-nonce"
 	newOrderPath            = "/acme/new-order"
+	newAuthzPath            = "/acme/new-authz"
 	orderPath               = "/acme/order/"
 	finalizeOrderPath       = "/acme/finalize/"
 
@@ -113,6 +114,11 @@ type WebFrontEndImpl struct {
 	// "website" field.
 	DirectoryWebsite string
 
+	// DirectoryProfiles is used for the /directory response's "meta" element's
+	// "profiles" field. It maps the names of the certificate profiles clients
+	// may request in a newOrder request to human-readable descriptions.
+	DirectoryProfiles map[string]string
+
 	// Allowed prefix for legacy accounts used by verify.go's `lookupJWK`.
 	// See `cmd/boulder-wfe2/main.go`'s comment on the configuration field
 	// `LegacyKeyIDPrefix` for more information.
@@ -391,6 +397,7 @@ func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer) http.Handler {
 	wfe.HandleFunc(m, revokeCertPath, wfe.RevokeCertificate, "POST")
 	wfe.HandleFunc(m, rolloverPath, wfe.KeyRollover, "POST")
 	wfe.HandleFunc(m, newOrderPath, wfe.NewOrder, "POST")
+	wfe.HandleFunc(m, newAuthzPath, wfe.NewAuthorization, "POST")
 	wfe.HandleFunc(m, finalizeOrderPath, wfe.FinalizeOrder, "POST")
 
 	// GETable and POST-as-GETable ACME endpoints
@@ -487,6 +494,7 @@ func (wfe *WebFrontEndImpl) Directory(
 		"newNonce":   newNoncePath,
 		"revokeCert": revokeCertPath,
 		"newOrder":   newOrderPath,
+		"newAuthz":   newAuthzPath,
 		"keyChange":  rolloverPath,
 	}
 
@@ -529,6 +537,11 @@ func (wfe *WebFrontEndImpl) Directory(
 	if wfe.DirectoryWebsite != "" {
 		metaMap["website"] = wfe.DirectoryWebsite
 	}
+	// The "meta" directory entry may also include an object describing the
+	// certificate profiles clients may select when creating an order
+	if len(wfe.DirectoryProfiles) > 0 {
+		metaMap["profiles"] = wfe.DirectoryProfiles
+	}
 	directoryEndpoints["meta"] = metaMap
 
 	response.Header().Set("Content-Type", "application/json")
@@ -1713,7 +1726,7 @@ func (wfe *WebFrontEndImpl) Authorization(
 		return
 	}
 
-	if identifier.IdentifierType(authzPB.Identifier) == identifier.DNS {
+	if identifier.IdentifierType(authzPB.TypeIdentifier) == identifier.DNS {
 		logEvent.DNSName = authzPB.Identifier
 		beeline.AddFieldToTrace(ctx, "authz.dnsname", authzPB.Identifier)
 	}
@@ -2132,6 +2145,9 @@ type orderJSON struct {
 	Status         core.AcmeStatus             `json:"status"`
 	Expires        time.Time                   `json:"expires"`
 	Identifiers    []identifier.ACMEIdentifier `json:"identifiers"`
+	NotBefore      *time.Time                  `json:"notBefore,omitempty"`
+	NotAfter       *time.Time                  `json:"notAfter,omitempty"`
+	Profile        string                      `json:"profile,omitempty"`
 	Authorizations []string                    `json:"authorizations"`
 	Finalize       string                      `json:"finalize"`
 	Certificate    string                      `json:"certificate,omitempty"`
@@ -2145,7 +2161,7 @@ type orderJSON struct {
 func (wfe *WebFrontEndImpl) orderToOrderJSON(request *http.Request, order *corepb.Order) orderJSON {
 	idents := make([]identifier.ACMEIdentifier, len(order.Names))
 	for i, name := range order.Names {
-		idents[i] = identifier.ACMEIdentifier{Type: identifier.IdentifierType(order.TypeIdentifier), Value: name}
+		idents[i] = identifier.ForOrderName(identifier.IdentifierType(order.TypeIdentifier), name)
 	}
 	finalizeURL := web.RelativeEndpoint(request,
 		fmt.Sprintf("%s%d/%d", finalizeOrderPath, order.RegistrationID, order.Id))
@@ -2153,8 +2169,17 @@ func (wfe *WebFrontEndImpl) orderToOrderJSON(request *http.Request, order *corep
 		Status:      core.AcmeStatus(order.Status),
 		Expires:     time.Unix(0, order.Expires).UTC(),
 		Identifiers: idents,
+		Profile:     order.CertProfile,
 		Finalize:    finalizeURL,
 	}
+	if order.NotBefore != 0 {
+		notBefore := time.Unix(0, order.NotBefore).UTC()
+		respObj.NotBefore = &notBefore
+	}
+	if order.NotAfter != 0 {
+		notAfter := time.Unix(0, order.NotAfter).UTC()
+		respObj.NotAfter = &notAfter
+	}
 	// If there is an order error, prefix its type with the V2 namespace
 	if order.Error != nil {
 		prob, err := bgrpc.PBToProblemDetails(order.Error)
@@ -2190,12 +2215,15 @@ func (wfe *WebFrontEndImpl) NewOrder(
 		return
 	}
 
-	// We only allow specifying Identifiers in a new order request - if the
-	// `notBefore` and/or `notAfter` fields described in Section 7.4 of acme-08
-	// are sent we return a probs.Malformed as we do not support them
+	// The optional `notBefore` and `notAfter` fields described in Section 7.4
+	// of RFC 8555 are RFC 3339 timestamps. Whether the requested validity
+	// period is acceptable is up to the RA. The optional `profile` field names
+	// one of the certificate profiles advertised in the directory; the RA
+	// decides whether the account may use it.
 	var newOrderRequest struct {
 		Identifiers         []identifier.ACMEIdentifier `json:"identifiers"`
 		NotBefore, NotAfter string
+		Profile             string `json:"profile"`
 	}
 	err := json.Unmarshal(body, &newOrderRequest)
 	if err != nil {
@@ -2209,27 +2237,36 @@ func (wfe *WebFrontEndImpl) NewOrder(
 			probs.Malformed("NewOrder request did not specify any identifiers"), nil)
 		return
 	}
-	if newOrderRequest.NotBefore != "" || newOrderRequest.NotAfter != "" {
-		wfe.sendError(response, logEvent, probs.Malformed("NotBefore and NotAfter are not supported"), nil)
-		return
+	var notBefore, notAfter int64
+	if newOrderRequest.NotBefore != "" {
+		t, err := time.Parse(time.RFC3339, newOrderRequest.NotBefore)
+		if err != nil {
+			wfe.sendError(response, logEvent, probs.Malformed("Invalid notBefore, must be an RFC 3339 timestamp"), err)
+			return
+		}
+		notBefore = t.UnixNano()
+	}
+	if newOrderRequest.NotAfter != "" {
+		t, err := time.Parse(time.RFC3339, newOrderRequest.NotAfter)
+		if err != nil {
+			wfe.sendError(response, logEvent, probs.Malformed("Invalid notAfter, must be an RFC 3339 timestamp"), err)
+			return
+		}
+		notAfter = t.UnixNano()
 	}
 
 	var hasValidCNLen bool
-	// Collect up all of the DNS identifier values into a []string for
-	// subsequent layers to process. We reject anything with a non-DNS
-	// type identifier here. Check to make sure one of the strings is
+	// Collect up all of the identifier values into a []string for
+	// subsequent layers to process. We reject anything other than DNS, IP
+	// or JWT type identifiers here. Check to make sure one of the strings is
 	// short enough to meet the max CN bytes requirement.
 	names := make([]string, len(newOrderRequest.Identifiers))
-	var firstIdent identifier.IdentifierType
+	typeIdentifiers := make([]string, len(newOrderRequest.Identifiers))
+	var typeIdentifier identifier.IdentifierType
 	for i, ident := range newOrderRequest.Identifiers {
-		if firstIdent == "" || firstIdent == ident.Type {
-			firstIdent = ident.Type
-		} else {
-			wfe.sendError(response, logEvent, probs.Malformed("Only one identifier type supported"), nil)
-		}
-		if ident.Type != identifier.DNS && ident.Type != identifier.JWT {
+		if ident.Type != identifier.DNS && ident.Type != identifier.JWT && ident.Type != identifier.IP {
 			wfe.sendError(response, logEvent,
-				probs.Malformed("NewOrder request included invalid non-DNS and non-JWT type identifier: type %q, value %q",
+				probs.Malformed("NewOrder request included invalid non-DNS, non-IP and non-JWT type identifier: type %q, value %q",
 					ident.Type, ident.Value),
 				nil)
 			return
@@ -2238,7 +2275,20 @@ func (wfe *WebFrontEndImpl) NewOrder(
 			wfe.sendError(response, logEvent, probs.Malformed("NewOrder request included empty domain name"), nil)
 			return
 		}
+		// Orders may mix DNS and IP identifiers (RFC 8738), and are then of
+		// type IP. The type of each identifier is passed to the RA as well.
+		// JWT identifiers can't be combined with either.
+		if typeIdentifier == "" || typeIdentifier == ident.Type {
+			typeIdentifier = ident.Type
+		} else if typeIdentifier == identifier.JWT || ident.Type == identifier.JWT {
+			wfe.sendError(response, logEvent,
+				probs.Malformed("NewOrder request included JWT identifiers with identifiers of another type"), nil)
+			return
+		} else {
+			typeIdentifier = identifier.IP
+		}
 		names[i] = ident.Value
+		typeIdentifiers[i] = string(ident.Type)
 		// The max length of a CommonName is 64 bytes. Check to make sure
 		// at least one DNS name meets this requirement to be promoted to
 		// the CN.
@@ -2254,9 +2304,13 @@ func (wfe *WebFrontEndImpl) NewOrder(
 	}
 
 	order, err := wfe.ra.NewOrder(ctx, &rapb.NewOrderRequest{
-		RegistrationID: acct.ID,
-		Names:          names,
-		TypeIdentifier: string(firstIdent),
+		RegistrationID:  acct.ID,
+		Names:           names,
+		TypeIdentifier:  string(typeIdentifier),
+		TypeIdentifiers: typeIdentifiers,
+		NotBefore:       notBefore,
+		NotAfter:        notAfter,
+		CertProfile:     newOrderRequest.Profile,
 	})
 	if err != nil || order == nil || order.Id == 0 || order.Created == 0 || order.RegistrationID == 0 || order.Expires == 0 || len(order.Names) == 0 {
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error creating new order"), err)
@@ -2277,6 +2331,86 @@ func (wfe *WebFrontEndImpl) NewOrder(
 	}
 }
 
+// NewAuthorization is used by clients to pre-authorize an identifier before
+// creating an order for it, as described in RFC 8555 Section 7.4.1.
+func (wfe *WebFrontEndImpl) NewAuthorization(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) {
+	body, _, acct, prob := wfe.validPOSTForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if prob != nil {
+		// validPOSTForAccount handles its own setting of logEvent.Errors
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	var newAuthzRequest struct {
+		Identifier identifier.ACMEIdentifier `json:"identifier"`
+	}
+	err := json.Unmarshal(body, &newAuthzRequest)
+	if err != nil {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("Unable to unmarshal NewAuthorization request body"), err)
+		return
+	}
+
+	ident := newAuthzRequest.Identifier
+	if ident.Type != identifier.DNS && ident.Type != identifier.IP {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("NewAuthorization request included invalid non-DNS and non-IP type identifier: type %q, value %q",
+				ident.Type, ident.Value),
+			nil)
+		return
+	}
+	if ident.Value == "" {
+		wfe.sendError(response, logEvent, probs.Malformed("NewAuthorization request included empty identifier value"), nil)
+		return
+	}
+
+	resp, err := wfe.ra.NewAuthorization(ctx, &rapb.NewAuthorizationRequest{
+		RegistrationID: acct.ID,
+		Identifier:     ident.Value,
+		TypeIdentifier: string(ident.Type),
+	})
+	if err != nil || resp == nil || resp.Authz == nil || resp.Authz.Id == "" || resp.Authz.Identifier == "" || resp.Authz.Status == "" || resp.Authz.Expires == 0 {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error creating new authz"), err)
+		return
+	}
+	authzPB := resp.Authz
+	if !resp.Reused {
+		logEvent.Created = authzPB.Id
+	}
+	beeline.AddFieldToTrace(ctx, "authz.id", authzPB.Id)
+	if ident.Type == identifier.DNS {
+		logEvent.DNSName = authzPB.Identifier
+		beeline.AddFieldToTrace(ctx, "authz.dnsname", authzPB.Identifier)
+	}
+
+	authz, err := bgrpc.PBToAuthz(authzPB)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Problem getting authorization"), err)
+		return
+	}
+
+	response.Header().Set("Location", urlForAuthz(authz, request))
+
+	wfe.prepAuthorizationForDisplay(request, &authz)
+
+	// An existing authorization for the identifier is returned with 200 OK,
+	// since no new resource was created.
+	status := http.StatusCreated
+	if resp.Reused {
+		status = http.StatusOK
+	}
+	err = wfe.writeJsonResponse(response, logEvent, status, authz)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Failed to JSON marshal authz"), err)
+		return
+	}
+}
+
 // GetOrder is used to retrieve a existing order object
 func (wfe *WebFrontEndImpl) GetOrder(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
 	if features.Enabled(features.MandatoryPOSTAsGET) && request.Method != http.MethodPost && !requiredStale(request, logEvent) {
@@ -2285,15 +2419,20 @@ func (wfe *WebFrontEndImpl) GetOrder(ctx context.Context, logEvent *web.RequestE
 	}
 
 	var requesterAccount *core.Registration
-	// Any POSTs to the Order endpoint should be POST-as-GET requests. There are
-	// no POSTs with a body allowed for this endpoint.
+	var requestBody []byte
+	// If the request is a POST it is either:
+	//   A) an update to an order to deactivate it
+	//   B) a POST-as-GET to query the order details
 	if request.Method == http.MethodPost {
-		acct, prob := wfe.validPOSTAsGETForAccount(request, ctx, logEvent)
+		// Both POST options need to be authenticated by an account
+		body, _, acct, prob := wfe.validPOSTForAccount(request, ctx, logEvent)
+		addRequesterHeader(response, logEvent.Requester)
 		if prob != nil {
 			wfe.sendError(response, logEvent, prob, nil)
 			return
 		}
 		requesterAccount = acct
+		requestBody = body
 	}
 
 	// Path prefix is stripped, so this should be like "<account ID>/<order ID>"
@@ -2347,6 +2486,18 @@ func (wfe *WebFrontEndImpl) GetOrder(ctx context.Context, logEvent *web.RequestE
 		wfe.sendError(response, logEvent, probs.NotFound(fmt.Sprintf("No order found for account ID %d", acctID)), nil)
 		return
 	}
+
+	// If the body isn't empty we know it isn't a POST-as-GET and must be an
+	// attempt to deactivate the order.
+	if string(requestBody) != "" {
+		// If the deactivation fails return early as errors and return codes
+		// have already been set. Otherwise continue so that the user gets
+		// sent the deactivated order.
+		if !wfe.deactivateOrder(ctx, order, logEvent, response, requestBody) {
+			return
+		}
+	}
+
 	respObj := wfe.orderToOrderJSON(request, order)
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, respObj)
 	if err != nil {
@@ -2355,6 +2506,36 @@ func (wfe *WebFrontEndImpl) GetOrder(ctx context.Context, logEvent *web.RequestE
 	}
 }
 
+func (wfe *WebFrontEndImpl) deactivateOrder(
+	ctx context.Context,
+	order *corepb.Order,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	body []byte) bool {
+	var req struct {
+		Status core.AcmeStatus
+	}
+	err := json.Unmarshal(body, &req)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.Malformed("Error unmarshaling JSON"), err)
+		return false
+	}
+	if req.Status != core.StatusDeactivated {
+		wfe.sendError(response, logEvent, probs.Malformed("Invalid status value"), err)
+		return false
+	}
+	_, err = wfe.ra.DeactivateOrder(ctx, order)
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error deactivating order"), err)
+		return false
+	}
+	// Since the order passed to DeactivateOrder isn't mutated locally by the
+	// function we must manually set the status here before displaying the
+	// order to the user
+	order.Status = string(core.StatusDeactivated)
+	return true
+}
+
 // FinalizeOrder is used to request issuance for a existing order object.
 // Most processing of the order details is handled by the RA but
 // we do attempt to throw away requests with invalid CSRs here.
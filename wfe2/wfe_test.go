@@ -227,6 +227,10 @@ func (ra *MockRegistrationAuthority) DeactivateAuthorization(context.Context, *c
 	return &emptypb.Empty{}, nil
 }
 
+func (ra *MockRegistrationAuthority) DeactivateOrder(context.Context, *corepb.Order, ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
 func (ra *MockRegistrationAuthority) DeactivateRegistration(context.Context, *corepb.Registration, ...grpc.CallOption) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
@@ -238,11 +242,41 @@ func (ra *MockRegistrationAuthority) NewOrder(ctx context.Context, in *rapb.NewO
 		Created:          time.Date(2021, 1, 1, 1, 1, 1, 0, time.UTC).UnixNano(),
 		Expires:          time.Date(2021, 2, 1, 1, 1, 1, 0, time.UTC).UnixNano(),
 		Names:            in.Names,
+		TypeIdentifier:   in.TypeIdentifier,
 		Status:           string(core.StatusPending),
 		V2Authorizations: []int64{1},
+		NotBefore:        in.NotBefore,
+		NotAfter:         in.NotAfter,
+		CertProfile:      in.CertProfile,
 	}, nil
 }
 
+func (ra *MockRegistrationAuthority) UpdateAuthorization(context.Context, *rapb.UpdateAuthorizationRequest, ...grpc.CallOption) (*corepb.Authorization, error) {
+	return &corepb.Authorization{}, nil
+}
+
+// NewAuthorization returns a pending authz for the requested identifier. The
+// authz for "reused.example.com" is marked as an existing one.
+func (ra *MockRegistrationAuthority) NewAuthorization(ctx context.Context, in *rapb.NewAuthorizationRequest, _ ...grpc.CallOption) (*rapb.NewAuthorizationResponse, error) {
+	authz := &corepb.Authorization{
+		Id:             "1",
+		RegistrationID: in.RegistrationID,
+		Identifier:     in.Identifier,
+		TypeIdentifier: in.TypeIdentifier,
+		Status:         string(core.StatusPending),
+		Expires:        time.Date(2021, 2, 1, 1, 1, 1, 0, time.UTC).UnixNano(),
+		Challenges: []*corepb.Challenge{
+			{
+				Id:     1,
+				Type:   string(core.ChallengeTypeHTTP01),
+				Status: string(core.StatusPending),
+				Token:  "token",
+			},
+		},
+	}
+	return &rapb.NewAuthorizationResponse{Authz: authz, Reused: in.Identifier == "reused.example.com"}, nil
+}
+
 func (ra *MockRegistrationAuthority) FinalizeOrder(ctx context.Context, in *rapb.FinalizeOrderRequest, _ ...grpc.CallOption) (*corepb.Order, error) {
 	in.Order.Status = string(core.StatusProcessing)
 	return in.Order, nil
@@ -716,6 +750,7 @@ func TestDirectory(t *testing.T) {
 		name         string
 		caaIdent     string
 		website      string
+		profiles     map[string]string
 		expectedJSON string
 		request      *http.Request
 	}{
@@ -730,6 +765,7 @@ func TestDirectory(t *testing.T) {
   "newNonce": "http://localhost:4300/acme/new-nonce",
   "newAccount": "http://localhost:4300/acme/new-acct",
   "newOrder": "http://localhost:4300/acme/new-order",
+  "newAuthz": "http://localhost:4300/acme/new-authz",
   "revokeCert": "http://localhost:4300/acme/revoke-cert",
   "AAAAAAAAAAA": "https://community.letsencrypt.org/t/adding-random-entries-to-the-directory/33417"
 }`,
@@ -752,6 +788,7 @@ func TestDirectory(t *testing.T) {
   "newAccount": "http://localhost:4300/acme/new-acct",
   "newNonce": "http://localhost:4300/acme/new-nonce",
   "newOrder": "http://localhost:4300/acme/new-order",
+  "newAuthz": "http://localhost:4300/acme/new-authz",
   "revokeCert": "http://localhost:4300/acme/revoke-cert"
 }`,
 		},
@@ -773,16 +810,39 @@ func TestDirectory(t *testing.T) {
   "newAccount": "http://localhost/acme/new-acct",
   "newNonce": "http://localhost/acme/new-nonce",
   "newOrder": "http://localhost/acme/new-order",
+  "newAuthz": "http://localhost/acme/new-authz",
   "revokeCert": "http://localhost/acme/revoke-cert"
+}`,
+		},
+		{
+			name:     "standard GET, profiles meta",
+			profiles: map[string]string{"classic": "90-day serverAuth certificates", "shortlived": "7-day certificates without OCSP"},
+			request:  getReq,
+			expectedJSON: `{
+  "AAAAAAAAAAA": "https://community.letsencrypt.org/t/adding-random-entries-to-the-directory/33417",
+  "keyChange": "http://localhost:4300/acme/key-change",
+  "meta": {
+    "profiles": {
+      "classic": "90-day serverAuth certificates",
+      "shortlived": "7-day certificates without OCSP"
+    },
+    "termsOfService": "http://example.invalid/terms"
+  },
+  "newAccount": "http://localhost:4300/acme/new-acct",
+  "newNonce": "http://localhost:4300/acme/new-nonce",
+  "newOrder": "http://localhost:4300/acme/new-order",
+  "newAuthz": "http://localhost:4300/acme/new-authz",
+  "revokeCert": "http://localhost:4300/acme/revoke-cert"
 }`,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			// Configure a caaIdentity and website for the /directory meta based on the tc
+			// Configure a caaIdentity, website and profiles for the /directory meta based on the tc
 			wfe.DirectoryCAAIdentity = tc.caaIdent // "Radiant Lock"
 			wfe.DirectoryWebsite = tc.website      //"zombo.com"
+			wfe.DirectoryProfiles = tc.profiles
 			responseWriter := httptest.NewRecorder()
 			// Serve the /directory response for this request into a recorder
 			mux.ServeHTTP(responseWriter, tc.request)
@@ -814,6 +874,7 @@ func TestRelativeDirectory(t *testing.T) {
 		fmt.Fprintf(expected, `"newNonce":"%s/acme/new-nonce",`, hostname)
 		fmt.Fprintf(expected, `"newAccount":"%s/acme/new-acct",`, hostname)
 		fmt.Fprintf(expected, `"newOrder":"%s/acme/new-order",`, hostname)
+		fmt.Fprintf(expected, `"newAuthz":"%s/acme/new-authz",`, hostname)
 		fmt.Fprintf(expected, `"revokeCert":"%s/acme/revoke-cert",`, hostname)
 		fmt.Fprintf(expected, `"AAAAAAAAAAA":"https://community.letsencrypt.org/t/adding-random-entries-to-the-directory/33417",`)
 		fmt.Fprintf(expected, `"meta":{"termsOfService":"http://example.invalid/terms"}`)
@@ -2449,12 +2510,52 @@ func TestNewOrder(t *testing.T) {
 		{
 			Name:         "POST, invalid identifier in payload",
 			Request:      signAndPost(t, targetPath, signedURL, nonDNSIdentifierBody, 1, wfe.nonceService),
-			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included invalid non-DNS type identifier: type \"fakeID\", value \"www.i-am-21.com\"","status":400}`,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included invalid non-DNS, non-IP and non-JWT type identifier: type \"fakeID\", value \"www.i-am-21.com\"","status":400}`,
 		},
 		{
-			Name:         "POST, notAfter and notBefore in payload",
-			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notBefore":"now", "notAfter": "later"}`, 1, wfe.nonceService),
-			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NotBefore and NotAfter are not supported","status":400}`,
+			Name:         "POST, invalid notBefore in payload",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notBefore":"now", "notAfter": "2021-01-08T01:01:01Z"}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Invalid notBefore, must be an RFC 3339 timestamp","status":400}`,
+		},
+		{
+			Name:         "POST, invalid notAfter in payload",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notAfter": "later"}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Invalid notAfter, must be an RFC 3339 timestamp","status":400}`,
+		},
+		{
+			Name:    "POST, good payload with notBefore and notAfter",
+			Request: signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notBefore":"2021-01-01T01:01:01Z", "notAfter": "2021-01-08T01:01:01Z"}`, 1, wfe.nonceService),
+			ExpectedBody: `
+			{
+				"status": "pending",
+				"expires": "2021-02-01T01:01:01Z",
+				"identifiers": [
+					{ "type": "dns", "value": "not-example.com"}
+				],
+				"notBefore": "2021-01-01T01:01:01Z",
+				"notAfter": "2021-01-08T01:01:01Z",
+				"authorizations": [
+					"http://localhost/acme/authz-v3/1"
+				],
+				"finalize": "http://localhost/acme/finalize/1/1"
+			}`,
+		},
+		{
+			Name:    "POST, good payload with profile",
+			Request: signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "profile":"shortlived"}`, 1, wfe.nonceService),
+			ExpectedBody: `
+			{
+				"status": "pending",
+				"expires": "2021-02-01T01:01:01Z",
+				"identifiers": [
+					{ "type": "dns", "value": "not-example.com"}
+				],
+				"profile": "shortlived",
+				"authorizations": [
+					"http://localhost/acme/authz-v3/1"
+				],
+				"finalize": "http://localhost/acme/finalize/1/1"
+			}`,
 		},
 		{
 			Name:         "POST, no potential CNs 64 bytes or smaller",
@@ -2478,6 +2579,28 @@ func TestNewOrder(t *testing.T) {
 				"finalize": "http://localhost/acme/finalize/1/1"
 			}`,
 		},
+		{
+			Name:    "POST, good payload mixing DNS and IP identifiers",
+			Request: signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}, {"type": "ip", "value": "192.0.2.1"}]}`, 1, wfe.nonceService),
+			ExpectedBody: `
+			{
+				"status": "pending",
+				"expires": "2021-02-01T01:01:01Z",
+				"identifiers": [
+					{ "type": "dns", "value": "not-example.com"},
+					{ "type": "ip", "value": "192.0.2.1"}
+				],
+				"authorizations": [
+					"http://localhost/acme/authz-v3/1"
+				],
+				"finalize": "http://localhost/acme/finalize/1/1"
+			}`,
+		},
+		{
+			Name:         "POST, JWT identifier mixed with DNS identifier",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}, {"type": "jwt", "value": "abc"}]}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included JWT identifiers with identifiers of another type","status":400}`,
+		},
 		{
 			Name:    "POST, good payload",
 			Request: signAndPost(t, targetPath, signedURL, validOrderBody, 1, wfe.nonceService),
@@ -2522,6 +2645,127 @@ func TestNewOrder(t *testing.T) {
 	}
 }
 
+// newOrderCapturingRA is a mock RA that records the last NewOrder request.
+type newOrderCapturingRA struct {
+	MockRegistrationAuthority
+	lastRequest *rapb.NewOrderRequest
+}
+
+func (ra *newOrderCapturingRA) NewOrder(ctx context.Context, in *rapb.NewOrderRequest, opts ...grpc.CallOption) (*corepb.Order, error) {
+	ra.lastRequest = in
+	return ra.MockRegistrationAuthority.NewOrder(ctx, in, opts...)
+}
+
+func TestNewOrderIdentifierTypes(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	ra := &newOrderCapturingRA{}
+	wfe.ra = ra
+
+	signedURL := "http://localhost/new-order"
+	body := `{"identifiers":[{"type": "dns", "value": "not-example.com"}, {"type": "dns", "value": "192.0.2.1"}, {"type": "ip", "value": "192.0.2.2"}]}`
+	responseWriter := httptest.NewRecorder()
+	wfe.NewOrder(ctx, newRequestEvent(), responseWriter, signAndPost(t, "new-order", signedURL, body, 1, wfe.nonceService))
+
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertDeepEquals(t, ra.lastRequest.Names, []string{"not-example.com", "192.0.2.1", "192.0.2.2"})
+	test.AssertDeepEquals(t, ra.lastRequest.TypeIdentifiers, []string{"dns", "dns", "ip"})
+}
+
+func TestNewAuthorization(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	responseWriter := httptest.NewRecorder()
+
+	targetHost := "localhost"
+	targetPath := "new-authz"
+	signedURL := fmt.Sprintf("http://%s/%s", targetHost, targetPath)
+
+	testCases := []struct {
+		Name            string
+		Request         *http.Request
+		ExpectedStatus  int
+		ExpectedBody    string
+		ExpectedHeaders map[string]string
+	}{
+		{
+			Name:           "POST, properly signed JWS, payload isn't valid",
+			Request:        signAndPost(t, targetPath, signedURL, "foo", 1, wfe.nonceService),
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Request payload did not parse as JSON","status":400}`,
+		},
+		{
+			Name:           "POST, empty identifier value",
+			Request:        signAndPost(t, targetPath, signedURL, `{"identifier":{"type":"dns","value":""}}`, 1, wfe.nonceService),
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewAuthorization request included empty identifier value","status":400}`,
+		},
+		{
+			Name:           "POST, invalid identifier type",
+			Request:        signAndPost(t, targetPath, signedURL, `{"identifier":{"type":"jwt","value":"abc"}}`, 1, wfe.nonceService),
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewAuthorization request included invalid non-DNS and non-IP type identifier: type \"jwt\", value \"abc\"","status":400}`,
+		},
+		{
+			Name:           "POST, good payload",
+			Request:        signAndPost(t, targetPath, signedURL, `{"identifier":{"type":"dns","value":"not-example.com"}}`, 1, wfe.nonceService),
+			ExpectedStatus: http.StatusCreated,
+			ExpectedBody: `
+			{
+				"identifier": {"type": "dns", "value": "not-example.com"},
+				"status": "pending",
+				"expires": "2021-02-01T01:01:01Z",
+				"challenges": [
+					{
+						"type": "http-01",
+						"status": "pending",
+						"url": "http://localhost/acme/chall-v3/1/7TyhFQ",
+						"token": "token"
+					}
+				]
+			}`,
+			ExpectedHeaders: map[string]string{
+				"Location": "http://localhost/acme/authz-v3/1",
+			},
+		},
+		{
+			Name:           "POST, good payload, existing authz",
+			Request:        signAndPost(t, targetPath, signedURL, `{"identifier":{"type":"dns","value":"reused.example.com"}}`, 1, wfe.nonceService),
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: `
+			{
+				"identifier": {"type": "dns", "value": "reused.example.com"},
+				"status": "pending",
+				"expires": "2021-02-01T01:01:01Z",
+				"challenges": [
+					{
+						"type": "http-01",
+						"status": "pending",
+						"url": "http://localhost/acme/chall-v3/1/7TyhFQ",
+						"token": "token"
+					}
+				]
+			}`,
+			ExpectedHeaders: map[string]string{
+				"Location": "http://localhost/acme/authz-v3/1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			responseWriter = httptest.NewRecorder()
+
+			wfe.NewAuthorization(ctx, newRequestEvent(), responseWriter, tc.Request)
+			test.AssertEquals(t, responseWriter.Code, tc.ExpectedStatus)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.ExpectedBody)
+
+			headers := responseWriter.Header()
+			for k, v := range tc.ExpectedHeaders {
+				test.AssertEquals(t, headers.Get(k), v)
+			}
+		})
+	}
+}
+
 func TestFinalizeOrder(t *testing.T) {
 	wfe, _ := setupWFE(t)
 	responseWriter := httptest.NewRecorder()
@@ -2827,9 +3071,14 @@ func TestGetOrder(t *testing.T) {
 			Response: `{"type":"` + probs.V2ErrorNS + `serverInternal","detail":"Failed to retrieve order for ID 3","status":500}`,
 		},
 		{
-			Name:     "Invalid POST-as-GET",
+			Name:     "POST with invalid status",
 			Request:  makePost(1, "1/1", "{}"),
-			Response: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"POST-as-GET requests must have an empty payload", "status":400}`,
+			Response: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Invalid status value", "status":400}`,
+		},
+		{
+			Name:     "Deactivate order",
+			Request:  makePost(1, "1/4", `{"status":"deactivated"}`),
+			Response: `{"status": "deactivated","expires": "1970-01-01T00:00:00.9466848Z","identifiers":[{"type":"dns", "value":"example.com"}], "authorizations":["http://localhost/acme/authz-v3/1"],"finalize":"http://localhost/acme/finalize/1/4"}`,
 		},
 		{
 			Name:     "Valid POST-as-GET, wrong account",
@@ -3332,6 +3581,42 @@ func TestPrepAuthzForDisplay(t *testing.T) {
 	test.AssertEquals(t, chal.ProvidedKeyAuthorization, "")
 }
 
+func TestPrepAuthzForDisplayDNSAccount01(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	// Make an authz for a wildcard identifier offering both DNS-based
+	// challenges
+	authz := &core.Authorization{
+		ID:             "12345",
+		Status:         core.StatusPending,
+		RegistrationID: 1,
+		Identifier:     identifier.DNSIdentifier("*.example.com"),
+		Challenges: []core.Challenge{
+			core.DNSChallenge01("token"),
+			core.DNSAccountChallenge01("token"),
+		},
+	}
+	for i := range authz.Challenges {
+		authz.Challenges[i].ProvidedKeyAuthorization = "token.key"
+	}
+
+	wfe.prepAuthorizationForDisplay(&http.Request{Host: "localhost"}, authz)
+	test.AssertEquals(t, authz.Wildcard, true)
+	test.AssertEquals(t, authz.Identifier.Value, "example.com")
+	test.AssertEquals(t, len(authz.Challenges), 2)
+
+	chal := authz.Challenges[1]
+	test.AssertEquals(t, chal.Type, core.ChallengeTypeDNSAccount01)
+	test.AssertEquals(t, chal.URL, fmt.Sprintf("http://localhost/acme/chall-v3/12345/%s", chal.StringID()))
+	test.AssertEquals(t, chal.ProvidedKeyAuthorization, "")
+	test.AssertNotEquals(t, chal.StringID(), authz.Challenges[0].StringID())
+
+	chalJSON, err := json.Marshal(chal)
+	test.AssertNotError(t, err, "marshaling dns-account-01 challenge")
+	test.AssertContains(t, string(chalJSON), `"type":"dns-account-01"`)
+	test.AssertContains(t, string(chalJSON), `"token":"token"`)
+}
+
 // noSCTMockRA is a mock RA that always returns a `berrors.MissingSCTsError` from `FinalizeOrder`
 type noSCTMockRA struct {
 	MockRegistrationAuthority